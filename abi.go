@@ -0,0 +1,439 @@
+package aptos
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/aptos-labs/aptos-go-sdk/api"
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+	"io"
+	"math/big"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ParseFunctionId parses a function id of the form address::module::function e.g. 0x1::coin::balance
+func ParseFunctionId(functionId string) (module ModuleId, function string, err error) {
+	parts := strings.Split(functionId, "::")
+	if len(parts) != 3 || parts[1] == "" || parts[2] == "" {
+		return module, "", fmt.Errorf("invalid function id %s, expected address::module::function", functionId)
+	}
+	err = module.Address.ParseStringRelaxed(parts[0])
+	if err != nil {
+		return module, "", fmt.Errorf("invalid address in function id %s: %w", functionId, err)
+	}
+	module.Name = parts[1]
+	return module, parts[2], nil
+}
+
+// FunctionAbi fetches the ABI of a function from its module, modules are cached as in [NodeClient.AccountModule]
+func (rc *NodeClient) FunctionAbi(module ModuleId, function string, ledgerVersion ...uint64) (abi *api.MoveFunction, err error) {
+	return rc.FunctionAbiCtx(context.Background(), module, function, ledgerVersion...)
+}
+
+// FunctionAbiCtx is [NodeClient.FunctionAbi] with a [context.Context] for cancellation and deadlines
+func (rc *NodeClient) FunctionAbiCtx(ctx context.Context, module ModuleId, function string, ledgerVersion ...uint64) (abi *api.MoveFunction, err error) {
+	bytecode, err := rc.AccountModuleCtx(ctx, module.Address, module.Name, ledgerVersion...)
+	if err != nil {
+		return nil, err
+	}
+	if bytecode.Abi == nil {
+		return nil, fmt.Errorf("module %s::%s has no ABI", module.Address.String(), module.Name)
+	}
+	for _, exposed := range bytecode.Abi.ExposedFunctions {
+		if exposed.Name == function {
+			return exposed, nil
+		}
+	}
+	return nil, fmt.Errorf("function %s not found in module %s::%s", function, module.Address.String(), module.Name)
+}
+
+// EntryFunctionFromAbi builds an entry function payload from its function id, type arguments, and arguments, using the
+// function's ABI to serialize each argument.  Leading signer parameters are not passed as arguments.
+//
+// Arguments may be Go values or strings, e.g. from a config file or an HTTP request:
+//   - bool, integers, [big.Int], [AccountAddress], and string are converted to the parameter type, strings are parsed
+//   - vector<u8> takes a []byte, a 0x prefixed hex string, or any other string as UTF-8 bytes
+//   - vector<T> takes a slice, or a string of a JSON array
+//   - 0x1::string::String takes a string, and 0x1::object::Object<T> takes an address
+//   - 0x1::option::Option<T> takes nil or a nil pointer for none, or a value of T
+//   - Any [bcs.Marshaler] is serialized as is
+//
+// For example:
+//
+//	payload, err := client.EntryFunctionFromAbi("0x1::aptos_account::transfer_coins", []string{"0x1::aptos_coin::AptosCoin"}, []any{"0xcafe", "100"})
+func (rc *NodeClient) EntryFunctionFromAbi(functionId string, typeArgs []string, args []any, ledgerVersion ...uint64) (payload *EntryFunction, err error) {
+	return rc.EntryFunctionFromAbiCtx(context.Background(), functionId, typeArgs, args, ledgerVersion...)
+}
+
+// EntryFunctionFromAbiCtx is [NodeClient.EntryFunctionFromAbi] with a [context.Context] for cancellation and deadlines
+func (rc *NodeClient) EntryFunctionFromAbiCtx(ctx context.Context, functionId string, typeArgs []string, args []any, ledgerVersion ...uint64) (payload *EntryFunction, err error) {
+	module, function, typeTags, argBytes, err := rc.serializeFunctionArgs(ctx, functionId, typeArgs, args, true, ledgerVersion...)
+	if err != nil {
+		return nil, err
+	}
+	return &EntryFunction{Module: module, Function: function, ArgTypes: typeTags, Args: argBytes}, nil
+}
+
+// ViewPayloadFromAbi builds a view function payload from its function id, type arguments, and arguments, using the
+// function's ABI to serialize each argument.  Arguments are converted as in [NodeClient.EntryFunctionFromAbi].
+//
+//	payload, err := client.ViewPayloadFromAbi("0x1::coin::balance", []string{"0x1::aptos_coin::AptosCoin"}, []any{"0xcafe"})
+//	balance, err := ViewInto[uint64](client, payload)
+func (rc *NodeClient) ViewPayloadFromAbi(functionId string, typeArgs []string, args []any, ledgerVersion ...uint64) (payload *ViewPayload, err error) {
+	return rc.ViewPayloadFromAbiCtx(context.Background(), functionId, typeArgs, args, ledgerVersion...)
+}
+
+// ViewPayloadFromAbiCtx is [NodeClient.ViewPayloadFromAbi] with a [context.Context] for cancellation and deadlines
+func (rc *NodeClient) ViewPayloadFromAbiCtx(ctx context.Context, functionId string, typeArgs []string, args []any, ledgerVersion ...uint64) (payload *ViewPayload, err error) {
+	module, function, typeTags, argBytes, err := rc.serializeFunctionArgs(ctx, functionId, typeArgs, args, false, ledgerVersion...)
+	if err != nil {
+		return nil, err
+	}
+	return &ViewPayload{Module: module, Function: function, ArgTypes: typeTags, Args: argBytes}, nil
+}
+
+func (rc *NodeClient) serializeFunctionArgs(ctx context.Context, functionId string, typeArgs []string, args []any, entry bool, ledgerVersion ...uint64) (module ModuleId, function string, typeTags []TypeTag, argBytes [][]byte, err error) {
+	module, function, err = ParseFunctionId(functionId)
+	if err != nil {
+		return
+	}
+	abi, err := rc.FunctionAbiCtx(ctx, module, function, ledgerVersion...)
+	if err != nil {
+		return
+	}
+	if entry && !abi.IsEntry {
+		err = fmt.Errorf("%s is not an entry function", functionId)
+		return
+	}
+	if !entry && !abi.IsView {
+		err = fmt.Errorf("%s is not a view function", functionId)
+		return
+	}
+	if len(typeArgs) != len(abi.GenericTypeParams) {
+		err = fmt.Errorf("%s takes %d type arguments, got %d", functionId, len(abi.GenericTypeParams), len(typeArgs))
+		return
+	}
+
+	typeTags = make([]TypeTag, len(typeArgs))
+	for i, typeArg := range typeArgs {
+		var tag *TypeTag
+		tag, err = ParseTypeTag(typeArg)
+		if err != nil {
+			return
+		}
+		typeTags[i] = *tag
+	}
+
+	// Signers are provided by the transaction, not as arguments
+	params := abi.Params
+	for len(params) > 0 && (params[0] == "signer" || params[0] == "&signer") {
+		params = params[1:]
+	}
+	if len(args) != len(params) {
+		err = fmt.Errorf("%s takes %d arguments, got %d", functionId, len(params), len(args))
+		return
+	}
+
+	argBytes = make([][]byte, len(args))
+	for i, param := range params {
+		var paramType *TypeTag
+		paramType, err = parseTypeTag(param, typeTags)
+		if err != nil {
+			return
+		}
+		argBytes[i], err = bcs.SerializeSingle(func(ser *bcs.Serializer) {
+			serializeArgument(ser, paramType, args[i])
+		})
+		if err != nil {
+			err = fmt.Errorf("argument %d of %s as %s: %w", i, functionId, paramType.String(), err)
+			return
+		}
+	}
+	return
+}
+
+// serializeArgument serializes a Go value or string as the given Move type
+func serializeArgument(ser *bcs.Serializer, paramType *TypeTag, value any) {
+	if marshaler, ok := value.(bcs.Marshaler); ok && !isNilValue(value) && !isOptionTag(paramType) {
+		marshaler.MarshalBCS(ser)
+		return
+	}
+
+	switch tag := paramType.Value.(type) {
+	case *BoolTag:
+		switch b := derefValue(value).(type) {
+		case bool:
+			ser.Bool(b)
+		case string:
+			parsed, err := strconv.ParseBool(b)
+			if err != nil {
+				ser.SetError(err)
+				return
+			}
+			ser.Bool(parsed)
+		default:
+			ser.SetError(fmt.Errorf("expected a bool, got %T", value))
+		}
+	case *U8Tag:
+		if num, ok := argumentUint(ser, value, 8); ok {
+			ser.U8(uint8(num.Uint64()))
+		}
+	case *U16Tag:
+		if num, ok := argumentUint(ser, value, 16); ok {
+			ser.U16(uint16(num.Uint64()))
+		}
+	case *U32Tag:
+		if num, ok := argumentUint(ser, value, 32); ok {
+			ser.U32(uint32(num.Uint64()))
+		}
+	case *U64Tag:
+		if num, ok := argumentUint(ser, value, 64); ok {
+			ser.U64(num.Uint64())
+		}
+	case *U128Tag:
+		if num, ok := argumentUint(ser, value, 128); ok {
+			ser.U128(*num)
+		}
+	case *U256Tag:
+		if num, ok := argumentUint(ser, value, 256); ok {
+			ser.U256(*num)
+		}
+	case *AddressTag:
+		if address, ok := argumentAddress(ser, value); ok {
+			ser.Struct(&address)
+		}
+	case *SignerTag:
+		ser.SetError(fmt.Errorf("signer can't be passed as an argument"))
+	case *VectorTag:
+		serializeVectorArgument(ser, tag, value)
+	case *StructTag:
+		serializeStructArgument(ser, tag, value)
+	default:
+		ser.SetError(fmt.Errorf("unsupported argument type %s", paramType.String()))
+	}
+}
+
+func serializeVectorArgument(ser *bcs.Serializer, tag *VectorTag, value any) {
+	value = derefValue(value)
+	if _, ok := tag.TypeParam.Value.(*U8Tag); ok {
+		switch bytes := value.(type) {
+		case []byte:
+			ser.WriteBytes(bytes)
+			return
+		case string:
+			if strings.HasPrefix(bytes, "0x") {
+				parsed, err := ParseHex(bytes)
+				if err != nil {
+					ser.SetError(err)
+					return
+				}
+				ser.WriteBytes(parsed)
+			} else {
+				ser.WriteBytes([]byte(bytes))
+			}
+			return
+		}
+	}
+	if str, ok := value.(string); ok {
+		var items []any
+		if err := json.Unmarshal([]byte(str), &items); err != nil {
+			ser.SetError(fmt.Errorf("expected a JSON array for %s: %w", tag.String(), err))
+			return
+		}
+		value = items
+	}
+
+	items := reflect.ValueOf(value)
+	if items.Kind() != reflect.Slice && items.Kind() != reflect.Array {
+		ser.SetError(fmt.Errorf("expected a slice for %s, got %T", tag.String(), value))
+		return
+	}
+	ser.Uleb128(uint32(items.Len()))
+	for i := 0; i < items.Len(); i++ {
+		serializeArgument(ser, &tag.TypeParam, items.Index(i).Interface())
+		if ser.Error() != nil {
+			ser.SetError(fmt.Errorf("vector[%d]: %w", i, ser.Error()))
+			return
+		}
+	}
+}
+
+func serializeStructArgument(ser *bcs.Serializer, tag *StructTag, value any) {
+	if tag.Address != AccountOne {
+		ser.SetError(fmt.Errorf("unsupported argument type %s", tag.String()))
+		return
+	}
+	switch tag.Module + "::" + tag.Name {
+	case "string::String":
+		str, ok := derefValue(value).(string)
+		if !ok {
+			ser.SetError(fmt.Errorf("expected a string, got %T", value))
+			return
+		}
+		ser.WriteString(str)
+	case "object::Object":
+		if address, ok := argumentAddress(ser, value); ok {
+			ser.Struct(&address)
+		}
+	case "option::Option":
+		// An Option is serialized as a vector of zero or one values
+		if isNilValue(value) {
+			ser.Uleb128(0)
+			return
+		}
+		ser.Uleb128(1)
+		serializeArgument(ser, &tag.TypeParams[0], derefValue(value))
+	default:
+		ser.SetError(fmt.Errorf("unsupported argument type %s", tag.String()))
+	}
+}
+
+// argumentUint converts a Go integer, big.Int, JSON number, or decimal string to a number of at most the given bits
+func argumentUint(ser *bcs.Serializer, value any, bits int) (*big.Int, bool) {
+	num := new(big.Int)
+	switch v := derefValue(value).(type) {
+	case big.Int:
+		num.Set(&v)
+	case string:
+		if _, ok := num.SetString(v, 10); !ok {
+			ser.SetError(fmt.Errorf("invalid number %s", v))
+			return nil, false
+		}
+	case json.Number:
+		if _, ok := num.SetString(v.String(), 10); !ok {
+			ser.SetError(fmt.Errorf("invalid number %s", v))
+			return nil, false
+		}
+	case float64:
+		if _, accuracy := big.NewFloat(v).Int(num); accuracy != big.Exact {
+			ser.SetError(fmt.Errorf("invalid number %v", v))
+			return nil, false
+		}
+	default:
+		rv := reflect.ValueOf(v)
+		switch rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			num.SetInt64(rv.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			num.SetUint64(rv.Uint())
+		default:
+			ser.SetError(fmt.Errorf("expected a number, got %T", value))
+			return nil, false
+		}
+	}
+	if num.Sign() < 0 || num.BitLen() > bits {
+		ser.SetError(fmt.Errorf("%s out of range for u%d", num.String(), bits))
+		return nil, false
+	}
+	return num, true
+}
+
+// argumentAddress converts an AccountAddress or string to an AccountAddress
+func argumentAddress(ser *bcs.Serializer, value any) (address AccountAddress, ok bool) {
+	switch v := derefValue(value).(type) {
+	case AccountAddress:
+		return v, true
+	case string:
+		err := address.ParseStringRelaxed(v)
+		if err != nil {
+			ser.SetError(err)
+			return address, false
+		}
+		return address, true
+	default:
+		ser.SetError(fmt.Errorf("expected an address, got %T", value))
+		return address, false
+	}
+}
+
+// isOptionTag returns true for 0x1::option::Option<T>
+func isOptionTag(tag *TypeTag) bool {
+	structTag, ok := tag.Value.(*StructTag)
+	return ok && structTag.Address == AccountOne && structTag.Module == "option" && structTag.Name == "Option"
+}
+
+// derefValue follows pointers to the underlying value
+func derefValue(value any) any {
+	rv := reflect.ValueOf(value)
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if !rv.IsValid() {
+		return nil
+	}
+	return rv.Interface()
+}
+
+// isNilValue returns true for nil and nil pointers
+func isNilValue(value any) bool {
+	if value == nil {
+		return true
+	}
+	rv := reflect.ValueOf(value)
+	return rv.Kind() == reflect.Pointer && rv.IsNil()
+}
+
+//region ViewJSONPayload
+
+// ViewJSONPayload is a view function request with JSON arguments, for when arguments aren't BCS encoded.  Arguments
+// follow the JSON API, e.g. u64 and larger numbers are strings and vector<u8> is a hex string.
+type ViewJSONPayload struct {
+	Function      string   `json:"function"`       // Function id e.g. 0x1::coin::balance
+	TypeArguments []string `json:"type_arguments"` // Type arguments e.g. 0x1::aptos_coin::AptosCoin
+	Arguments     []any    `json:"arguments"`
+}
+
+// ViewJSON runs a view function on chain with JSON arguments, returning a list of return values
+//
+//	values, err := client.ViewJSON(&ViewJSONPayload{
+//		Function:      "0x1::coin::balance",
+//		TypeArguments: []string{"0x1::aptos_coin::AptosCoin"},
+//		Arguments:     []any{"0xcafe"},
+//	})
+func (rc *NodeClient) ViewJSON(payload *ViewJSONPayload, ledgerVersion ...uint64) (data []any, err error) {
+	return rc.ViewJSONCtx(context.Background(), payload, ledgerVersion...)
+}
+
+// ViewJSONCtx is [NodeClient.ViewJSON] with a [context.Context] for cancellation and deadlines
+func (rc *NodeClient) ViewJSONCtx(ctx context.Context, payload *ViewJSONPayload, ledgerVersion ...uint64) (data []any, err error) {
+	// The API rejects nulls for the lists
+	request := *payload
+	if request.TypeArguments == nil {
+		request.TypeArguments = []string{}
+	}
+	if request.Arguments == nil {
+		request.Arguments = []any{}
+	}
+	body, err := json.Marshal(&request)
+	if err != nil {
+		return nil, err
+	}
+	au := rc.baseUrl.JoinPath("view")
+	if len(ledgerVersion) > 0 {
+		params := url.Values{}
+		params.Set("ledger_version", strconv.FormatUint(ledgerVersion[0], 10))
+		au.RawQuery = params.Encode()
+	}
+	response, _, err := rc.postRetryCtx(ctx, au.String(), "application/json", body)
+	if err != nil {
+		err = fmt.Errorf("POST %s, %w", au.String(), err)
+		return
+	}
+	if response.StatusCode >= 400 {
+		err = NewHttpError(response)
+		return nil, err
+	}
+	blob, err := io.ReadAll(response.Body)
+	if err != nil {
+		err = fmt.Errorf("error getting response data, %w", err)
+		return
+	}
+	_ = response.Body.Close()
+	err = json.Unmarshal(blob, &data)
+	return
+}
+
+//endregion
@@ -0,0 +1,145 @@
+package aptos
+
+import (
+	"encoding/json"
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseFunctionId(t *testing.T) {
+	module, function, err := ParseFunctionId("0x1::coin::balance")
+	assert.NoError(t, err)
+	assert.Equal(t, ModuleId{Address: AccountOne, Name: "coin"}, module)
+	assert.Equal(t, "balance", function)
+
+	for _, functionId := range []string{"", "0x1::coin", "0x1::coin::", "zz::coin::balance", "0x1::coin::balance::extra"} {
+		_, _, err = ParseFunctionId(functionId)
+		assert.Error(t, err, functionId)
+	}
+}
+
+func TestEntryFunctionFromAbi(t *testing.T) {
+	const testModule = `{"bytecode":"0xa11ceb0b","abi":{"address":"0x1","name":"test","friends":[],"exposed_functions":[
+		{"name":"everything","visibility":"public","is_entry":true,"is_view":false,"generic_type_params":[{"constraints":[]}],
+		 "params":["&signer","bool","u8","u16","u32","u64","u128","u256","address","vector<u8>","vector<u64>","0x1::string::String","0x1::option::Option<address>","0x1::object::Object<T0>","vector<T0>"],"return":[]},
+		{"name":"balance","visibility":"public","is_entry":false,"is_view":true,"generic_type_params":[{"constraints":[]}],
+		 "params":["address"],"return":["u64"]}
+	],"structs":[]}}`
+	var viewRequest map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/accounts/" + AccountOne.String() + "/module/test":
+			_, _ = w.Write([]byte(testModule))
+		case "/v1/view":
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.NoError(t, json.Unmarshal(body, &viewRequest))
+			_, _ = w.Write([]byte(`["100"]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewNodeClient(server.URL+"/v1", 4)
+	assert.NoError(t, err)
+
+	u256, _ := new(big.Int).SetString("115792089237316195423570985008687907853269984665640564039457584007913129639935", 10)
+	payload, err := client.EntryFunctionFromAbi("0x1::test::everything", []string{"u8"}, []any{
+		"true",
+		uint8(1),
+		"2",
+		float64(3),
+		json.Number("18446744073709551615"),
+		big.NewInt(6),
+		u256,
+		"0xcafe",
+		"0x0102",
+		`["7", 8]`,
+		"hello",
+		&AccountOne,
+		AccountOne,
+		[]int{9},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, ModuleId{Address: AccountOne, Name: "test"}, payload.Module)
+	assert.Equal(t, "everything", payload.Function)
+	assert.Equal(t, []TypeTag{{Value: &U8Tag{}}}, payload.ArgTypes)
+
+	cafe := AccountAddress{30: 0xca, 31: 0xfe}
+	expected := []func(ser *bcs.Serializer){
+		func(ser *bcs.Serializer) { ser.Bool(true) },
+		func(ser *bcs.Serializer) { ser.U8(1) },
+		func(ser *bcs.Serializer) { ser.U16(2) },
+		func(ser *bcs.Serializer) { ser.U32(3) },
+		func(ser *bcs.Serializer) { ser.U64(18446744073709551615) },
+		func(ser *bcs.Serializer) { ser.U128(*big.NewInt(6)) },
+		func(ser *bcs.Serializer) { ser.U256(*u256) },
+		func(ser *bcs.Serializer) { ser.Struct(&cafe) },
+		func(ser *bcs.Serializer) { ser.WriteBytes([]byte{1, 2}) },
+		func(ser *bcs.Serializer) { ser.Uleb128(2); ser.U64(7); ser.U64(8) },
+		func(ser *bcs.Serializer) { ser.WriteString("hello") },
+		func(ser *bcs.Serializer) { ser.Uleb128(1); ser.Struct(&AccountOne) },
+		func(ser *bcs.Serializer) { ser.Struct(&AccountOne) },
+		func(ser *bcs.Serializer) { ser.Uleb128(1); ser.U8(9) },
+	}
+	assert.Len(t, payload.Args, len(expected))
+	for i, serialize := range expected {
+		expectedBytes, err := bcs.SerializeSingle(serialize)
+		assert.NoError(t, err)
+		assert.Equal(t, expectedBytes, payload.Args[i], "argument %d", i)
+	}
+
+	// A missing option is none, and a plain string is UTF-8 bytes
+	var noAddress *AccountAddress
+	args := []any{false, 0, 0, 0, 0, 0, 0, "cafe", "text", []uint64{}, "", noAddress, "0x1", []any{}}
+	payload, err = client.EntryFunctionFromAbi("0x1::test::everything", []string{"u8"}, args)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{4, 't', 'e', 'x', 't'}, payload.Args[8])
+	assert.Equal(t, []byte{0}, payload.Args[11])
+
+	// Out of range and mistyped arguments are errors
+	badArgs := [][]any{
+		{true, 256, 0, 0, 0, 0, 0, "0x1", "", []uint64{}, "", nil, "0x1", []any{}},
+		{true, 0, -1, 0, 0, 0, 0, "0x1", "", []uint64{}, "", nil, "0x1", []any{}},
+		{true, 0, 0, 0, 0, 0, 0, "0x1", "", "not json", "", nil, "0x1", []any{}},
+		{true, 0, 0, 0, 0, 0, 0, 5, "", []uint64{}, "", nil, "0x1", []any{}},
+		{true, 0, 0, 0, 0.5, 0, 0, "0x1", "", []uint64{}, "", nil, "0x1", []any{}},
+		{true, 0, 0, 0, 0, 0, 0, "0x1", "", []uint64{}, "", nil, "0x1"},
+	}
+	for i, args := range badArgs {
+		_, err = client.EntryFunctionFromAbi("0x1::test::everything", []string{"u8"}, args)
+		assert.Error(t, err, "bad arguments %d", i)
+	}
+	_, err = client.EntryFunctionFromAbi("0x1::test::everything", nil, args)
+	assert.Error(t, err)
+	_, err = client.EntryFunctionFromAbi("0x1::test::balance", []string{"u8"}, []any{"0x1"})
+	assert.Error(t, err)
+	_, err = client.EntryFunctionFromAbi("0x1::test::missing", nil, nil)
+	assert.Error(t, err)
+
+	viewPayload, err := client.ViewPayloadFromAbi("0x1::test::balance", []string{"0x1::aptos_coin::AptosCoin"}, []any{"0x1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "0x1::aptos_coin::AptosCoin", viewPayload.ArgTypes[0].String())
+	assert.Equal(t, [][]byte{AccountOne[:]}, viewPayload.Args)
+
+	values, err := client.ViewJSON(&ViewJSONPayload{Function: "0x1::test::balance", TypeArguments: []string{"0x1::aptos_coin::AptosCoin"}, Arguments: []any{"0x1"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []any{"100"}, values)
+	assert.Equal(t, map[string]any{
+		"function":       "0x1::test::balance",
+		"type_arguments": []any{"0x1::aptos_coin::AptosCoin"},
+		"arguments":      []any{"0x1"},
+	}, viewRequest)
+
+	// Nil lists are sent as empty lists
+	_, err = client.ViewJSON(&ViewJSONPayload{Function: "0x1::test::balance"})
+	assert.NoError(t, err)
+	assert.Equal(t, []any{}, viewRequest["arguments"])
+}
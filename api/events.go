@@ -30,3 +30,27 @@ func (o *Event) UnmarshalJSON(b []byte) error {
 	o.Data = data.Data
 	return nil
 }
+
+// VersionedEvent is an [Event] along with the version of the transaction that emitted it, as returned when querying
+// events by event handle or creation number
+type VersionedEvent struct {
+	Version uint64 // The version of the transaction that emitted the event
+	Event
+}
+
+func (o *VersionedEvent) UnmarshalJSON(b []byte) error {
+	type inner struct {
+		Version U64 `json:"version"`
+	}
+	data := &inner{}
+	err := json.Unmarshal(b, &data)
+	if err != nil {
+		return err
+	}
+	err = o.Event.UnmarshalJSON(b)
+	if err != nil {
+		return err
+	}
+	o.Version = data.Version.toUint64()
+	return nil
+}
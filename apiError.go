@@ -0,0 +1,114 @@
+package aptos
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// AptosErrorCode is the machine-readable error code of an [AptosApiError]
+type AptosErrorCode string
+
+// Error codes returned by the node's API
+const (
+	AptosErrorCodeAccountNotFound          AptosErrorCode = "account_not_found"
+	AptosErrorCodeResourceNotFound         AptosErrorCode = "resource_not_found"
+	AptosErrorCodeModuleNotFound           AptosErrorCode = "module_not_found"
+	AptosErrorCodeStructFieldNotFound      AptosErrorCode = "struct_field_not_found"
+	AptosErrorCodeVersionNotFound          AptosErrorCode = "version_not_found"
+	AptosErrorCodeTransactionNotFound      AptosErrorCode = "transaction_not_found"
+	AptosErrorCodeTableItemNotFound        AptosErrorCode = "table_item_not_found"
+	AptosErrorCodeBlockNotFound            AptosErrorCode = "block_not_found"
+	AptosErrorCodeStateValueNotFound       AptosErrorCode = "state_value_not_found"
+	AptosErrorCodeVersionPruned            AptosErrorCode = "version_pruned"
+	AptosErrorCodeBlockPruned              AptosErrorCode = "block_pruned"
+	AptosErrorCodeInvalidInput             AptosErrorCode = "invalid_input"
+	AptosErrorCodeInvalidTransactionUpdate AptosErrorCode = "invalid_transaction_update"
+	AptosErrorCodeSequenceNumberTooOld     AptosErrorCode = "sequence_number_too_old"
+	AptosErrorCodeVmError                  AptosErrorCode = "vm_error"
+	AptosErrorCodeRejectedByFilter         AptosErrorCode = "rejected_by_filter"
+	AptosErrorCodeHealthCheckFailed        AptosErrorCode = "health_check_failed"
+	AptosErrorCodeMempoolIsFull            AptosErrorCode = "mempool_is_full"
+	AptosErrorCodeInternalError            AptosErrorCode = "internal_error"
+	AptosErrorCodeWebFrameworkError        AptosErrorCode = "web_framework_error"
+	AptosErrorCodeBcsNotSupported          AptosErrorCode = "bcs_not_supported"
+	AptosErrorCodeApiDisabled              AptosErrorCode = "api_disabled"
+)
+
+// Sentinel errors for matching common API errors with [errors.Is]
+//
+//	_, err := client.Account(address)
+//	if errors.Is(err, ErrAccountNotFound) {
+//		// The account hasn't been created on-chain yet
+//	}
+var (
+	ErrAccountNotFound     = &AptosApiError{ErrorCode: AptosErrorCodeAccountNotFound}
+	ErrResourceNotFound    = &AptosApiError{ErrorCode: AptosErrorCodeResourceNotFound}
+	ErrModuleNotFound      = &AptosApiError{ErrorCode: AptosErrorCodeModuleNotFound}
+	ErrTransactionNotFound = &AptosApiError{ErrorCode: AptosErrorCodeTransactionNotFound}
+	ErrTableItemNotFound   = &AptosApiError{ErrorCode: AptosErrorCodeTableItemNotFound}
+	ErrBlockNotFound       = &AptosApiError{ErrorCode: AptosErrorCodeBlockNotFound}
+	ErrVersionNotFound     = &AptosApiError{ErrorCode: AptosErrorCodeVersionNotFound}
+	ErrVersionPruned       = &AptosApiError{ErrorCode: AptosErrorCodeVersionPruned}
+	ErrMempoolIsFull       = &AptosApiError{ErrorCode: AptosErrorCodeMempoolIsFull}
+)
+
+// AptosApiError is the structured error returned by the node's API, e.g. for a missing account or a transaction
+// rejected from mempool.
+//
+// Errors from [NodeClient] calls are an [HttpError] wrapping the AptosApiError, so use [errors.As] to get at it, or
+// [errors.Is] with a sentinel such as [ErrAccountNotFound] to match on the error code.
+//
+//	var apiErr *AptosApiError
+//	if errors.As(err, &apiErr) && apiErr.ErrorCode == AptosErrorCodeVmError {
+//		// Look at apiErr.VmErrorCode
+//	}
+type AptosApiError struct {
+	Message     string         // Human-readable description of the error
+	ErrorCode   AptosErrorCode // Machine-readable error code e.g. AptosErrorCodeAccountNotFound
+	VmErrorCode *uint64        // The Move VM status code, if the error came from the VM
+}
+
+func (e *AptosApiError) Error() string {
+	if e.VmErrorCode != nil {
+		return fmt.Sprintf("%s (%s, vm_error_code %d)", e.Message, e.ErrorCode, *e.VmErrorCode)
+	}
+	return fmt.Sprintf("%s (%s)", e.Message, e.ErrorCode)
+}
+
+// Is matches another AptosApiError with the same error code, and the same VM error code if the target has one
+func (e *AptosApiError) Is(target error) bool {
+	other, ok := target.(*AptosApiError)
+	if !ok {
+		return false
+	}
+	if e.ErrorCode != other.ErrorCode {
+		return false
+	}
+	return other.VmErrorCode == nil || (e.VmErrorCode != nil && *e.VmErrorCode == *other.VmErrorCode)
+}
+
+func (e *AptosApiError) UnmarshalJSON(b []byte) error {
+	type inner struct {
+		Message     string         `json:"message"`
+		ErrorCode   AptosErrorCode `json:"error_code"`
+		VmErrorCode *uint64        `json:"vm_error_code"`
+	}
+	data := &inner{}
+	err := json.Unmarshal(b, &data)
+	if err != nil {
+		return err
+	}
+	e.Message = data.Message
+	e.ErrorCode = data.ErrorCode
+	e.VmErrorCode = data.VmErrorCode
+	return nil
+}
+
+// parseAptosApiError decodes an error response body, returning nil if it isn't an API error e.g. from a proxy
+func parseAptosApiError(body []byte) *AptosApiError {
+	apiErr := &AptosApiError{}
+	if json.Unmarshal(body, apiErr) != nil || apiErr.ErrorCode == "" {
+		return nil
+	}
+	return apiErr
+}
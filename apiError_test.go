@@ -0,0 +1,58 @@
+package aptos
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAptosApiError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/accounts/" + AccountOne.String():
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Account not found by Address(0x1) and Ledger version(10)","error_code":"account_not_found","vm_error_code":null}`))
+		case "/v1/view":
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"message":"Move abort","error_code":"vm_error","vm_error_code":4016}`))
+		default:
+			// e.g. a load balancer in front of the node
+			w.WriteHeader(http.StatusBadGateway)
+			_, _ = w.Write([]byte(`<html>Bad Gateway</html>`))
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewNodeClient(server.URL+"/v1", 4)
+	assert.NoError(t, err)
+	client.SetRetryPolicy(nil)
+
+	_, err = client.Account(AccountOne)
+	assert.ErrorIs(t, err, ErrAccountNotFound)
+	assert.NotErrorIs(t, err, ErrResourceNotFound)
+	var apiErr *AptosApiError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, AptosErrorCodeAccountNotFound, apiErr.ErrorCode)
+	assert.Nil(t, apiErr.VmErrorCode)
+	// Still an HttpError for callers checking the status code
+	var httpErr *HttpError
+	assert.True(t, errors.As(err, &httpErr))
+	assert.Equal(t, http.StatusNotFound, httpErr.StatusCode)
+
+	_, err = client.View(&ViewPayload{Module: ModuleId{Address: AccountOne, Name: "coin"}, Function: "balance"})
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, uint64(4016), *apiErr.VmErrorCode)
+	vmErrorCode := uint64(4016)
+	assert.ErrorIs(t, err, &AptosApiError{ErrorCode: AptosErrorCodeVmError})
+	assert.ErrorIs(t, err, &AptosApiError{ErrorCode: AptosErrorCodeVmError, VmErrorCode: &vmErrorCode})
+	otherVmErrorCode := uint64(1)
+	assert.NotErrorIs(t, err, &AptosApiError{ErrorCode: AptosErrorCodeVmError, VmErrorCode: &otherVmErrorCode})
+
+	// Bodies that aren't API errors are left as is
+	_, err = client.Info()
+	assert.True(t, errors.As(err, &httpErr))
+	assert.Nil(t, httpErr.ApiError)
+	assert.False(t, errors.As(err, &apiErr))
+}
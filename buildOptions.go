@@ -0,0 +1,237 @@
+package aptos
+
+import (
+	"errors"
+	"fmt"
+	"github.com/aptos-labs/aptos-go-sdk/crypto"
+	"time"
+)
+
+// BuildOption configures a transaction built by [NodeClient.BuildTransactionWithOptions] and the other WithOptions
+// builders.  Unlike the untyped options of [NodeClient.BuildTransaction], a wrong option is a compile error.
+//
+//	rawTxn, err := client.BuildTransactionWithOptions(sender.AccountAddress(), payload,
+//		WithEstimatedGasUnitPrice(GasPricePriorityPrioritized),
+//		WithSimulatedMaxGasAmount(1.5, sender.PubKey()),
+//		WithExpirationSeconds(60),
+//	)
+type BuildOption func(config *buildConfig) error
+
+// buildConfig is the transaction as configured by BuildOptions, anything not set is fetched on-chain or defaulted
+type buildConfig struct {
+	maxGasAmount     uint64
+	haveMaxGasAmount bool
+	gasUnitPrice     uint64
+	haveGasUnitPrice bool
+	gasPricePriority *GasPricePriority
+
+	expirationSeconds     int64
+	haveExpirationSeconds bool
+	expirationTimestamp   uint64 // Seconds since Unix epoch, 0 to expire expirationSeconds from now
+
+	sequenceNumber     uint64
+	haveSequenceNumber bool
+	sequenceNumbers    *AccountSequenceNumber
+
+	chainId     uint8
+	haveChainId bool
+
+	feePayer         *AccountAddress
+	secondarySigners []AccountAddress
+
+	estimateMaxGas *EstimateMaxGasAmount
+}
+
+// newBuildConfig applies the options over the defaults, and checks that they don't conflict
+func newBuildConfig(options []BuildOption) (*buildConfig, error) {
+	config := &buildConfig{
+		maxGasAmount:      100_000, // Default to 0.001 APT max gas amount
+		gasUnitPrice:      100,     // Default to min gas price
+		expirationSeconds: 300,     // Default to 5 minutes
+	}
+	for _, option := range options {
+		err := option(config)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if config.haveGasUnitPrice && config.gasPricePriority != nil {
+		return nil, errors.New("GasUnitPrice and EstimateGasUnitPrice cannot both be set")
+	}
+	if config.haveMaxGasAmount && config.estimateMaxGas != nil {
+		return nil, errors.New("MaxGasAmount and EstimateMaxGasAmount cannot both be set")
+	}
+	if config.haveSequenceNumber && config.sequenceNumbers != nil {
+		return nil, errors.New("SequenceNumber and AccountSequenceNumber cannot both be set")
+	}
+	if config.haveExpirationSeconds && config.expirationTimestamp != 0 {
+		return nil, errors.New("ExpirationSeconds and ExpirationTime cannot both be set")
+	}
+	return config, nil
+}
+
+// expiration returns the expiration timestamp in seconds since Unix epoch
+func (config *buildConfig) expiration() uint64 {
+	if config.expirationTimestamp != 0 {
+		return config.expirationTimestamp
+	}
+	return uint64(time.Now().Unix() + config.expirationSeconds)
+}
+
+// releaseSequenceNumber gives the sequence number back to the manager it came from, if any, after the transaction
+// failed before reaching chain
+func (config *buildConfig) releaseSequenceNumber(sequenceNumber uint64) {
+	if config.sequenceNumbers != nil {
+		config.sequenceNumbers.Release(sequenceNumber)
+	}
+}
+
+// WithMaxGasAmount sets the most gas the transaction can use, 100,000 by default
+func WithMaxGasAmount(maxGasAmount uint64) BuildOption {
+	return func(config *buildConfig) error {
+		config.maxGasAmount = maxGasAmount
+		config.haveMaxGasAmount = true
+		return nil
+	}
+}
+
+// WithGasUnitPrice sets the price per unit of gas in octas, 100 by default
+func WithGasUnitPrice(gasUnitPrice uint64) BuildOption {
+	return func(config *buildConfig) error {
+		config.gasUnitPrice = gasUnitPrice
+		config.haveGasUnitPrice = true
+		return nil
+	}
+}
+
+// WithEstimatedGasUnitPrice fetches the gas unit price on-chain with EstimateGasPrice, at the given priority
+func WithEstimatedGasUnitPrice(priority GasPricePriority) BuildOption {
+	return func(config *buildConfig) error {
+		config.gasPricePriority = &priority
+		return nil
+	}
+}
+
+// WithSimulatedMaxGasAmount simulates the transaction, and sets the max gas amount to the simulated gas used times the
+// multiplier for headroom, see [EstimateMaxGasAmount].  The signers' public keys are needed for simulation.  It can't be
+// combined with [WithMaxGasAmount].
+func WithSimulatedMaxGasAmount(multiplier float64, signers ...crypto.PublicKey) BuildOption {
+	return func(config *buildConfig) error {
+		config.estimateMaxGas = &EstimateMaxGasAmount{Multiplier: multiplier, Signers: signers}
+		return nil
+	}
+}
+
+// WithExpirationSeconds sets how long from now the transaction expires, 5 minutes by default
+func WithExpirationSeconds(seconds int64) BuildOption {
+	return func(config *buildConfig) error {
+		if seconds < 0 {
+			return errors.New("ExpirationSeconds cannot be less than 0")
+		}
+		config.expirationSeconds = seconds
+		config.haveExpirationSeconds = true
+		return nil
+	}
+}
+
+// WithExpirationTime sets when the transaction expires, e.g. to give transactions built at different times the same
+// expiration.  It is truncated to the second.
+func WithExpirationTime(expiration time.Time) BuildOption {
+	return func(config *buildConfig) error {
+		if expiration.Unix() <= 0 {
+			return fmt.Errorf("ExpirationTime %s is not after the Unix epoch", expiration)
+		}
+		config.expirationTimestamp = uint64(expiration.Unix())
+		return nil
+	}
+}
+
+// WithSequenceNumber sets the sender's sequence number, rather than fetching it on-chain
+func WithSequenceNumber(sequenceNumber uint64) BuildOption {
+	return func(config *buildConfig) error {
+		config.sequenceNumber = sequenceNumber
+		config.haveSequenceNumber = true
+		return nil
+	}
+}
+
+// WithAccountSequenceNumber takes the sender's sequence number from the manager, rather than fetching it on-chain
+func WithAccountSequenceNumber(sequenceNumbers *AccountSequenceNumber) BuildOption {
+	return func(config *buildConfig) error {
+		if sequenceNumbers == nil {
+			return errors.New("AccountSequenceNumber cannot be nil")
+		}
+		config.sequenceNumbers = sequenceNumbers
+		return nil
+	}
+}
+
+// WithChainId sets the chain id, rather than using the client's
+func WithChainId(chainId uint8) BuildOption {
+	return func(config *buildConfig) error {
+		config.chainId = chainId
+		config.haveChainId = true
+		return nil
+	}
+}
+
+// WithFeePayer has the fee payer pay for gas rather than the sender, only for
+// [NodeClient.BuildTransactionMultiAgentWithOptions]
+func WithFeePayer(feePayer AccountAddress) BuildOption {
+	return func(config *buildConfig) error {
+		config.feePayer = &feePayer
+		return nil
+	}
+}
+
+// WithSecondarySigners adds signers to the transaction besides the sender, only for
+// [NodeClient.BuildTransactionMultiAgentWithOptions]
+func WithSecondarySigners(signers ...AccountAddress) BuildOption {
+	return func(config *buildConfig) error {
+		config.secondarySigners = append(config.secondarySigners, signers...)
+		return nil
+	}
+}
+
+// buildOptionsFromAny converts the untyped options of BuildTransaction and the other builders to BuildOptions.  A
+// BuildOption can be among them.
+func buildOptionsFromAny(function string, options []any) ([]BuildOption, error) {
+	out := make([]BuildOption, 0, len(options))
+	for i, option := range options {
+		switch value := option.(type) {
+		case BuildOption:
+			out = append(out, value)
+		case MaxGasAmount:
+			out = append(out, WithMaxGasAmount(uint64(value)))
+		case GasUnitPrice:
+			out = append(out, WithGasUnitPrice(uint64(value)))
+		case EstimateGasUnitPrice:
+			out = append(out, WithEstimatedGasUnitPrice(GasPricePriority(value)))
+		case EstimateMaxGasAmount:
+			out = append(out, WithSimulatedMaxGasAmount(value.Multiplier, value.Signers...))
+		case ExpirationSeconds:
+			out = append(out, WithExpirationSeconds(int64(value)))
+		case SequenceNumber:
+			out = append(out, WithSequenceNumber(uint64(value)))
+		case *AccountSequenceNumber:
+			out = append(out, WithAccountSequenceNumber(value))
+		case ChainIdOption:
+			out = append(out, WithChainId(uint8(value)))
+		case FeePayer:
+			if value == nil {
+				// No fee payer, as before typed options
+				out = append(out, func(config *buildConfig) error {
+					config.feePayer = nil
+					return nil
+				})
+				continue
+			}
+			out = append(out, WithFeePayer(*value))
+		case AdditionalSigners:
+			out = append(out, WithSecondarySigners(value...))
+		default:
+			return nil, fmt.Errorf("%s arg [%d] unknown option type %T", function, i+4, option)
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,117 @@
+package aptos
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestBuildOptions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/accounts/" + AccountOne.String():
+			_, _ = w.Write([]byte(`{"sequence_number":"3","authentication_key":"0x1"}`))
+		case "/v1/estimate_gas_price":
+			_, _ = w.Write([]byte(`{"deprioritized_gas_estimate":100,"gas_estimate":150,"prioritized_gas_estimate":200}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	client, err := NewNodeClient(server.URL+"/v1", 4)
+	assert.NoError(t, err)
+	payload, err := CoinTransferPayload(nil, AccountTwo, 1)
+	assert.NoError(t, err)
+	txnPayload := TransactionPayload{Payload: payload}
+
+	// Anything not set is fetched or defaulted
+	rawTxn, err := client.BuildTransactionWithOptions(AccountOne, txnPayload)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), rawTxn.SequenceNumber)
+	assert.Equal(t, uint64(100_000), rawTxn.MaxGasAmount)
+	assert.Equal(t, uint64(100), rawTxn.GasUnitPrice)
+	assert.Equal(t, uint8(4), rawTxn.ChainId)
+	assert.InDelta(t, time.Now().Unix()+300, int64(rawTxn.ExpirationTimestampSeconds), 5)
+
+	expiration := time.Unix(1_900_000_000, 0)
+	rawTxn, err = client.BuildTransactionWithOptions(AccountOne, txnPayload,
+		WithMaxGasAmount(2000),
+		WithEstimatedGasUnitPrice(GasPricePriorityPrioritized),
+		WithExpirationTime(expiration),
+		WithSequenceNumber(7),
+		WithChainId(9),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(7), rawTxn.SequenceNumber)
+	assert.Equal(t, uint64(2000), rawTxn.MaxGasAmount)
+	assert.Equal(t, uint64(200), rawTxn.GasUnitPrice)
+	assert.Equal(t, uint8(9), rawTxn.ChainId)
+	assert.Equal(t, uint64(1_900_000_000), rawTxn.ExpirationTimestampSeconds)
+
+	// Conflicting and invalid options
+	_, err = client.BuildTransactionWithOptions(AccountOne, txnPayload, WithExpirationSeconds(60), WithExpirationTime(expiration))
+	assert.Error(t, err)
+	_, err = client.BuildTransactionWithOptions(AccountOne, txnPayload, WithExpirationSeconds(-1))
+	assert.Error(t, err)
+	_, err = client.BuildTransactionWithOptions(AccountOne, txnPayload, WithGasUnitPrice(100), WithEstimatedGasUnitPrice(GasPricePriorityNormal))
+	assert.Error(t, err)
+	_, err = client.BuildTransactionWithOptions(AccountOne, txnPayload, WithAccountSequenceNumber(nil))
+	assert.Error(t, err)
+
+	// A fee payer needs a multi-agent transaction, rather than being ignored
+	_, err = client.BuildTransactionWithOptions(AccountOne, txnPayload, WithFeePayer(AccountTwo))
+	assert.ErrorContains(t, err, "BuildTransactionMultiAgent")
+	feePayer := AccountTwo
+	_, err = client.BuildTransaction(AccountOne, txnPayload, FeePayer(&feePayer))
+	assert.ErrorContains(t, err, "BuildTransactionMultiAgent")
+
+	rawTxnWithData, err := client.BuildTransactionMultiAgentWithOptions(AccountOne, txnPayload,
+		WithFeePayer(AccountTwo), WithSecondarySigners(AccountThree), WithSequenceNumber(1))
+	assert.NoError(t, err)
+	assert.Equal(t, MultiAgentWithFeePayerRawTransactionWithDataVariant, rawTxnWithData.Variant)
+	inner := rawTxnWithData.Inner.(*MultiAgentWithFeePayerRawTransactionWithData)
+	assert.Equal(t, AccountTwo, *inner.FeePayer)
+	assert.Equal(t, []AccountAddress{AccountThree}, inner.SecondarySigners)
+	assert.Equal(t, uint64(1), inner.RawTxn.SequenceNumber)
+
+	// The untyped options are adapted, and can be mixed with BuildOptions
+	rawTxnWithData, err = client.BuildTransactionMultiAgent(AccountOne, txnPayload,
+		FeePayer(&feePayer), AdditionalSigners{AccountThree}, SequenceNumber(1), WithGasUnitPrice(150))
+	assert.NoError(t, err)
+	inner = rawTxnWithData.Inner.(*MultiAgentWithFeePayerRawTransactionWithData)
+	assert.Equal(t, AccountTwo, *inner.FeePayer)
+	assert.Equal(t, []AccountAddress{AccountThree}, inner.SecondarySigners)
+	assert.Equal(t, uint64(150), inner.RawTxn.GasUnitPrice)
+
+	rawTxnWithData, err = client.BuildTransactionMultiAgent(AccountOne, txnPayload, AdditionalSigners{AccountThree}, SequenceNumber(1))
+	assert.NoError(t, err)
+	assert.Equal(t, MultiAgentRawTransactionWithDataVariant, rawTxnWithData.Variant)
+
+	_, err = client.BuildTransaction(AccountOne, txnPayload, "bad option")
+	assert.EqualError(t, err, "BuildTransaction arg [4] unknown option type string")
+	// A nil FeePayer is no fee payer
+	rawTxnWithData, err = client.BuildTransactionMultiAgent(AccountOne, txnPayload, FeePayer(&feePayer), FeePayer(nil), SequenceNumber(1))
+	assert.NoError(t, err)
+	assert.Equal(t, MultiAgentRawTransactionWithDataVariant, rawTxnWithData.Variant)
+}
+
+func TestAPTTransferTransactionWithOptions(t *testing.T) {
+	// Every option is given, so nothing is fetched from the node
+	client, err := NewClient(LocalnetConfig)
+	assert.NoError(t, err)
+	sender, err := NewEd25519Account()
+	assert.NoError(t, err)
+
+	signedTxn, err := APTTransferTransactionWithOptions(client, sender, AccountTwo, 100,
+		WithSequenceNumber(3), WithChainId(9), WithGasUnitPrice(150), WithMaxGasAmount(1000))
+	assert.NoError(t, err)
+	assert.NoError(t, signedTxn.Verify())
+	rawTxn := signedTxn.Transaction.(*RawTransaction)
+	assert.Equal(t, sender.Address, rawTxn.Sender)
+	assert.Equal(t, uint64(3), rawTxn.SequenceNumber)
+	assert.Equal(t, uint8(9), rawTxn.ChainId)
+	assert.Equal(t, uint64(150), rawTxn.GasUnitPrice)
+	assert.Equal(t, uint64(1000), rawTxn.MaxGasAmount)
+}
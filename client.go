@@ -37,8 +37,11 @@
 package aptos
 
 import (
+	"context"
 	"github.com/aptos-labs/aptos-go-sdk/api"
+	"github.com/aptos-labs/aptos-go-sdk/crypto"
 	"github.com/hasura/go-graphql-client"
+	"net/http"
 	"time"
 )
 
@@ -163,16 +166,78 @@ func (client *Client) SetTimeout(timeout time.Duration) {
 	client.nodeClient.client.Timeout = timeout
 }
 
+// SetRetryPolicy replaces the node client's retry policy for transient failures, nil disables retries
+//
+//	client.SetRetryPolicy(&RetryPolicy{MaxRetries: 5, InitialBackoff: time.Second, Multiplier: 2})
+func (client *Client) SetRetryPolicy(policy *RetryPolicy) {
+	client.nodeClient.SetRetryPolicy(policy)
+}
+
 // Info Retrieves the node info about the network and it's current state
 func (client *Client) Info() (info NodeInfo, err error) {
 	return client.nodeClient.Info()
 }
 
+// InfoCtx is [Client.Info] with a [context.Context] for cancellation and deadlines
+func (client *Client) InfoCtx(ctx context.Context) (info NodeInfo, err error) {
+	return client.nodeClient.InfoCtx(ctx)
+}
+
+// Healthy Checks the node's health endpoint, returning an error matching [ErrNodeUnhealthy] if the node is unhealthy or
+// its ledger is more than maxLag behind
+func (client *Client) Healthy(maxLag time.Duration) (err error) {
+	return client.nodeClient.Healthy(maxLag)
+}
+
+// HealthyCtx is [Client.Healthy] with a [context.Context] for cancellation and deadlines
+func (client *Client) HealthyCtx(ctx context.Context, maxLag time.Duration) (err error) {
+	return client.nodeClient.HealthyCtx(ctx, maxLag)
+}
+
+// Lag Returns how far the node's ledger timestamp trails the local clock
+func (client *Client) Lag() (lag time.Duration, err error) {
+	return client.nodeClient.Lag()
+}
+
+// LagCtx is [Client.Lag] with a [context.Context] for cancellation and deadlines
+func (client *Client) LagCtx(ctx context.Context) (lag time.Duration, err error) {
+	return client.nodeClient.LagCtx(ctx)
+}
+
+// CheckHealth Checks the node once and records the result as the client's [HealthStatus], see [NodeClient.CheckHealth]
+func (client *Client) CheckHealth(ctx context.Context, maxLag time.Duration) HealthStatus {
+	return client.nodeClient.CheckHealth(ctx, maxLag)
+}
+
+// RunHealthMonitor Checks the health of the node every period until the context is cancelled, see
+// [NodeClient.RunHealthMonitor]
+//
+//	go client.RunHealthMonitor(ctx, 10*time.Second, 30*time.Second)
+//	http.Handle("/ready", client.HealthHandler())
+func (client *Client) RunHealthMonitor(ctx context.Context, period time.Duration, maxLag time.Duration) error {
+	return client.nodeClient.RunHealthMonitor(ctx, period, maxLag)
+}
+
+// HealthStatus Returns the result of the most recent health check
+func (client *Client) HealthStatus() HealthStatus {
+	return client.nodeClient.HealthStatus()
+}
+
+// HealthHandler Returns an [http.Handler] for readiness probes, responding 200 if the client is healthy and 503 otherwise
+func (client *Client) HealthHandler() http.Handler {
+	return client.nodeClient.HealthHandler()
+}
+
 // Account Retrieves information about the account such as [SequenceNumber] and [crypto.AuthenticationKey]
 func (client *Client) Account(address AccountAddress, ledgerVersion ...uint64) (info AccountInfo, err error) {
 	return client.nodeClient.Account(address, ledgerVersion...)
 }
 
+// AccountCtx is [Client.Account] with a [context.Context] for cancellation and deadlines
+func (client *Client) AccountCtx(ctx context.Context, address AccountAddress, ledgerVersion ...uint64) (info AccountInfo, err error) {
+	return client.nodeClient.AccountCtx(ctx, address, ledgerVersion...)
+}
+
 // AccountResource Retrieves a single resource given its struct name.
 //
 //	address := AccountOne
@@ -186,6 +251,11 @@ func (client *Client) AccountResource(address AccountAddress, resourceType strin
 	return client.nodeClient.AccountResource(address, resourceType, ledgerVersion...)
 }
 
+// AccountResourceCtx is [Client.AccountResource] with a [context.Context] for cancellation and deadlines
+func (client *Client) AccountResourceCtx(ctx context.Context, address AccountAddress, resourceType string, ledgerVersion ...uint64) (data map[string]any, err error) {
+	return client.nodeClient.AccountResourceCtx(ctx, address, resourceType, ledgerVersion...)
+}
+
 // AccountResources fetches resources for an account into a JSON-like map[string]any in AccountResourceInfo.Data
 // For fetching raw Move structs as BCS, See #AccountResourcesBCS
 //
@@ -200,11 +270,118 @@ func (client *Client) AccountResources(address AccountAddress, ledgerVersion ...
 	return client.nodeClient.AccountResources(address, ledgerVersion...)
 }
 
+// AccountResourcesCtx is [Client.AccountResources] with a [context.Context] for cancellation and deadlines
+func (client *Client) AccountResourcesCtx(ctx context.Context, address AccountAddress, ledgerVersion ...uint64) (resources []AccountResourceInfo, err error) {
+	return client.nodeClient.AccountResourcesCtx(ctx, address, ledgerVersion...)
+}
+
+// AccountResourcesPage fetches a page of resources for an account, along with the cursor for the next page, which is
+// empty after the last page.  Start is a cursor from a previous page, empty for the first page.
+//
+//	resources, cursor, err := client.AccountResourcesPage(address, "", nil)
+//	for err == nil && cursor != "" {
+//		var more []AccountResourceInfo
+//		more, cursor, err = client.AccountResourcesPage(address, cursor, nil)
+//		resources = append(resources, more...)
+//	}
+func (client *Client) AccountResourcesPage(address AccountAddress, start string, limit *uint64, ledgerVersion ...uint64) (resources []AccountResourceInfo, cursor string, err error) {
+	return client.nodeClient.AccountResourcesPage(address, start, limit, ledgerVersion...)
+}
+
+// AccountResourcesPageCtx is [Client.AccountResourcesPage] with a [context.Context] for cancellation and deadlines
+func (client *Client) AccountResourcesPageCtx(ctx context.Context, address AccountAddress, start string, limit *uint64, ledgerVersion ...uint64) (resources []AccountResourceInfo, cursor string, err error) {
+	return client.nodeClient.AccountResourcesPageCtx(ctx, address, start, limit, ledgerVersion...)
+}
+
+// AccountResourcesIterator pages through all resources of an account, following cursors until done.  PageSize is the
+// number of resources fetched per request, 0 for the node's default.
+//
+//	resources, err := client.AccountResourcesIterator(address, 0).Collect()
+func (client *Client) AccountResourcesIterator(address AccountAddress, pageSize uint64, ledgerVersion ...uint64) *Iterator[AccountResourceInfo] {
+	return client.nodeClient.AccountResourcesIterator(address, pageSize, ledgerVersion...)
+}
+
+// AccountResourcesIteratorCtx is [Client.AccountResourcesIterator] with a [context.Context] for cancellation and
+// deadlines
+func (client *Client) AccountResourcesIteratorCtx(ctx context.Context, address AccountAddress, pageSize uint64, ledgerVersion ...uint64) *Iterator[AccountResourceInfo] {
+	return client.nodeClient.AccountResourcesIteratorCtx(ctx, address, pageSize, ledgerVersion...)
+}
+
 // AccountResourcesBCS fetches account resources as raw Move struct BCS blobs in AccountResourceRecord.Data []byte
 func (client *Client) AccountResourcesBCS(address AccountAddress, ledgerVersion ...uint64) (resources []AccountResourceRecord, err error) {
 	return client.nodeClient.AccountResourcesBCS(address, ledgerVersion...)
 }
 
+// AccountResourcesBCSCtx is [Client.AccountResourcesBCS] with a [context.Context] for cancellation and deadlines
+func (client *Client) AccountResourcesBCSCtx(ctx context.Context, address AccountAddress, ledgerVersion ...uint64) (resources []AccountResourceRecord, err error) {
+	return client.nodeClient.AccountResourcesBCSCtx(ctx, address, ledgerVersion...)
+}
+
+// AccountResourcesBCSPage fetches a page of resources for an account as raw Move struct BCS blobs, along with the cursor
+// for the next page.  See [Client.AccountResourcesPage] for the paging arguments.
+func (client *Client) AccountResourcesBCSPage(address AccountAddress, start string, limit *uint64, ledgerVersion ...uint64) (resources []AccountResourceRecord, cursor string, err error) {
+	return client.nodeClient.AccountResourcesBCSPage(address, start, limit, ledgerVersion...)
+}
+
+// AccountResourcesBCSPageCtx is [Client.AccountResourcesBCSPage] with a [context.Context] for cancellation and deadlines
+func (client *Client) AccountResourcesBCSPageCtx(ctx context.Context, address AccountAddress, start string, limit *uint64, ledgerVersion ...uint64) (resources []AccountResourceRecord, cursor string, err error) {
+	return client.nodeClient.AccountResourcesBCSPageCtx(ctx, address, start, limit, ledgerVersion...)
+}
+
+// AccountResourcesBCSIterator pages through all resources of an account as raw Move struct BCS blobs, following cursors
+// until done.  See [Client.AccountResourcesIterator] for the paging arguments.
+func (client *Client) AccountResourcesBCSIterator(address AccountAddress, pageSize uint64, ledgerVersion ...uint64) *Iterator[AccountResourceRecord] {
+	return client.nodeClient.AccountResourcesBCSIterator(address, pageSize, ledgerVersion...)
+}
+
+// AccountResourcesBCSIteratorCtx is [Client.AccountResourcesBCSIterator] with a [context.Context] for cancellation and
+// deadlines
+func (client *Client) AccountResourcesBCSIteratorCtx(ctx context.Context, address AccountAddress, pageSize uint64, ledgerVersion ...uint64) *Iterator[AccountResourceRecord] {
+	return client.nodeClient.AccountResourcesBCSIteratorCtx(ctx, address, pageSize, ledgerVersion...)
+}
+
+// AccountModules Retrieves all modules published by an account, along with their ABIs.  The modules are cached for later
+// calls to [Client.AccountModule].
+func (client *Client) AccountModules(address AccountAddress, ledgerVersion ...uint64) (modules []*api.MoveBytecode, err error) {
+	return client.nodeClient.AccountModules(address, ledgerVersion...)
+}
+
+// AccountModulesCtx is [Client.AccountModules] with a [context.Context] for cancellation and deadlines
+func (client *Client) AccountModulesCtx(ctx context.Context, address AccountAddress, ledgerVersion ...uint64) (modules []*api.MoveBytecode, err error) {
+	return client.nodeClient.AccountModulesCtx(ctx, address, ledgerVersion...)
+}
+
+// AccountModule Retrieves a single module published by an account, along with its ABI.  Modules are cached by address
+// and name, use [Client.ClearModuleCache] to pick up package upgrades.
+//
+//	module, err := client.AccountModule(AccountOne, "coin")
+//	for _, function := range module.Abi.ExposedFunctions {
+//		fmt.Println(function.Name, function.Params)
+//	}
+func (client *Client) AccountModule(address AccountAddress, moduleName string, ledgerVersion ...uint64) (module *api.MoveBytecode, err error) {
+	return client.nodeClient.AccountModule(address, moduleName, ledgerVersion...)
+}
+
+// AccountModuleCtx is [Client.AccountModule] with a [context.Context] for cancellation and deadlines
+func (client *Client) AccountModuleCtx(ctx context.Context, address AccountAddress, moduleName string, ledgerVersion ...uint64) (module *api.MoveBytecode, err error) {
+	return client.nodeClient.AccountModuleCtx(ctx, address, moduleName, ledgerVersion...)
+}
+
+// AccountModuleBCS Retrieves the raw bytecode of a single module published by an account, without its ABI
+func (client *Client) AccountModuleBCS(address AccountAddress, moduleName string, ledgerVersion ...uint64) (bytecode []byte, err error) {
+	return client.nodeClient.AccountModuleBCS(address, moduleName, ledgerVersion...)
+}
+
+// AccountModuleBCSCtx is [Client.AccountModuleBCS] with a [context.Context] for cancellation and deadlines
+func (client *Client) AccountModuleBCSCtx(ctx context.Context, address AccountAddress, moduleName string, ledgerVersion ...uint64) (bytecode []byte, err error) {
+	return client.nodeClient.AccountModuleBCSCtx(ctx, address, moduleName, ledgerVersion...)
+}
+
+// ClearModuleCache Drops all modules cached by [Client.AccountModule], e.g. after publishing a package upgrade
+func (client *Client) ClearModuleCache() {
+	client.nodeClient.ClearModuleCache()
+}
+
 // BlockByHeight fetches a block by height
 //
 //	block, _ := client.BlockByHeight(1, false)
@@ -216,6 +393,29 @@ func (client *Client) BlockByHeight(blockHeight uint64, withTransactions bool) (
 	return client.nodeClient.BlockByHeight(blockHeight, withTransactions)
 }
 
+// BlockByHeightCtx is [Client.BlockByHeight] with a [context.Context] for cancellation and deadlines
+func (client *Client) BlockByHeightCtx(ctx context.Context, blockHeight uint64, withTransactions bool) (data *api.Block, err error) {
+	return client.nodeClient.BlockByHeightCtx(ctx, blockHeight, withTransactions)
+}
+
+// BlockByHeightBCS fetches a block by height as BCS, decoding its transactions with their TransactionInfo, events, and
+// write sets
+//
+//	block, _ := client.BlockByHeightBCS(1, true)
+//	for _, txn := range block.Transactions {
+//		if txn.Transaction.Type() == aptos.TransactionVariantUser {
+//			// Process user transaction
+//		}
+//	}
+func (client *Client) BlockByHeightBCS(blockHeight uint64, withTransactions bool) (data *OnChainBlock, err error) {
+	return client.nodeClient.BlockByHeightBCS(blockHeight, withTransactions)
+}
+
+// BlockByHeightBCSCtx is [Client.BlockByHeightBCS] with a [context.Context] for cancellation and deadlines
+func (client *Client) BlockByHeightBCSCtx(ctx context.Context, blockHeight uint64, withTransactions bool) (data *OnChainBlock, err error) {
+	return client.nodeClient.BlockByHeightBCSCtx(ctx, blockHeight, withTransactions)
+}
+
 // BlockByVersion fetches a block by ledger version
 //
 //	block, _ := client.BlockByVersion(123, false)
@@ -227,15 +427,18 @@ func (client *Client) BlockByVersion(ledgerVersion uint64, withTransactions bool
 	return client.nodeClient.BlockByVersion(ledgerVersion, withTransactions)
 }
 
+// BlockByVersionCtx is [Client.BlockByVersion] with a [context.Context] for cancellation and deadlines
+func (client *Client) BlockByVersionCtx(ctx context.Context, ledgerVersion uint64, withTransactions bool) (data *api.Block, err error) {
+	return client.nodeClient.BlockByVersionCtx(ctx, ledgerVersion, withTransactions)
+}
+
 // TransactionByHash gets info on a transaction
 // The transaction may be pending or recently committed.
 //
 //	data, err := client.TransactionByHash("0xabcd")
 //	if err != nil {
-//		if httpErr, ok := err.(aptos.HttpError) {
-//			if httpErr.StatusCode == 404 {
-//				// if we're sure this has been submitted, assume it is still pending elsewhere in the mempool
-//			}
+//		if errors.Is(err, aptos.ErrTransactionNotFound) {
+//			// if we're sure this has been submitted, assume it is still pending elsewhere in the mempool
 //		}
 //	} else {
 //		if data["type"] == "pending_transaction" {
@@ -246,23 +449,47 @@ func (client *Client) TransactionByHash(txnHash string) (data *api.Transaction,
 	return client.nodeClient.TransactionByHash(txnHash)
 }
 
+// TransactionByHashCtx is [Client.TransactionByHash] with a [context.Context] for cancellation and deadlines
+func (client *Client) TransactionByHashCtx(ctx context.Context, txnHash string) (data *api.Transaction, err error) {
+	return client.nodeClient.TransactionByHashCtx(ctx, txnHash)
+}
+
 // TransactionByVersion gets info on a transaction from its LedgerVersion.  It must have been
 // committed to have a ledger version
 //
 //	data, err := client.TransactionByVersion("0xabcd")
 //	if err != nil {
-//		if httpErr, ok := err.(aptos.HttpError) {
-//			if httpErr.StatusCode == 404 {
-//				// if we're sure this has been submitted, the full node might not be caught up to this version yet
-//			}
+//		if errors.Is(err, aptos.ErrTransactionNotFound) {
+//			// if we're sure this has been submitted, the full node might not be caught up to this version yet
 //		}
 //	}
 func (client *Client) TransactionByVersion(version uint64) (data *api.Transaction, err error) {
 	return client.nodeClient.TransactionByVersion(version)
 }
 
+// TransactionByVersionCtx is [Client.TransactionByVersion] with a [context.Context] for cancellation and deadlines
+func (client *Client) TransactionByVersionCtx(ctx context.Context, version uint64) (data *api.Transaction, err error) {
+	return client.nodeClient.TransactionByVersionCtx(ctx, version)
+}
+
+// TransactionByVersionBCS gets a committed transaction by version as BCS, with its TransactionInfo, events, and
+// write set
+//
+//	data, err := client.TransactionByVersionBCS(version)
+//	if err == nil && !data.Info.Status.Success() {
+//		// Transaction failed
+//	}
+func (client *Client) TransactionByVersionBCS(version uint64) (data *TransactionOnChainData, err error) {
+	return client.nodeClient.TransactionByVersionBCS(version)
+}
+
+// TransactionByVersionBCSCtx is [Client.TransactionByVersionBCS] with a [context.Context] for cancellation and deadlines
+func (client *Client) TransactionByVersionBCSCtx(ctx context.Context, version uint64) (data *TransactionOnChainData, err error) {
+	return client.nodeClient.TransactionByVersionBCSCtx(ctx, version)
+}
+
 // PollForTransactions Waits up to 10 seconds for transactions to be done, polling at 10Hz
-// Accepts options PollPeriod and PollTimeout which should wrap time.Duration values.
+// Accepts options PollPeriod and PollTimeout which should wrap time.Duration values, or any [PollOption].
 //
 //	hashes := []string{"0x1234", "0x4567"}
 //	err := client.PollForTransactions(hashes)
@@ -275,9 +502,36 @@ func (client *Client) PollForTransactions(txnHashes []string, options ...any) er
 	return client.nodeClient.PollForTransactions(txnHashes, options...)
 }
 
-// WaitForTransaction Do a long-GET for one transaction and wait for it to complete
-func (client *Client) WaitForTransaction(txnHash string) (data *api.UserTransaction, err error) {
-	return client.nodeClient.WaitForTransaction(txnHash)
+// PollForTransactionsCtx is [Client.PollForTransactions] with a [context.Context] for cancellation and deadlines
+func (client *Client) PollForTransactionsCtx(ctx context.Context, txnHashes []string, options ...any) error {
+	return client.nodeClient.PollForTransactionsCtx(ctx, txnHashes, options...)
+}
+
+// PollForTransactionsWithOptions Waits for transactions to be done like [Client.PollForTransactions], with typed
+// options so that mistakes are caught at compile time
+//
+//	err := client.PollForTransactionsWithOptions(hashes, WithPollPeriod(500*time.Millisecond), WithPollTimeout(5*time.Second))
+func (client *Client) PollForTransactionsWithOptions(txnHashes []string, options ...PollOption) error {
+	return client.nodeClient.PollForTransactionsWithOptions(txnHashes, options...)
+}
+
+// PollForTransactionsWithOptionsCtx is [Client.PollForTransactionsWithOptions] with a [context.Context] for
+// cancellation and deadlines
+func (client *Client) PollForTransactionsWithOptionsCtx(ctx context.Context, txnHashes []string, options ...PollOption) error {
+	return client.nodeClient.PollForTransactionsWithOptionsCtx(ctx, txnHashes, options...)
+}
+
+// WaitForTransaction Waits for one transaction to complete.  Pass [WaitModeCommitted] to wait until it is committed or
+// expired, with typed errors for failed and expired transactions, see [NodeClient.WaitForTransaction].
+//
+//	txn, err := client.WaitForTransaction(submitResponse.Hash, WaitModeCommitted)
+func (client *Client) WaitForTransaction(txnHash string, options ...any) (data *api.UserTransaction, err error) {
+	return client.nodeClient.WaitForTransaction(txnHash, options...)
+}
+
+// WaitForTransactionCtx is [Client.WaitForTransaction] with a [context.Context] for cancellation and deadlines
+func (client *Client) WaitForTransactionCtx(ctx context.Context, txnHash string, options ...any) (data *api.UserTransaction, err error) {
+	return client.nodeClient.WaitForTransactionCtx(ctx, txnHash, options...)
 }
 
 // Transactions Get recent transactions.
@@ -290,22 +544,230 @@ func (client *Client) Transactions(start *uint64, limit *uint64) (data []*api.Tr
 	return client.nodeClient.Transactions(start, limit)
 }
 
+// TransactionsCtx is [Client.Transactions] with a [context.Context] for cancellation and deadlines
+func (client *Client) TransactionsCtx(ctx context.Context, start *uint64, limit *uint64) (data []*api.Transaction, err error) {
+	return client.nodeClient.TransactionsCtx(ctx, start, limit)
+}
+
+// TransactionsBCS Get recent transactions as BCS, with their TransactionInfo, events, and write sets.
+// Start is a version number. Nil for most recent transactions.
+// Limit is a number of transactions to return. 'about a hundred' by default.
+func (client *Client) TransactionsBCS(start *uint64, limit *uint64) (data []*TransactionOnChainData, err error) {
+	return client.nodeClient.TransactionsBCS(start, limit)
+}
+
+// TransactionsBCSCtx is [Client.TransactionsBCS] with a [context.Context] for cancellation and deadlines
+func (client *Client) TransactionsBCSCtx(ctx context.Context, start *uint64, limit *uint64) (data []*TransactionOnChainData, err error) {
+	return client.nodeClient.TransactionsBCSCtx(ctx, start, limit)
+}
+
+// AccountTransactions Get the committed transactions sent by an account.
+// Start is a sequence number. Nil for the most recent transactions.
+// Limit is a number of transactions to return. 'about a hundred' by default.
+//
+//	start := uint64(0)
+//	limit := uint64(10)
+//	client.AccountTransactions(address, &start, &limit) // Returns the first 10 transactions sent by the account
+func (client *Client) AccountTransactions(address AccountAddress, start *uint64, limit *uint64) (data []*api.Transaction, err error) {
+	return client.nodeClient.AccountTransactions(address, start, limit)
+}
+
+// AccountTransactionsCtx is [Client.AccountTransactions] with a [context.Context] for cancellation and deadlines
+func (client *Client) AccountTransactionsCtx(ctx context.Context, address AccountAddress, start *uint64, limit *uint64) (data []*api.Transaction, err error) {
+	return client.nodeClient.AccountTransactionsCtx(ctx, address, start, limit)
+}
+
+// AccountTransactionsIterator Pages through the full transaction history of an account, starting at the given sequence
+// number.  PageSize is the number of transactions fetched per request, 0 for the node's default.
+//
+//	txns, err := client.AccountTransactionsIterator(address, 0, 100).Collect()
+func (client *Client) AccountTransactionsIterator(address AccountAddress, start uint64, pageSize uint64) *Iterator[*api.Transaction] {
+	return client.nodeClient.AccountTransactionsIterator(address, start, pageSize)
+}
+
+// AccountTransactionsIteratorCtx is [Client.AccountTransactionsIterator] with a [context.Context] for cancellation and
+// deadlines
+func (client *Client) AccountTransactionsIteratorCtx(ctx context.Context, address AccountAddress, start uint64, pageSize uint64) *Iterator[*api.Transaction] {
+	return client.nodeClient.AccountTransactionsIteratorCtx(ctx, address, start, pageSize)
+}
+
+// EventsByCreationNumber Get the V1 events of the event handle created with the given creation number on an account.
+// Start is a sequence number. Nil for the most recent events.
+// Limit is a number of events to return. 'about a hundred' by default.
+func (client *Client) EventsByCreationNumber(address AccountAddress, creationNumber uint64, start *uint64, limit *uint64) (data []*api.VersionedEvent, err error) {
+	return client.nodeClient.EventsByCreationNumber(address, creationNumber, start, limit)
+}
+
+// EventsByCreationNumberCtx is [Client.EventsByCreationNumber] with a [context.Context] for cancellation and deadlines
+func (client *Client) EventsByCreationNumberCtx(ctx context.Context, address AccountAddress, creationNumber uint64, start *uint64, limit *uint64) (data []*api.VersionedEvent, err error) {
+	return client.nodeClient.EventsByCreationNumberCtx(ctx, address, creationNumber, start, limit)
+}
+
+// EventsByHandle Get the V1 events of an event handle field of a resource on an account.
+// Start is a sequence number. Nil for the most recent events.
+// Limit is a number of events to return. 'about a hundred' by default.
+//
+//	client.EventsByHandle(address, "0x1::coin::CoinStore<0x1::aptos_coin::AptosCoin>", "deposit_events", nil, nil)
+func (client *Client) EventsByHandle(address AccountAddress, eventHandle string, fieldName string, start *uint64, limit *uint64) (data []*api.VersionedEvent, err error) {
+	return client.nodeClient.EventsByHandle(address, eventHandle, fieldName, start, limit)
+}
+
+// EventsByHandleCtx is [Client.EventsByHandle] with a [context.Context] for cancellation and deadlines
+func (client *Client) EventsByHandleCtx(ctx context.Context, address AccountAddress, eventHandle string, fieldName string, start *uint64, limit *uint64) (data []*api.VersionedEvent, err error) {
+	return client.nodeClient.EventsByHandleCtx(ctx, address, eventHandle, fieldName, start, limit)
+}
+
+// EventsByCreationNumberIterator Pages through the events of an event handle by creation number, starting at the given
+// sequence number.  PageSize is the number of events fetched per request, 0 for the node's default.
+func (client *Client) EventsByCreationNumberIterator(address AccountAddress, creationNumber uint64, start uint64, pageSize uint64) *Iterator[*api.VersionedEvent] {
+	return client.nodeClient.EventsByCreationNumberIterator(address, creationNumber, start, pageSize)
+}
+
+// EventsByCreationNumberIteratorCtx is [Client.EventsByCreationNumberIterator] with a [context.Context] for
+// cancellation and deadlines
+func (client *Client) EventsByCreationNumberIteratorCtx(ctx context.Context, address AccountAddress, creationNumber uint64, start uint64, pageSize uint64) *Iterator[*api.VersionedEvent] {
+	return client.nodeClient.EventsByCreationNumberIteratorCtx(ctx, address, creationNumber, start, pageSize)
+}
+
+// EventsByHandleIterator Pages through the events of an event handle field, starting at the given sequence number.
+// PageSize is the number of events fetched per request, 0 for the node's default.
+//
+//	events, err := client.EventsByHandleIterator(address, "0x1::coin::CoinStore<0x1::aptos_coin::AptosCoin>", "deposit_events", 0, 100).Collect()
+func (client *Client) EventsByHandleIterator(address AccountAddress, eventHandle string, fieldName string, start uint64, pageSize uint64) *Iterator[*api.VersionedEvent] {
+	return client.nodeClient.EventsByHandleIterator(address, eventHandle, fieldName, start, pageSize)
+}
+
+// EventsByHandleIteratorCtx is [Client.EventsByHandleIterator] with a [context.Context] for cancellation and deadlines
+func (client *Client) EventsByHandleIteratorCtx(ctx context.Context, address AccountAddress, eventHandle string, fieldName string, start uint64, pageSize uint64) *Iterator[*api.VersionedEvent] {
+	return client.nodeClient.EventsByHandleIteratorCtx(ctx, address, eventHandle, fieldName, start, pageSize)
+}
+
+// FollowEventsByHandle Tails an event handle field from the given sequence number, calling handler with each event and
+// polling for new events once caught up.  It runs until the context is cancelled, or the handler or a request returns
+// an error.  Accepts option PollPeriod, which defaults to 1 second.
+//
+//	err := client.FollowEventsByHandle(ctx, address, "0x1::coin::CoinStore<0x1::aptos_coin::AptosCoin>", "deposit_events", 0,
+//		func(event *api.VersionedEvent) error {
+//			fmt.Printf("deposit of %v at version %d\n", event.Data["amount"], event.Version)
+//			return nil
+//		}, PollPeriod(5*time.Second))
+func (client *Client) FollowEventsByHandle(ctx context.Context, address AccountAddress, eventHandle string, fieldName string, start uint64, handler func(event *api.VersionedEvent) error, options ...any) error {
+	return client.nodeClient.FollowEventsByHandle(ctx, address, eventHandle, fieldName, start, handler, options...)
+}
+
+// SubscribeBlocks Delivers blocks in order of height starting at fromHeight, polling for new blocks once caught up.  See
+// [NodeClient.SubscribeBlocks] for options.
+//
+//	sub, err := client.SubscribeBlocks(ctx, fromHeight)
+//	for block := range sub.Items() {
+//		// handle block
+//	}
+//	fromHeight = sub.Checkpoint()
+func (client *Client) SubscribeBlocks(ctx context.Context, fromHeight uint64, options ...any) (*Subscription[*api.Block], error) {
+	return client.nodeClient.SubscribeBlocks(ctx, fromHeight, options...)
+}
+
+// SubscribeTransactions Delivers committed transactions matching the filter in order of version starting at
+// fromVersion, polling for new transactions once caught up.  See [NodeClient.SubscribeTransactions] for options.
+//
+//	sub, err := client.SubscribeTransactions(ctx, fromVersion, &TransactionFilter{Sender: &address})
+//	for txn := range sub.Items() {
+//		// handle txn
+//	}
+//	fromVersion = sub.Checkpoint()
+func (client *Client) SubscribeTransactions(ctx context.Context, fromVersion uint64, filter *TransactionFilter, options ...any) (*Subscription[*api.Transaction], error) {
+	return client.nodeClient.SubscribeTransactions(ctx, fromVersion, filter, options...)
+}
+
+// TableItem Reads an item from a Move table by its handle, returning the value decoded as JSON.  The key is encoded as
+// JSON the same way as view function arguments.
+//
+//	keyType := TypeTag{Value: &AddressTag{}}
+//	valueType := TypeTag{Value: &U64Tag{}}
+//	value, err := client.TableItem(handle, keyType, valueType, "0x1")
+func (client *Client) TableItem(handle string, keyType TypeTag, valueType TypeTag, key any, ledgerVersion ...uint64) (data any, err error) {
+	return client.nodeClient.TableItem(handle, keyType, valueType, key, ledgerVersion...)
+}
+
+// TableItemCtx is [Client.TableItem] with a [context.Context] for cancellation and deadlines
+func (client *Client) TableItemCtx(ctx context.Context, handle string, keyType TypeTag, valueType TypeTag, key any, ledgerVersion ...uint64) (data any, err error) {
+	return client.nodeClient.TableItemCtx(ctx, handle, keyType, valueType, key, ledgerVersion...)
+}
+
+// TableItemBCS Reads an item from a Move table by its handle and the BCS encoded key, returning the BCS encoded value
+//
+//	key, _ := bcs.SerializeU64(1)
+//	valueBytes, err := client.TableItemBCS(handle, key)
+func (client *Client) TableItemBCS(handle string, key []byte, ledgerVersion ...uint64) (data []byte, err error) {
+	return client.nodeClient.TableItemBCS(handle, key, ledgerVersion...)
+}
+
+// TableItemBCSCtx is [Client.TableItemBCS] with a [context.Context] for cancellation and deadlines
+func (client *Client) TableItemBCSCtx(ctx context.Context, handle string, key []byte, ledgerVersion ...uint64) (data []byte, err error) {
+	return client.nodeClient.TableItemBCSCtx(ctx, handle, key, ledgerVersion...)
+}
+
 // SubmitTransaction Submits an already signed transaction to the blockchain
 func (client *Client) SubmitTransaction(signedTransaction *SignedTransaction) (data *api.SubmitTransactionResponse, err error) {
 	return client.nodeClient.SubmitTransaction(signedTransaction)
 }
 
+// SubmitTransactionCtx is [Client.SubmitTransaction] with a [context.Context] for cancellation and deadlines
+func (client *Client) SubmitTransactionCtx(ctx context.Context, signedTransaction *SignedTransaction) (data *api.SubmitTransactionResponse, err error) {
+	return client.nodeClient.SubmitTransactionCtx(ctx, signedTransaction)
+}
+
+// SubmitTransactions Submits many already signed transactions in batches, returning which were accepted and why each of
+// the others was rejected
+//
+//	result, err := client.SubmitTransactions(signedTxns)
+//	for _, failure := range result.Failures {
+//		fmt.Printf("transaction %d rejected: %s\n", failure.Index, failure.Message)
+//	}
+func (client *Client) SubmitTransactions(signedTxns []*SignedTransaction) (result *BatchSubmissionResult, err error) {
+	return client.nodeClient.SubmitTransactions(signedTxns)
+}
+
+// SubmitTransactionsCtx is [Client.SubmitTransactions] with a [context.Context] for cancellation and deadlines
+func (client *Client) SubmitTransactionsCtx(ctx context.Context, signedTxns []*SignedTransaction) (result *BatchSubmissionResult, err error) {
+	return client.nodeClient.SubmitTransactionsCtx(ctx, signedTxns)
+}
+
+// SimulateTransaction Simulates a transaction with zeroed signatures, returning the expected gas used, VM status,
+// changes, and events.  See [NodeClient.SimulateTransaction] for which public keys to pass.
+//
+//	rawTxn, _ := client.BuildTransaction(sender.AccountAddress(), txnPayload)
+//	simulated, err := client.SimulateTransaction(rawTxn, sender.PubKey())
+func (client *Client) SimulateTransaction(rawTxn RawTransactionImpl, signers ...crypto.PublicKey) (data []*api.UserTransaction, err error) {
+	return client.nodeClient.SimulateTransaction(rawTxn, signers...)
+}
+
+// SimulateTransactionCtx is [Client.SimulateTransaction] with a [context.Context] for cancellation and deadlines
+func (client *Client) SimulateTransactionCtx(ctx context.Context, rawTxn RawTransactionImpl, signers ...crypto.PublicKey) (data []*api.UserTransaction, err error) {
+	return client.nodeClient.SimulateTransactionCtx(ctx, rawTxn, signers...)
+}
+
 // GetChainId Retrieves the ChainId of the network
 // Note this will be cached forever, or taken directly from the config
 func (client *Client) GetChainId() (chainId uint8, err error) {
 	return client.nodeClient.GetChainId()
 }
 
+// GetChainIdCtx is [Client.GetChainId] with a [context.Context] for cancellation and deadlines
+func (client *Client) GetChainIdCtx(ctx context.Context) (chainId uint8, err error) {
+	return client.nodeClient.GetChainIdCtx(ctx)
+}
+
 // Fund Uses the faucet to fund an address, only applies to non-production networks
 func (client *Client) Fund(address AccountAddress, amount uint64) error {
 	return client.faucetClient.Fund(address, amount)
 }
 
+// FundCtx is [Client.Fund] with a [context.Context] for cancellation and deadlines
+func (client *Client) FundCtx(ctx context.Context, address AccountAddress, amount uint64) error {
+	return client.faucetClient.FundCtx(ctx, address, amount)
+}
+
 // BuildTransaction Builds a raw transaction from the payload and fetches any necessary information from on-chain
 //
 //	sender := NewEd25519Account()
@@ -328,6 +790,59 @@ func (client *Client) BuildTransaction(sender AccountAddress, payload Transactio
 	return client.nodeClient.BuildTransaction(sender, payload, options...)
 }
 
+// BuildTransactionCtx is [Client.BuildTransaction] with a [context.Context] for cancellation and deadlines
+func (client *Client) BuildTransactionCtx(ctx context.Context, sender AccountAddress, payload TransactionPayload, options ...any) (rawTxn *RawTransaction, err error) {
+	return client.nodeClient.BuildTransactionCtx(ctx, sender, payload, options...)
+}
+
+// BuildTransactionWithOptions Builds a raw transaction from the payload like [Client.BuildTransaction], with typed
+// options so that mistakes are caught at compile time
+//
+//	rawTxn, err := client.BuildTransactionWithOptions(sender.AccountAddress(), txnPayload,
+//		WithEstimatedGasUnitPrice(GasPricePriorityNormal),
+//		WithExpirationSeconds(60),
+//	)
+func (client *Client) BuildTransactionWithOptions(sender AccountAddress, payload TransactionPayload, options ...BuildOption) (rawTxn *RawTransaction, err error) {
+	return client.nodeClient.BuildTransactionWithOptions(sender, payload, options...)
+}
+
+// BuildTransactionWithOptionsCtx is [Client.BuildTransactionWithOptions] with a [context.Context] for cancellation and
+// deadlines
+func (client *Client) BuildTransactionWithOptionsCtx(ctx context.Context, sender AccountAddress, payload TransactionPayload, options ...BuildOption) (rawTxn *RawTransaction, err error) {
+	return client.nodeClient.BuildTransactionWithOptionsCtx(ctx, sender, payload, options...)
+}
+
+// BuildTransactionMultiAgent Builds a raw transaction with a fee payer or secondary signers, see
+// [NodeClient.BuildTransactionMultiAgent]
+//
+//	rawTxn, err := client.BuildTransactionMultiAgent(sender.AccountAddress(), txnPayload, FeePayer(&sponsor.Address))
+func (client *Client) BuildTransactionMultiAgent(sender AccountAddress, payload TransactionPayload, options ...any) (rawTxn *RawTransactionWithData, err error) {
+	return client.nodeClient.BuildTransactionMultiAgent(sender, payload, options...)
+}
+
+// BuildTransactionMultiAgentCtx is [Client.BuildTransactionMultiAgent] with a [context.Context] for cancellation and
+// deadlines
+func (client *Client) BuildTransactionMultiAgentCtx(ctx context.Context, sender AccountAddress, payload TransactionPayload, options ...any) (rawTxn *RawTransactionWithData, err error) {
+	return client.nodeClient.BuildTransactionMultiAgentCtx(ctx, sender, payload, options...)
+}
+
+// BuildTransactionMultiAgentWithOptions Builds a raw transaction with a fee payer or secondary signers, with typed
+// options so that mistakes are caught at compile time
+//
+//	rawTxn, err := client.BuildTransactionMultiAgentWithOptions(sender.AccountAddress(), txnPayload,
+//		WithFeePayer(sponsor.Address),
+//		WithSecondarySigners(cosigner.Address),
+//	)
+func (client *Client) BuildTransactionMultiAgentWithOptions(sender AccountAddress, payload TransactionPayload, options ...BuildOption) (rawTxn *RawTransactionWithData, err error) {
+	return client.nodeClient.BuildTransactionMultiAgentWithOptions(sender, payload, options...)
+}
+
+// BuildTransactionMultiAgentWithOptionsCtx is [Client.BuildTransactionMultiAgentWithOptions] with a [context.Context]
+// for cancellation and deadlines
+func (client *Client) BuildTransactionMultiAgentWithOptionsCtx(ctx context.Context, sender AccountAddress, payload TransactionPayload, options ...BuildOption) (rawTxn *RawTransactionWithData, err error) {
+	return client.nodeClient.BuildTransactionMultiAgentWithOptionsCtx(ctx, sender, payload, options...)
+}
+
 // BuildSignAndSubmitTransaction Convenience function to do all three in one
 // for more configuration, please use them separately
 //
@@ -351,6 +866,53 @@ func (client *Client) BuildSignAndSubmitTransaction(sender *Account, payload Tra
 	return client.nodeClient.BuildSignAndSubmitTransaction(sender, payload, options...)
 }
 
+// BuildSignAndSubmitTransactionCtx is [Client.BuildSignAndSubmitTransaction] with a [context.Context] for cancellation and deadlines
+func (client *Client) BuildSignAndSubmitTransactionCtx(ctx context.Context, sender *Account, payload TransactionPayload, options ...any) (data *api.SubmitTransactionResponse, err error) {
+	return client.nodeClient.BuildSignAndSubmitTransactionCtx(ctx, sender, payload, options...)
+}
+
+// BuildSignAndSubmitTransactionWithOptions Convenience function to build, sign, and submit like
+// [Client.BuildSignAndSubmitTransaction], with typed options so that mistakes are caught at compile time
+//
+//	submitResponse, err := client.BuildSignAndSubmitTransactionWithOptions(sender, txnPayload, WithSimulatedMaxGasAmount(1.5))
+func (client *Client) BuildSignAndSubmitTransactionWithOptions(sender *Account, payload TransactionPayload, options ...BuildOption) (data *api.SubmitTransactionResponse, err error) {
+	return client.nodeClient.BuildSignAndSubmitTransactionWithOptions(sender, payload, options...)
+}
+
+// BuildSignAndSubmitTransactionWithOptionsCtx is [Client.BuildSignAndSubmitTransactionWithOptions] with a
+// [context.Context] for cancellation and deadlines
+func (client *Client) BuildSignAndSubmitTransactionWithOptionsCtx(ctx context.Context, sender *Account, payload TransactionPayload, options ...BuildOption) (data *api.SubmitTransactionResponse, err error) {
+	return client.nodeClient.BuildSignAndSubmitTransactionWithOptionsCtx(ctx, sender, payload, options...)
+}
+
+// BuildSponsoredTransaction Builds a transaction for a fee payer to pay for, with the fee payer left as a placeholder
+// so that the sender can sign first, see [NodeClient.BuildSponsoredTransaction]
+//
+//	rawTxn, err := client.BuildSponsoredTransaction(sender.Address, payload)
+//	senderAuth, err := rawTxn.Sign(sender)
+//	feePayerAuth, err := rawTxn.SignAsFeePayer(feePayer)
+//	response, err := client.SubmitSponsored(rawTxn, senderAuth, feePayerAuth)
+func (client *Client) BuildSponsoredTransaction(sender AccountAddress, payload TransactionPayload, options ...BuildOption) (rawTxn *RawTransactionWithData, err error) {
+	return client.nodeClient.BuildSponsoredTransaction(sender, payload, options...)
+}
+
+// BuildSponsoredTransactionCtx is [Client.BuildSponsoredTransaction] with a [context.Context] for cancellation and
+// deadlines
+func (client *Client) BuildSponsoredTransactionCtx(ctx context.Context, sender AccountAddress, payload TransactionPayload, options ...BuildOption) (rawTxn *RawTransactionWithData, err error) {
+	return client.nodeClient.BuildSponsoredTransactionCtx(ctx, sender, payload, options...)
+}
+
+// SubmitSponsored Assembles a fee payer transaction from the sender's and fee payer's authenticators and submits it,
+// see [NodeClient.SubmitSponsored]
+func (client *Client) SubmitSponsored(rawTxn *RawTransactionWithData, sender *crypto.AccountAuthenticator, feePayer *crypto.AccountAuthenticator, secondarySigners ...crypto.AccountAuthenticator) (data *api.SubmitTransactionResponse, err error) {
+	return client.nodeClient.SubmitSponsored(rawTxn, sender, feePayer, secondarySigners...)
+}
+
+// SubmitSponsoredCtx is [Client.SubmitSponsored] with a [context.Context] for cancellation and deadlines
+func (client *Client) SubmitSponsoredCtx(ctx context.Context, rawTxn *RawTransactionWithData, sender *crypto.AccountAuthenticator, feePayer *crypto.AccountAuthenticator, secondarySigners ...crypto.AccountAuthenticator) (data *api.SubmitTransactionResponse, err error) {
+	return client.nodeClient.SubmitSponsoredCtx(ctx, rawTxn, sender, feePayer, secondarySigners...)
+}
+
 // View Runs a view function on chain returning a list of return values.
 //
 //	 address := AccountOne
@@ -369,16 +931,98 @@ func (client *Client) View(payload *ViewPayload, ledgerVersion ...uint64) (vals
 	return client.nodeClient.View(payload, ledgerVersion...)
 }
 
+// ViewCtx is [Client.View] with a [context.Context] for cancellation and deadlines
+func (client *Client) ViewCtx(ctx context.Context, payload *ViewPayload, ledgerVersion ...uint64) (vals []any, err error) {
+	return client.nodeClient.ViewCtx(ctx, payload, ledgerVersion...)
+}
+
+// ViewBCS Runs a view function like [Client.View], returning each return value BCS encoded rather than as JSON
+//
+//	values, err := client.ViewBCS(payload)
+//	balance := bcs.NewDeserializer(values[0]).U64()
+func (client *Client) ViewBCS(payload *ViewPayload, ledgerVersion ...uint64) (values [][]byte, err error) {
+	return client.nodeClient.ViewBCS(payload, ledgerVersion...)
+}
+
+// ViewBCSCtx is [Client.ViewBCS] with a [context.Context] for cancellation and deadlines
+func (client *Client) ViewBCSCtx(ctx context.Context, payload *ViewPayload, ledgerVersion ...uint64) (values [][]byte, err error) {
+	return client.nodeClient.ViewBCSCtx(ctx, payload, ledgerVersion...)
+}
+
+// ViewJSON Runs a view function on chain with JSON arguments, for when arguments aren't BCS encoded
+//
+//	values, err := client.ViewJSON(&ViewJSONPayload{
+//		Function:      "0x1::coin::balance",
+//		TypeArguments: []string{"0x1::aptos_coin::AptosCoin"},
+//		Arguments:     []any{"0xcafe"},
+//	})
+func (client *Client) ViewJSON(payload *ViewJSONPayload, ledgerVersion ...uint64) (vals []any, err error) {
+	return client.nodeClient.ViewJSON(payload, ledgerVersion...)
+}
+
+// ViewJSONCtx is [Client.ViewJSON] with a [context.Context] for cancellation and deadlines
+func (client *Client) ViewJSONCtx(ctx context.Context, payload *ViewJSONPayload, ledgerVersion ...uint64) (vals []any, err error) {
+	return client.nodeClient.ViewJSONCtx(ctx, payload, ledgerVersion...)
+}
+
+// FunctionAbi Fetches the ABI of a function from its module, modules are cached as in [Client.AccountModule]
+func (client *Client) FunctionAbi(module ModuleId, function string, ledgerVersion ...uint64) (abi *api.MoveFunction, err error) {
+	return client.nodeClient.FunctionAbi(module, function, ledgerVersion...)
+}
+
+// FunctionAbiCtx is [Client.FunctionAbi] with a [context.Context] for cancellation and deadlines
+func (client *Client) FunctionAbiCtx(ctx context.Context, module ModuleId, function string, ledgerVersion ...uint64) (abi *api.MoveFunction, err error) {
+	return client.nodeClient.FunctionAbiCtx(ctx, module, function, ledgerVersion...)
+}
+
+// EntryFunctionFromAbi Builds an entry function payload from its function id and Go or string arguments, serialized
+// with the function's ABI.  See [NodeClient.EntryFunctionFromAbi] for the accepted arguments.
+//
+//	payload, err := client.EntryFunctionFromAbi("0x1::aptos_account::transfer", nil, []any{"0xcafe", "100"})
+//	rawTxn, err := client.BuildTransaction(sender.AccountAddress(), TransactionPayload{Payload: payload})
+func (client *Client) EntryFunctionFromAbi(functionId string, typeArgs []string, args []any, ledgerVersion ...uint64) (payload *EntryFunction, err error) {
+	return client.nodeClient.EntryFunctionFromAbi(functionId, typeArgs, args, ledgerVersion...)
+}
+
+// EntryFunctionFromAbiCtx is [Client.EntryFunctionFromAbi] with a [context.Context] for cancellation and deadlines
+func (client *Client) EntryFunctionFromAbiCtx(ctx context.Context, functionId string, typeArgs []string, args []any, ledgerVersion ...uint64) (payload *EntryFunction, err error) {
+	return client.nodeClient.EntryFunctionFromAbiCtx(ctx, functionId, typeArgs, args, ledgerVersion...)
+}
+
+// ViewPayloadFromAbi Builds a view function payload from its function id and Go or string arguments, serialized with
+// the function's ABI.  See [NodeClient.EntryFunctionFromAbi] for the accepted arguments.
+//
+//	payload, err := client.ViewPayloadFromAbi("0x1::coin::balance", []string{"0x1::aptos_coin::AptosCoin"}, []any{"0xcafe"})
+//	balance, err := ViewInto[uint64](client, payload)
+func (client *Client) ViewPayloadFromAbi(functionId string, typeArgs []string, args []any, ledgerVersion ...uint64) (payload *ViewPayload, err error) {
+	return client.nodeClient.ViewPayloadFromAbi(functionId, typeArgs, args, ledgerVersion...)
+}
+
+// ViewPayloadFromAbiCtx is [Client.ViewPayloadFromAbi] with a [context.Context] for cancellation and deadlines
+func (client *Client) ViewPayloadFromAbiCtx(ctx context.Context, functionId string, typeArgs []string, args []any, ledgerVersion ...uint64) (payload *ViewPayload, err error) {
+	return client.nodeClient.ViewPayloadFromAbiCtx(ctx, functionId, typeArgs, args, ledgerVersion...)
+}
+
 // EstimateGasPrice Retrieves the gas estimate from the network.
 func (client *Client) EstimateGasPrice() (info EstimateGasInfo, err error) {
 	return client.nodeClient.EstimateGasPrice()
 }
 
+// EstimateGasPriceCtx is [Client.EstimateGasPrice] with a [context.Context] for cancellation and deadlines
+func (client *Client) EstimateGasPriceCtx(ctx context.Context) (info EstimateGasInfo, err error) {
+	return client.nodeClient.EstimateGasPriceCtx(ctx)
+}
+
 // AccountAPTBalance retrieves the APT balance in the account
 func (client *Client) AccountAPTBalance(address AccountAddress) (uint64, error) {
 	return client.nodeClient.AccountAPTBalance(address)
 }
 
+// AccountAPTBalanceCtx is [Client.AccountAPTBalance] with a [context.Context] for cancellation and deadlines
+func (client *Client) AccountAPTBalanceCtx(ctx context.Context, address AccountAddress) (uint64, error) {
+	return client.nodeClient.AccountAPTBalanceCtx(ctx, address)
+}
+
 // QueryIndexer queries the indexer using GraphQL to fill the `query` struct with data.  See examples in the indexer client on how to make queries
 //
 //	var out []CoinBalance
@@ -409,12 +1053,27 @@ func (client *Client) QueryIndexer(query any, variables map[string]any, options
 	return client.indexerClient.Query(query, variables, options...)
 }
 
+// QueryIndexerCtx is [Client.QueryIndexer] with a [context.Context] for cancellation and deadlines
+func (client *Client) QueryIndexerCtx(ctx context.Context, query any, variables map[string]any, options ...graphql.Option) error {
+	return client.indexerClient.QueryCtx(ctx, query, variables, options...)
+}
+
 // GetProcessorStatus returns the ledger version up to which the processor has processed
 func (client *Client) GetProcessorStatus(processorName string) (uint64, error) {
 	return client.indexerClient.GetProcessorStatus(processorName)
 }
 
+// GetProcessorStatusCtx is [Client.GetProcessorStatus] with a [context.Context] for cancellation and deadlines
+func (client *Client) GetProcessorStatusCtx(ctx context.Context, processorName string) (uint64, error) {
+	return client.indexerClient.GetProcessorStatusCtx(ctx, processorName)
+}
+
 // GetCoinBalances gets the balances of all coins associated with a given address
 func (client *Client) GetCoinBalances(address AccountAddress) ([]CoinBalance, error) {
 	return client.indexerClient.GetCoinBalances(address)
 }
+
+// GetCoinBalancesCtx is [Client.GetCoinBalances] with a [context.Context] for cancellation and deadlines
+func (client *Client) GetCoinBalancesCtx(ctx context.Context, address AccountAddress) ([]CoinBalance, error) {
+	return client.indexerClient.GetCoinBalancesCtx(ctx, address)
+}
@@ -10,6 +10,11 @@ const ClientHeader = "x-aptos-client"
 
 var ClientHeaderValue = "aptos-go-sdk/unk"
 
+// SdkVersion is the version of this module in the build, e.g. "v1.2.0", or "unk" if it isn't known
+var SdkVersion = "unk"
+
+const sdkModulePath = "github.com/aptos-labs/aptos-go-sdk"
+
 func init() {
 	vcsRevision := "unk"
 	vcsMod := ""
@@ -43,12 +48,24 @@ func init() {
 		params.Set("os", goOs)
 	}
 	ClientHeaderValue = fmt.Sprintf("aptos-go-sdk/%s;%s", vcsRevision, params.Encode())
+
+	if ok {
+		// The SDK is the main module in its own tests and tools, and a dependency otherwise
+		modules := append([]*debug.Module{&buildInfo.Main}, buildInfo.Deps...)
+		for _, module := range modules {
+			if module.Path == sdkModulePath && module.Version != "" && module.Version != "(devel)" {
+				SdkVersion = module.Version
+				break
+			}
+		}
+	}
 }
 
 // APTTransferTransaction Move some APT from sender to dest, only for single signer
 // Amount in Octas (10^-8 APT)
 //
-// options may be: MaxGasAmount, GasUnitPrice, ExpirationSeconds, ValidUntil, SequenceNumber, ChainIdOption
+// options may be: MaxGasAmount, GasUnitPrice, ExpirationSeconds, SequenceNumber, ChainIdOption, or any [BuildOption],
+// see [APTTransferTransactionWithOptions] for typed options
 // deprecated, please use the EntryFunction APIs
 func APTTransferTransaction(client *Client, sender TransactionSigner, dest AccountAddress, amount uint64, options ...any) (signedTxn *SignedTransaction, err error) {
 	entryFunction, err := CoinTransferPayload(nil, dest, amount)
@@ -63,3 +80,22 @@ func APTTransferTransaction(client *Client, sender TransactionSigner, dest Accou
 	}
 	return rawTxn.SignedTransaction(sender)
 }
+
+// APTTransferTransactionWithOptions Move some APT from sender to dest like [APTTransferTransaction], with typed options
+// so that mistakes are caught at compile time
+// Amount in Octas (10^-8 APT)
+//
+//	signedTxn, err := APTTransferTransactionWithOptions(client, sender, dest, 100, WithMaxGasAmount(1000))
+func APTTransferTransactionWithOptions(client *Client, sender TransactionSigner, dest AccountAddress, amount uint64, options ...BuildOption) (signedTxn *SignedTransaction, err error) {
+	entryFunction, err := CoinTransferPayload(nil, dest, amount)
+	if err != nil {
+		return nil, err
+	}
+
+	rawTxn, err := client.BuildTransactionWithOptions(sender.AccountAddress(),
+		TransactionPayload{Payload: entryFunction}, options...)
+	if err != nil {
+		return
+	}
+	return rawTxn.SignedTransaction(sender)
+}
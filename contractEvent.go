@@ -0,0 +1,68 @@
+package aptos
+
+import (
+	"fmt"
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+)
+
+//region ContractEvent
+
+type ContractEventVariant uint32
+
+const (
+	ContractEventVariantV1 ContractEventVariant = 0 // Event emitted to an event handle
+	ContractEventVariantV2 ContractEventVariant = 1 // Module event
+)
+
+// ContractEvent is an event emitted by a transaction, the event Data is the BCS encoded Move struct of the event Type.
+//
+// CreationNumber, Address, and SequenceNumber identify the event handle of a V1 event, and are unset for a V2 event.
+// Implements bcs.Struct
+type ContractEvent struct {
+	Variant        ContractEventVariant
+	CreationNumber uint64
+	Address        AccountAddress
+	SequenceNumber uint64
+	Type           TypeTag
+	Data           []byte
+}
+
+//region ContractEvent bcs.Struct
+
+func (event *ContractEvent) MarshalBCS(ser *bcs.Serializer) {
+	ser.Uleb128(uint32(event.Variant))
+	switch event.Variant {
+	case ContractEventVariantV1:
+		ser.U64(event.CreationNumber)
+		ser.Struct(&event.Address)
+		ser.U64(event.SequenceNumber)
+	case ContractEventVariantV2:
+	default:
+		ser.SetError(fmt.Errorf("unknown ContractEvent variant %d", event.Variant))
+		return
+	}
+	ser.Struct(&event.Type)
+	ser.WriteBytes(event.Data)
+}
+
+func (event *ContractEvent) UnmarshalBCS(des *bcs.Deserializer) {
+	event.Variant = ContractEventVariant(des.Uleb128())
+	if des.Error() != nil {
+		return
+	}
+	switch event.Variant {
+	case ContractEventVariantV1:
+		event.CreationNumber = des.U64()
+		des.Struct(&event.Address)
+		event.SequenceNumber = des.U64()
+	case ContractEventVariantV2:
+	default:
+		des.SetError(fmt.Errorf("unknown ContractEvent variant %d", event.Variant))
+		return
+	}
+	des.Struct(&event.Type)
+	event.Data = des.ReadBytes()
+}
+
+//endregion
+//endregion
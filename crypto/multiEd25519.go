@@ -24,15 +24,19 @@ func (key *MultiEd25519PublicKey) Verify(msg []byte, signature Signature) bool {
 	switch signature.(type) {
 	case *MultiEd25519Signature:
 		sig := signature.(*MultiEd25519Signature)
-		verified := uint8(0)
-		// TODO: Verify with bitmap, and check that this works properly
+		// The signatures are those of the keys in the bitmap, in order of key index, and every one must verify
+		verified := 0
 		for i, pubKey := range key.PubKeys {
-			if pubKey.Verify(msg, sig.Signatures[i]) {
-				verified++
+			if sig.Bitmap[i/8]&(128>>(i%8)) == 0 {
+				continue
 			}
+			if verified >= len(sig.Signatures) || !pubKey.Verify(msg, sig.Signatures[verified]) {
+				return false
+			}
+			verified++
 		}
 
-		return verified >= key.SignaturesRequired
+		return verified == len(sig.Signatures) && verified >= int(key.SignaturesRequired)
 	default:
 		return false
 	}
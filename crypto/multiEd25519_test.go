@@ -93,6 +93,6 @@ func createMultiEd25519Signature(t *testing.T, key1 *Ed25519PrivateKey, key2 *Ed
 			sig1.(*Ed25519Signature),
 			sig2.(*Ed25519Signature),
 		},
-		Bitmap: [4]byte([]byte("c0000000")),
+		Bitmap: [4]byte{0xc0, 0x00, 0x00, 0x00},
 	}
 }
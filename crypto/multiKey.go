@@ -17,17 +17,23 @@ type MultiKey struct {
 
 //region MultiKey VerifyingKey implementation
 
+// Verify checks a signature like the chain does.  The signatures are those of the keys in the bitmap, in order of key
+// index, and every one of them must verify.
 func (key *MultiKey) Verify(msg []byte, signature Signature) bool {
 	switch signature.(type) {
 	case *MultiKeySignature:
 		sig := signature.(*MultiKeySignature)
-		verified := uint8(0)
+		verified := 0
 		for i, pub := range key.PubKeys {
-			if pub.Verify(msg, sig.Signatures[i]) {
-				verified++
+			if !sig.Bitmap.ContainsKey(uint8(i)) {
+				continue
 			}
+			if verified >= len(sig.Signatures) || !pub.Verify(msg, sig.Signatures[verified]) {
+				return false
+			}
+			verified++
 		}
-		return key.SignaturesRequired <= verified
+		return verified == len(sig.Signatures) && verified >= int(key.SignaturesRequired)
 	default:
 		return false
 	}
@@ -211,7 +217,7 @@ type MultiKeyBitmap [MultiKeyBitmapSize]byte
 // ContainsKey tells us if the current index is in the map
 func (bm *MultiKeyBitmap) ContainsKey(index uint8) bool {
 	numByte, numBit := KeyIndices(index)
-	return (bm[numByte] & (128 >> numBit)) == 1
+	return (bm[numByte] & (128 >> numBit)) != 0
 }
 
 // AddKey adds the value to the map, returning an error if it is already added
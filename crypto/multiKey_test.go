@@ -93,6 +93,6 @@ func createMultiKeySignature(t *testing.T, key1 *SingleSigner, key2 *SingleSigne
 			sig1.(*AnySignature),
 			sig2.(*AnySignature),
 		},
-		Bitmap: MultiKeyBitmap([]byte("c0000000")),
+		Bitmap: MultiKeyBitmap{0xc0, 0x00, 0x00, 0x00},
 	}
 }
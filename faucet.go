@@ -1,10 +1,12 @@
 package aptos
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"net/url"
 	"strconv"
 )
@@ -12,8 +14,9 @@ import (
 // FaucetClient uses the underlying NodeClient to request for APT for gas on a network.
 // This can only be used in a test network (e.g. Localnet, Devnet, Testnet)
 type FaucetClient struct {
-	nodeClient *NodeClient
-	url        *url.URL
+	nodeClient   *NodeClient
+	url          *url.URL
+	interceptors []Interceptor
 }
 
 // NewFaucetClient creates a new client specifically for requesting faucet funds
@@ -23,13 +26,18 @@ func NewFaucetClient(nodeClient *NodeClient, faucetUrl string) (*FaucetClient, e
 		return nil, fmt.Errorf("failed to parse faucet url '%s': %w", faucetUrl, err)
 	}
 	return &FaucetClient{
-		nodeClient,
-		parsedUrl,
+		nodeClient: nodeClient,
+		url:        parsedUrl,
 	}, nil
 }
 
 // Fund account with the given amount of AptosCoin
 func (faucetClient *FaucetClient) Fund(address AccountAddress, amount uint64) error {
+	return faucetClient.FundCtx(context.Background(), address, amount)
+}
+
+// FundCtx is [FaucetClient.Fund] with a [context.Context] for cancellation and deadlines
+func (faucetClient *FaucetClient) FundCtx(ctx context.Context, address AccountAddress, amount uint64) error {
 	if faucetClient.nodeClient == nil {
 		return errors.New("faucet's node-client not initialized")
 	}
@@ -41,8 +49,14 @@ func (faucetClient *FaucetClient) Fund(address AccountAddress, amount uint64) er
 	params.Set("address", address.String())
 	mintUrl.RawQuery = params.Encode()
 
-	// Make request for funds
-	response, err := faucetClient.nodeClient.Post(mintUrl.String(), "text/plain", nil)
+	// Make request for funds, through the faucet's interceptors rather than the node client's
+	req, err := http.NewRequestWithContext(ctx, "POST", mintUrl.String(), http.NoBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "text/plain")
+	req.Header.Set(ClientHeader, ClientHeaderValue)
+	response, err := runInterceptors(faucetClient.interceptors, req, faucetClient.nodeClient.client.Do)
 	if err != nil {
 		return err
 	}
@@ -64,9 +78,9 @@ func (faucetClient *FaucetClient) Fund(address AccountAddress, amount uint64) er
 	// Wait for fund transactions to go through
 	slog.Debug("FundAccount wait for transactions", "number of transactions", len(txnHashes))
 	if len(txnHashes) == 1 {
-		_, err = faucetClient.nodeClient.WaitForTransaction(txnHashes[0])
+		_, err = faucetClient.nodeClient.WaitForTransactionCtx(ctx, txnHashes[0])
 		return err
 	} else {
-		return faucetClient.nodeClient.PollForTransactions(txnHashes)
+		return faucetClient.nodeClient.PollForTransactionsCtx(ctx, txnHashes)
 	}
 }
@@ -0,0 +1,193 @@
+package aptos
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ErrNodeUnhealthy is returned by [NodeClient.Healthy] when the node reports itself unhealthy, e.g. because its ledger
+// is further behind than the allowed lag
+var ErrNodeUnhealthy = errors.New("node is unhealthy")
+
+// HealthStatus is the result of the most recent health check of a [NodeClient], see [NodeClient.RunHealthMonitor]
+type HealthStatus struct {
+	Healthy       bool
+	Lag           time.Duration // How far the node's ledger timestamp trails the local clock
+	LedgerVersion uint64
+	CheckedAt     time.Time // Zero if the node has not been checked yet
+	Err           error     // Why the node is unhealthy, nil if it is healthy
+}
+
+type healthState struct {
+	mutex  sync.RWMutex
+	status HealthStatus
+}
+
+func (state *healthState) get() HealthStatus {
+	state.mutex.RLock()
+	defer state.mutex.RUnlock()
+	return state.status
+}
+
+func (state *healthState) set(status HealthStatus) {
+	state.mutex.Lock()
+	defer state.mutex.Unlock()
+	state.status = status
+}
+
+// Healthy checks the node's /-/healthy endpoint, returning nil if the node is healthy.  If maxLag is more than zero,
+// the node is also unhealthy if its ledger timestamp is more than maxLag behind its own clock, to the second.
+//
+// An unhealthy node is an error matching [ErrNodeUnhealthy] with [errors.Is], other errors mean the check failed.
+//
+//	err := client.Healthy(30 * time.Second)
+//	if errors.Is(err, ErrNodeUnhealthy) {
+//		// The node is more than 30 seconds behind
+//	}
+func (rc *NodeClient) Healthy(maxLag time.Duration) (err error) {
+	return rc.HealthyCtx(context.Background(), maxLag)
+}
+
+// HealthyCtx is [NodeClient.Healthy] with a [context.Context] for cancellation and deadlines
+func (rc *NodeClient) HealthyCtx(ctx context.Context, maxLag time.Duration) (err error) {
+	au := rc.baseUrl.JoinPath("-/healthy")
+	if maxLag > 0 {
+		params := url.Values{}
+		params.Set("duration_secs", strconv.FormatUint(uint64(math.Ceil(maxLag.Seconds())), 10))
+		au.RawQuery = params.Encode()
+	}
+	// Not retried, since 503 is the answer rather than a transient failure
+	response, _, err := rc.do(ctx, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, "GET", au.String(), nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set(ClientHeader, ClientHeaderValue)
+		return req, nil
+	}, false)
+	if err != nil {
+		return fmt.Errorf("GET %s, %w", au.String(), err)
+	}
+	if response.StatusCode == http.StatusServiceUnavailable {
+		return fmt.Errorf("%w: %w", ErrNodeUnhealthy, NewHttpError(response))
+	}
+	if response.StatusCode >= 400 {
+		return NewHttpError(response)
+	}
+	_, _ = io.Copy(io.Discard, response.Body)
+	_ = response.Body.Close()
+	return nil
+}
+
+// Lag returns how far the node's ledger timestamp, see [NodeInfo.LedgerTimestamp], trails the local clock.  It is zero
+// if the ledger timestamp is ahead of the local clock.
+func (rc *NodeClient) Lag() (lag time.Duration, err error) {
+	return rc.LagCtx(context.Background())
+}
+
+// LagCtx is [NodeClient.Lag] with a [context.Context] for cancellation and deadlines
+func (rc *NodeClient) LagCtx(ctx context.Context) (lag time.Duration, err error) {
+	info, err := rc.InfoCtx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return ledgerLag(info, time.Now()), nil
+}
+
+func ledgerLag(info NodeInfo, now time.Time) time.Duration {
+	return max(now.Sub(time.UnixMicro(int64(info.LedgerTimestamp()))), 0)
+}
+
+// CheckHealth checks the node once and records the result as the client's [HealthStatus].  The node is healthy if its
+// /-/healthy endpoint succeeds, and if maxLag is more than zero, its [NodeClient.Lag] is no more than maxLag.
+func (rc *NodeClient) CheckHealth(ctx context.Context, maxLag time.Duration) HealthStatus {
+	status := HealthStatus{}
+	err := rc.HealthyCtx(ctx, maxLag)
+	if err == nil {
+		var info NodeInfo
+		info, err = rc.InfoCtx(ctx)
+		if err == nil {
+			status.LedgerVersion = info.LedgerVersion()
+			status.Lag = ledgerLag(info, time.Now())
+			if maxLag > 0 && status.Lag > maxLag {
+				err = fmt.Errorf("%w: ledger is %s behind, more than %s", ErrNodeUnhealthy, status.Lag, maxLag)
+			}
+		}
+	}
+	if err != nil && ctx.Err() != nil {
+		// Cancelled checks don't count against the node
+		return rc.health.get()
+	}
+	status.Healthy = err == nil
+	status.Err = err
+	status.CheckedAt = time.Now()
+	rc.health.set(status)
+	return status
+}
+
+// RunHealthMonitor checks the health of the node every period until the context is cancelled, see
+// [NodeClient.CheckHealth].  The latest result is available from [NodeClient.HealthStatus] and [NodeClient.HealthHandler].
+//
+// It returns the context's error once cancelled, or an error right away if the period isn't positive.
+//
+//	go client.RunHealthMonitor(ctx, 10*time.Second, 30*time.Second)
+//	http.Handle("/ready", client.HealthHandler())
+func (rc *NodeClient) RunHealthMonitor(ctx context.Context, period time.Duration, maxLag time.Duration) error {
+	if period <= 0 {
+		return fmt.Errorf("health monitor period must be positive, got %s", period)
+	}
+	for {
+		rc.CheckHealth(ctx, maxLag)
+		if err := sleepCtx(ctx, period); err != nil {
+			return err
+		}
+	}
+}
+
+// HealthStatus returns the result of the most recent health check.  The client is unhealthy until it has been checked.
+func (rc *NodeClient) HealthStatus() HealthStatus {
+	return rc.health.get()
+}
+
+// HealthHandler returns an [http.Handler] for readiness probes, responding with the client's [HealthStatus] as JSON,
+// with status 200 if it is healthy and 503 otherwise
+func (rc *NodeClient) HealthHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status := rc.HealthStatus()
+		body := struct {
+			Healthy       bool       `json:"healthy"`
+			LagSecs       float64    `json:"lag_secs"`
+			LedgerVersion uint64     `json:"ledger_version"`
+			CheckedAt     *time.Time `json:"checked_at,omitempty"`
+			Error         string     `json:"error,omitempty"`
+		}{
+			Healthy:       status.Healthy,
+			LagSecs:       status.Lag.Seconds(),
+			LedgerVersion: status.LedgerVersion,
+		}
+		if !status.CheckedAt.IsZero() {
+			body.CheckedAt = &status.CheckedAt
+		}
+		if status.Err != nil {
+			body.Error = status.Err.Error()
+		} else if !status.Healthy {
+			body.Error = "not checked yet"
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if status.Healthy {
+			w.WriteHeader(http.StatusOK)
+		} else {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		_ = json.NewEncoder(w).Encode(body)
+	})
+}
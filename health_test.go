@@ -0,0 +1,101 @@
+package aptos
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestNodeHealth(t *testing.T) {
+	var ledgerTimestamp atomic.Int64
+	var healthy atomic.Bool
+	var healthRequests atomic.Int32
+	ledgerTimestamp.Store(time.Now().Add(-2 * time.Second).UnixMicro())
+	healthy.Store(true)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/-/healthy":
+			healthRequests.Add(1)
+			assert.Equal(t, "10", r.URL.Query().Get("duration_secs"))
+			if !healthy.Load() {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				_, _ = w.Write([]byte(`{"message":"The latest ledger info timestamp is too old","error_code":"health_check_failed"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"message":"aptos-node:ok"}`))
+		case "/v1":
+			_, _ = fmt.Fprintf(w, `{"chain_id":4,"ledger_version":"100","ledger_timestamp":"%d"}`, ledgerTimestamp.Load())
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewNodeClient(server.URL+"/v1", 4)
+	assert.NoError(t, err)
+
+	assert.NoError(t, client.Healthy(9500*time.Millisecond))
+	lag, err := client.Lag()
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, lag, 2*time.Second)
+	assert.Less(t, lag, 10*time.Second)
+
+	// Unhealthy before the first check
+	recorder := httptest.NewRecorder()
+	client.HealthHandler().ServeHTTP(recorder, httptest.NewRequest("GET", "/ready", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	assert.False(t, client.HealthStatus().Healthy)
+
+	status := client.CheckHealth(context.Background(), 10*time.Second)
+	assert.True(t, status.Healthy)
+	assert.NoError(t, status.Err)
+	assert.Equal(t, uint64(100), status.LedgerVersion)
+	assert.Equal(t, status, client.HealthStatus())
+	recorder = httptest.NewRecorder()
+	client.HealthHandler().ServeHTTP(recorder, httptest.NewRequest("GET", "/ready", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	body := map[string]any{}
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+	assert.Equal(t, true, body["healthy"])
+	assert.Equal(t, float64(100), body["ledger_version"])
+
+	// Lagging by the local clock, even though the node reports itself healthy
+	ledgerTimestamp.Store(time.Now().Add(-time.Minute).UnixMicro())
+	status = client.CheckHealth(context.Background(), 10*time.Second)
+	assert.False(t, status.Healthy)
+	assert.ErrorIs(t, status.Err, ErrNodeUnhealthy)
+	assert.GreaterOrEqual(t, status.Lag, time.Minute)
+
+	// Unhealthy by the node's own check, which isn't retried
+	healthy.Store(false)
+	before := healthRequests.Load()
+	err = client.Healthy(10 * time.Second)
+	assert.ErrorIs(t, err, ErrNodeUnhealthy)
+	assert.Equal(t, before+1, healthRequests.Load())
+
+	// The monitor recovers once the node does
+	healthy.Store(true)
+	ledgerTimestamp.Store(time.Now().UnixMicro())
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		assert.ErrorIs(t, client.RunHealthMonitor(ctx, time.Millisecond, 10*time.Second), context.Canceled)
+		close(done)
+	}()
+	assert.Eventually(t, func() bool { return client.HealthStatus().Healthy }, 5*time.Second, time.Millisecond)
+	cancel()
+	<-done
+	assert.True(t, client.HealthStatus().Healthy)
+
+	// A period that isn't positive is rejected, rather than checking in a tight loop
+	before = healthRequests.Load()
+	assert.Error(t, client.RunHealthMonitor(context.Background(), 0, 10*time.Second))
+	assert.Error(t, client.RunHealthMonitor(context.Background(), -time.Second, 10*time.Second))
+	assert.Equal(t, before, healthRequests.Load())
+}
@@ -9,6 +9,8 @@ import (
 
 const HttpErrSummaryLength = 1000
 
+// HttpError is an HTTP response with an error status.  If the body is a structured error from the node's API, it is
+// decoded into ApiError, which is also available through [errors.As] and [errors.Is].
 type HttpError struct {
 	Status     string // e.g. "200 OK"
 	StatusCode int    // e.g. 200
@@ -16,6 +18,7 @@ type HttpError struct {
 	Method     string
 	RequestUrl url.URL
 	Body       []byte
+	ApiError   *AptosApiError // Decoded from the body, nil if it isn't an API error
 }
 
 func NewHttpError(response *http.Response) *HttpError {
@@ -28,9 +31,18 @@ func NewHttpError(response *http.Response) *HttpError {
 		Body:       body,
 		Method:     response.Request.Method,
 		RequestUrl: *response.Request.URL,
+		ApiError:   parseAptosApiError(body),
 	}
 }
 
+// Unwrap gives access to the decoded API error for [errors.As] and [errors.Is]
+func (he *HttpError) Unwrap() error {
+	if he.ApiError == nil {
+		return nil
+	}
+	return he.ApiError
+}
+
 // implement error interface
 func (he *HttpError) Error() string {
 	if len(he.Body) < HttpErrSummaryLength {
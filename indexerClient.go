@@ -12,20 +12,26 @@ import (
 
 // IndexerClient is a GraphQL client specifically for requesting for data from the Aptos indexer
 type IndexerClient struct {
-	inner *graphql.Client
+	inner        *graphql.Client
+	httpClient   *http.Client
+	interceptors []Interceptor
 }
 
 func NewIndexerClient(httpClient *http.Client, url string) *IndexerClient {
 	// Reuse the HTTP client in the node client
-	client := graphql.NewClient(url, httpClient)
-	return &IndexerClient{
-		client,
-	}
+	ic := &IndexerClient{httpClient: httpClient}
+	ic.inner = graphql.NewClient(url, indexerDoer{ic})
+	return ic
 }
 
 // Query is a generic function for making any GraphQL query against the indexer
 func (ic *IndexerClient) Query(query any, variables map[string]any, options ...graphql.Option) error {
-	return ic.inner.Query(context.Background(), query, variables, options...)
+	return ic.QueryCtx(context.Background(), query, variables, options...)
+}
+
+// QueryCtx is [IndexerClient.Query] with a [context.Context] for cancellation and deadlines
+func (ic *IndexerClient) QueryCtx(ctx context.Context, query any, variables map[string]any, options ...graphql.Option) error {
+	return ic.inner.Query(ctx, query, variables, options...)
 }
 
 type CoinBalance struct {
@@ -35,6 +41,11 @@ type CoinBalance struct {
 
 // GetCoinBalances retrieve the coin balances for all coins owned by the address
 func (ic *IndexerClient) GetCoinBalances(address AccountAddress) ([]CoinBalance, error) {
+	return ic.GetCoinBalancesCtx(context.Background(), address)
+}
+
+// GetCoinBalancesCtx is [IndexerClient.GetCoinBalances] with a [context.Context] for cancellation and deadlines
+func (ic *IndexerClient) GetCoinBalancesCtx(ctx context.Context, address AccountAddress) ([]CoinBalance, error) {
 	var out []CoinBalance
 	var q struct {
 		Current_coin_balances []struct {
@@ -47,7 +58,7 @@ func (ic *IndexerClient) GetCoinBalances(address AccountAddress) ([]CoinBalance,
 	variables := map[string]any{
 		"address": address.StringLong(),
 	}
-	err := ic.Query(&q, variables)
+	err := ic.QueryCtx(ctx, &q, variables)
 
 	if err != nil {
 		return nil, err
@@ -65,6 +76,11 @@ func (ic *IndexerClient) GetCoinBalances(address AccountAddress) ([]CoinBalance,
 
 // GetProcessorStatus tells the most updated version of the transaction processor.  This helps to determine freshness of data.
 func (ic *IndexerClient) GetProcessorStatus(processorName string) (uint64, error) {
+	return ic.GetProcessorStatusCtx(context.Background(), processorName)
+}
+
+// GetProcessorStatusCtx is [IndexerClient.GetProcessorStatus] with a [context.Context] for cancellation and deadlines
+func (ic *IndexerClient) GetProcessorStatusCtx(ctx context.Context, processorName string) (uint64, error) {
 	var q struct {
 		Processor_status []struct {
 			LastSuccessVersion uint64 `graphql:"last_success_version"`
@@ -73,7 +89,7 @@ func (ic *IndexerClient) GetProcessorStatus(processorName string) (uint64, error
 	variables := map[string]any{
 		"processor_name": processorName,
 	}
-	err := ic.Query(&q, variables)
+	err := ic.QueryCtx(ctx, &q, variables)
 	if err != nil {
 		return 0, err
 	}
@@ -83,12 +99,17 @@ func (ic *IndexerClient) GetProcessorStatus(processorName string) (uint64, error
 
 // WaitOnIndexer waits for the indexer processorName specified to catch up to the requestedVersion
 func (ic *IndexerClient) WaitOnIndexer(processorName string, requestedVersion uint64) error {
+	return ic.WaitOnIndexerCtx(context.Background(), processorName, requestedVersion)
+}
+
+// WaitOnIndexerCtx is [IndexerClient.WaitOnIndexer] with a [context.Context], cancelling the context stops waiting
+func (ic *IndexerClient) WaitOnIndexerCtx(ctx context.Context, processorName string, requestedVersion uint64) error {
 	// TODO: add customizable timeout and sleep time
 	const sleepTime = 100 * time.Millisecond
 	const timeout = 5 * time.Second
 	startTime := time.Now()
 	for {
-		version, err := ic.GetProcessorStatus(processorName)
+		version, err := ic.GetProcessorStatusCtx(ctx, processorName)
 		if err != nil {
 			// TODO: This should probably just retry, depending on the error
 			return err
@@ -102,7 +123,10 @@ func (ic *IndexerClient) WaitOnIndexer(processorName string, requestedVersion ui
 		}
 
 		// Sleep and try again later
-		time.Sleep(sleepTime)
+		err = sleepCtx(ctx, sleepTime)
+		if err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -0,0 +1,173 @@
+package aptos
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"runtime"
+	"time"
+)
+
+// RoundTrip sends a request and returns its response, like [http.Client.Do]
+type RoundTrip func(req *http.Request) (*http.Response, error)
+
+// Interceptor wraps every HTTP request a client sends.  It may modify the request's headers before calling next to send
+// it, and inspect or replace the response or error afterwards.  An interceptor can also skip calling next, e.g. to
+// serve a response from a cache.
+//
+// Interceptors run in the order they were added, so the first one added sees the request first and the response last.
+// Requests that are retried go through the interceptors again on every attempt.
+//
+//	client.AddInterceptor(func(req *http.Request, next RoundTrip) (*http.Response, error) {
+//		req.Header.Set("X-Trace-Id", traceId)
+//		return next(req)
+//	})
+type Interceptor func(req *http.Request, next RoundTrip) (*http.Response, error)
+
+// runInterceptors sends the request through the interceptors, the last of which calls send
+func runInterceptors(interceptors []Interceptor, req *http.Request, send RoundTrip) (*http.Response, error) {
+	if len(interceptors) == 0 {
+		return send(req)
+	}
+	return interceptors[0](req, func(req *http.Request) (*http.Response, error) {
+		return runInterceptors(interceptors[1:], req, send)
+	})
+}
+
+// HeaderRequestId is the header set by [RequestIdInterceptor]
+const HeaderRequestId = "X-Request-ID"
+
+// ApiKeyInterceptor authenticates every request with an API key, e.g. for the Aptos Labs API gateway, as an
+// Authorization: Bearer header.  Requests that already have an Authorization header are left alone.
+//
+//	client.AddInterceptor(ApiKeyInterceptor(os.Getenv("APTOS_API_KEY")))
+func ApiKeyInterceptor(apiKey string) Interceptor {
+	return func(req *http.Request, next RoundTrip) (*http.Response, error) {
+		if req.Header.Get("Authorization") == "" {
+			req.Header.Set("Authorization", "Bearer "+apiKey)
+		}
+		return next(req)
+	}
+}
+
+// UserAgentInterceptor sets the User-Agent of every request to the application, if given, followed by the SDK version
+// from [SdkVersion] and the Go version, e.g. "my-app/1.0 aptos-go-sdk/v1.2.0 (go1.22.4; linux/amd64)"
+func UserAgentInterceptor(application string) Interceptor {
+	userAgent := fmt.Sprintf("aptos-go-sdk/%s (%s; %s/%s)", SdkVersion, runtime.Version(), runtime.GOOS, runtime.GOARCH)
+	if application != "" {
+		userAgent = application + " " + userAgent
+	}
+	return func(req *http.Request, next RoundTrip) (*http.Response, error) {
+		req.Header.Set("User-Agent", userAgent)
+		return next(req)
+	}
+}
+
+// RequestIdInterceptor gives every request a random [HeaderRequestId] header, for correlating client logs with the
+// server's.  Requests that already have one, e.g. set by an earlier interceptor, are left alone.
+func RequestIdInterceptor() Interceptor {
+	return func(req *http.Request, next RoundTrip) (*http.Response, error) {
+		if req.Header.Get(HeaderRequestId) == "" {
+			id := make([]byte, 16)
+			_, err := rand.Read(id)
+			if err != nil {
+				return nil, fmt.Errorf("failed to generate request id: %w", err)
+			}
+			req.Header.Set(HeaderRequestId, hex.EncodeToString(id))
+		}
+		return next(req)
+	}
+}
+
+// LoggingInterceptor logs every request to logger, or [slog.Default] if nil, with its method, url, status, duration,
+// and request id if there is one.  Requests are logged at level, and failures, i.e. errors and 5xx responses, at least
+// at [slog.LevelWarn].  Add it after [RequestIdInterceptor] to log the request id.
+//
+//	client.AddInterceptor(RequestIdInterceptor(), LoggingInterceptor(logger, slog.LevelDebug))
+func LoggingInterceptor(logger *slog.Logger, level slog.Level) Interceptor {
+	return func(req *http.Request, next RoundTrip) (*http.Response, error) {
+		log := logger
+		if log == nil {
+			log = slog.Default()
+		}
+		start := time.Now()
+		response, err := next(req)
+		attrs := []slog.Attr{
+			slog.String("method", req.Method),
+			slog.String("url", req.URL.String()),
+			slog.Duration("duration", time.Since(start)),
+		}
+		if id := req.Header.Get(HeaderRequestId); id != "" {
+			attrs = append(attrs, slog.String("request_id", id))
+		}
+		logLevel := level
+		if err != nil {
+			attrs = append(attrs, slog.Any("err", err))
+			logLevel = max(level, slog.LevelWarn)
+		} else {
+			attrs = append(attrs, slog.Int("status", response.StatusCode))
+			if response.StatusCode >= 500 {
+				logLevel = max(level, slog.LevelWarn)
+			}
+		}
+		log.LogAttrs(req.Context(), logLevel, "aptos request", attrs...)
+		return response, err
+	}
+}
+
+// AddInterceptor adds interceptors to the end of the chain that every request from the client goes through, see
+// [Interceptor].  Add interceptors before making requests, it is not safe to call concurrently with requests.
+//
+//	client.AddInterceptor(ApiKeyInterceptor(apiKey), UserAgentInterceptor("my-app/1.0"))
+func (rc *NodeClient) AddInterceptor(interceptors ...Interceptor) {
+	rc.interceptors = append(rc.interceptors, interceptors...)
+}
+
+// send sends the request through the interceptors
+func (rc *NodeClient) send(req *http.Request) (*http.Response, error) {
+	return runInterceptors(rc.interceptors, req, rc.client.Do)
+}
+
+// AddInterceptor adds interceptors to the end of the chain that every request from the client goes through, see
+// [Interceptor].  Add interceptors before making requests, it is not safe to call concurrently with requests.
+func (ic *IndexerClient) AddInterceptor(interceptors ...Interceptor) {
+	ic.interceptors = append(ic.interceptors, interceptors...)
+}
+
+// indexerDoer sends the GraphQL client's requests through the indexer client's interceptors
+type indexerDoer struct {
+	ic *IndexerClient
+}
+
+func (doer indexerDoer) Do(req *http.Request) (*http.Response, error) {
+	return runInterceptors(doer.ic.interceptors, req, doer.ic.httpClient.Do)
+}
+
+// AddInterceptor adds interceptors to the end of the chain that every request from the client goes through, see
+// [Interceptor].  Add interceptors before making requests, it is not safe to call concurrently with requests.
+//
+// Faucet requests don't go through the interceptors of the [NodeClient] used to wait for transactions.
+func (faucetClient *FaucetClient) AddInterceptor(interceptors ...Interceptor) {
+	faucetClient.interceptors = append(faucetClient.interceptors, interceptors...)
+}
+
+// AddInterceptor adds interceptors to the node, indexer, and faucet clients, see [Interceptor].  Add interceptors before
+// making requests, it is not safe to call concurrently with requests.
+//
+//	client.AddInterceptor(
+//		ApiKeyInterceptor(apiKey),
+//		UserAgentInterceptor("my-app/1.0"),
+//		RequestIdInterceptor(),
+//		LoggingInterceptor(nil, slog.LevelDebug),
+//	)
+func (client *Client) AddInterceptor(interceptors ...Interceptor) {
+	client.nodeClient.AddInterceptor(interceptors...)
+	if client.indexerClient != nil {
+		client.indexerClient.AddInterceptor(interceptors...)
+	}
+	if client.faucetClient != nil {
+		client.faucetClient.AddInterceptor(interceptors...)
+	}
+}
@@ -0,0 +1,105 @@
+package aptos
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestInterceptors(t *testing.T) {
+	var mutex sync.Mutex
+	headers := map[string]http.Header{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		headers[r.URL.Path] = r.Header.Clone()
+		mutex.Unlock()
+		switch r.URL.Path {
+		case "/v1":
+			_, _ = w.Write([]byte(`{"chain_id":4,"ledger_version":"100"}`))
+		case "/graphql":
+			_, _ = w.Write([]byte(`{"data":{"processor_status":[{"last_success_version":7}]}}`))
+		case "/mint":
+			_, _ = w.Write([]byte(`[]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewNodeClient(server.URL+"/v1", 4)
+	assert.NoError(t, err)
+	logs := &bytes.Buffer{}
+	logger := slog.New(slog.NewJSONHandler(logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	var order []string
+	client.AddInterceptor(
+		func(req *http.Request, next RoundTrip) (*http.Response, error) {
+			order = append(order, "first")
+			response, err := next(req)
+			order = append(order, "first done")
+			return response, err
+		},
+		ApiKeyInterceptor("node-key"),
+		UserAgentInterceptor("test-app/1.0"),
+		RequestIdInterceptor(),
+		LoggingInterceptor(logger, slog.LevelDebug),
+		func(req *http.Request, next RoundTrip) (*http.Response, error) {
+			order = append(order, "last")
+			return next(req)
+		},
+	)
+
+	_, err = client.Info()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"first", "last", "first done"}, order)
+	header := headers["/v1"]
+	assert.Equal(t, "Bearer node-key", header.Get("Authorization"))
+	assert.True(t, strings.HasPrefix(header.Get("User-Agent"), "test-app/1.0 aptos-go-sdk/"), header.Get("User-Agent"))
+	assert.Len(t, header.Get(HeaderRequestId), 32)
+	assert.Equal(t, ClientHeaderValue, header.Get(ClientHeader))
+
+	logEntry := map[string]any{}
+	assert.NoError(t, json.Unmarshal(logs.Bytes(), &logEntry))
+	assert.Equal(t, "DEBUG", logEntry["level"])
+	assert.Equal(t, "GET", logEntry["method"])
+	assert.Equal(t, float64(200), logEntry["status"])
+	assert.Equal(t, header.Get(HeaderRequestId), logEntry["request_id"])
+
+	// Errors are logged at warn
+	logs.Reset()
+	_, err = client.Account(AccountOne)
+	assert.Error(t, err)
+	_, err = client.Get(server.URL + "/missing")
+	assert.NoError(t, err)
+	assert.Contains(t, logs.String(), `"status":404`)
+	assert.NotContains(t, logs.String(), `"level":"WARN"`)
+
+	// The indexer and faucet have their own interceptors
+	indexer := NewIndexerClient(client.client, server.URL+"/graphql")
+	indexer.AddInterceptor(ApiKeyInterceptor("indexer-key"))
+	version, err := indexer.GetProcessorStatus("default_processor")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(7), version)
+	assert.Equal(t, "Bearer indexer-key", headers["/graphql"].Get("Authorization"))
+
+	faucet, err := NewFaucetClient(client, server.URL)
+	assert.NoError(t, err)
+	faucet.AddInterceptor(RequestIdInterceptor())
+	assert.NoError(t, faucet.Fund(AccountOne, 100))
+	assert.Equal(t, "", headers["/mint"].Get("Authorization"))
+	assert.Len(t, headers["/mint"].Get(HeaderRequestId), 32)
+
+	// An interceptor can answer without sending the request
+	client.SetRetryPolicy(nil)
+	client.AddInterceptor(func(req *http.Request, next RoundTrip) (*http.Response, error) {
+		return nil, assert.AnError
+	})
+	_, err = client.Info()
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.Contains(t, logs.String(), `"level":"WARN"`)
+}
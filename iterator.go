@@ -0,0 +1,68 @@
+package aptos
+
+import "context"
+
+// Iterator walks through paginated results from the node, fetching the next page only once the current page is used up.
+//
+//	it := client.AccountTransactionsIterator(address, 0, 100)
+//	for it.Next() {
+//		txn := it.Value()
+//	}
+//	if err := it.Err(); err != nil {
+//		// Paging stopped early
+//	}
+type Iterator[T any] struct {
+	ctx   context.Context
+	fetch func(ctx context.Context) (page []T, more bool, err error)
+	page  []T
+	index int
+	more  bool
+	value T
+	err   error
+}
+
+// newIterator creates an iterator, fetch returns the next page and whether there may be more pages after it
+func newIterator[T any](ctx context.Context, fetch func(ctx context.Context) (page []T, more bool, err error)) *Iterator[T] {
+	return &Iterator[T]{
+		ctx:   ctx,
+		fetch: fetch,
+		more:  true,
+	}
+}
+
+// Next advances to the next value, fetching a page if needed.  It returns false at the end of the results or on error,
+// check [Iterator.Err] to tell them apart.
+func (it *Iterator[T]) Next() bool {
+	for it.index >= len(it.page) {
+		if !it.more || it.err != nil {
+			return false
+		}
+		it.page, it.more, it.err = it.fetch(it.ctx)
+		it.index = 0
+		if it.err != nil {
+			return false
+		}
+	}
+	it.value = it.page[it.index]
+	it.index++
+	return true
+}
+
+// Value returns the current value, only valid after [Iterator.Next] returns true
+func (it *Iterator[T]) Value() T {
+	return it.value
+}
+
+// Err returns the error that stopped iteration, if any
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// Collect reads all remaining values
+func (it *Iterator[T]) Collect() ([]T, error) {
+	values := make([]T, 0)
+	for it.Next() {
+		values = append(values, it.Value())
+	}
+	return values, it.Err()
+}
@@ -0,0 +1,90 @@
+package aptos
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+)
+
+// Response headers describing the state of the ledger when the node served the request
+const (
+	HeaderAptosChainId             = "X-Aptos-Chain-Id"
+	HeaderAptosLedgerVersion       = "X-Aptos-Ledger-Version"
+	HeaderAptosLedgerOldestVersion = "X-Aptos-Ledger-Oldest-Version"
+	HeaderAptosLedgerTimestampUsec = "X-Aptos-Ledger-TimestampUsec"
+	HeaderAptosEpoch               = "X-Aptos-Epoch"
+	HeaderAptosBlockHeight         = "X-Aptos-Block-Height"
+	HeaderAptosOldestBlockHeight   = "X-Aptos-Oldest-Block-Height"
+	HeaderAptosGasUsed             = "X-Aptos-Gas-Used"
+	HeaderAptosCursor              = "X-Aptos-Cursor" // Cursor for the next page of a paginated endpoint
+)
+
+// LedgerInfo is the state of the ledger when the node served a request, as given in the response headers.  Fields are
+// zero if the header was missing.
+//
+// Pass a LedgerInfo with [WithLedgerInfo] to capture it from any call.
+type LedgerInfo struct {
+	ChainId             uint8
+	LedgerVersion       uint64
+	OldestLedgerVersion uint64
+	LedgerTimestampUsec uint64
+	Epoch               uint64
+	BlockHeight         uint64
+	OldestBlockHeight   uint64
+	GasUsed             *uint64 // Gas used by a view function, nil otherwise
+}
+
+// LedgerInfoFromHeader parses the ledger info headers of a response, e.g. from [NodeClient.Get]
+func LedgerInfoFromHeader(header http.Header) LedgerInfo {
+	parse := func(name string) uint64 {
+		value, _ := strconv.ParseUint(header.Get(name), 10, 64)
+		return value
+	}
+	info := LedgerInfo{
+		LedgerVersion:       parse(HeaderAptosLedgerVersion),
+		OldestLedgerVersion: parse(HeaderAptosLedgerOldestVersion),
+		LedgerTimestampUsec: parse(HeaderAptosLedgerTimestampUsec),
+		Epoch:               parse(HeaderAptosEpoch),
+		BlockHeight:         parse(HeaderAptosBlockHeight),
+		OldestBlockHeight:   parse(HeaderAptosOldestBlockHeight),
+	}
+	if chainId, err := strconv.ParseUint(header.Get(HeaderAptosChainId), 10, 8); err == nil {
+		info.ChainId = uint8(chainId)
+	}
+	if gasUsed, err := strconv.ParseUint(header.Get(HeaderAptosGasUsed), 10, 64); err == nil {
+		info.GasUsed = &gasUsed
+	}
+	return info
+}
+
+type ledgerInfoKey struct{}
+
+// WithLedgerInfo captures the ledger info of responses to calls made with the context into info.  If a call makes more
+// than one request e.g. an iterator, info holds the ledger info of the last response.
+//
+//	info := &LedgerInfo{}
+//	account, err := client.AccountCtx(WithLedgerInfo(ctx, info), address)
+//	if err == nil && info.ChainId != expectedChainId {
+//		// Connected to the wrong network
+//	}
+func WithLedgerInfo(ctx context.Context, info *LedgerInfo) context.Context {
+	return context.WithValue(ctx, ledgerInfoKey{}, info)
+}
+
+// captureLedgerInfo fills in the context's LedgerInfo from a response, if one was set with [WithLedgerInfo]
+func captureLedgerInfo(ctx context.Context, response *http.Response) {
+	info, ok := ctx.Value(ledgerInfoKey{}).(*LedgerInfo)
+	if ok && info != nil && response.Header.Get(HeaderAptosLedgerVersion) != "" {
+		*info = LedgerInfoFromHeader(response.Header)
+	}
+}
+
+// responseLedgerVersion reads the node's ledger version from the response headers
+func responseLedgerVersion(response *http.Response) (uint64, bool) {
+	header := response.Header.Get(HeaderAptosLedgerVersion)
+	if header == "" {
+		return 0, false
+	}
+	ledgerVersion, err := strconv.ParseUint(header, 10, 64)
+	return ledgerVersion, err == nil
+}
@@ -0,0 +1,56 @@
+package aptos
+
+import (
+	"github.com/aptos-labs/aptos-go-sdk/api"
+	"sync"
+)
+
+// moduleCacheKey identifies a module, at a specific ledger version if pinned, otherwise at the latest version
+type moduleCacheKey struct {
+	address       AccountAddress
+	name          string
+	ledgerVersion uint64
+	pinned        bool
+}
+
+func newModuleCacheKey(address AccountAddress, name string, ledgerVersion ...uint64) moduleCacheKey {
+	key := moduleCacheKey{address: address, name: name}
+	if len(ledgerVersion) > 0 {
+		key.ledgerVersion = ledgerVersion[0]
+		key.pinned = true
+	}
+	return key
+}
+
+// moduleCache caches modules and their ABIs by (address, module name), which only change on a package upgrade
+type moduleCache struct {
+	mutex   sync.RWMutex
+	modules map[moduleCacheKey]*api.MoveBytecode
+}
+
+func (cache *moduleCache) get(key moduleCacheKey) (*api.MoveBytecode, bool) {
+	cache.mutex.RLock()
+	defer cache.mutex.RUnlock()
+	module, ok := cache.modules[key]
+	return module, ok
+}
+
+func (cache *moduleCache) put(key moduleCacheKey, module *api.MoveBytecode) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	if cache.modules == nil {
+		cache.modules = make(map[moduleCacheKey]*api.MoveBytecode)
+	}
+	cache.modules[key] = module
+}
+
+func (cache *moduleCache) clear() {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	cache.modules = nil
+}
+
+// ClearModuleCache drops all cached modules, e.g. after publishing a package upgrade
+func (rc *NodeClient) ClearModuleCache() {
+	rc.modules.clear()
+}
@@ -2,18 +2,22 @@ package aptos
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/aptos-labs/aptos-go-sdk/api"
 	"github.com/aptos-labs/aptos-go-sdk/bcs"
+	"github.com/aptos-labs/aptos-go-sdk/crypto"
 	"io"
 	"log/slog"
+	"math"
 	"net/http"
 	"net/http/cookiejar"
 	"net/url"
 	"os"
 	"strconv"
+	"sync/atomic"
 	"time"
 )
 
@@ -26,9 +30,14 @@ const ContentTypeAptosSignedTxnBcs = "application/x.aptos.signed_transaction+bcs
 const ContentTypeAptosViewFunctionBcs = "application/x.aptos.view_function+bcs"
 
 type NodeClient struct {
-	client  *http.Client
-	baseUrl *url.URL
-	chainId uint8
+	client       *http.Client
+	baseUrl      *url.URL
+	chainId      atomic.Uint32 // Cached chain id, 0 until known, read and written by concurrent calls
+	retryPolicy  *RetryPolicy
+	pool         *NodePool
+	modules      moduleCache
+	health       healthState
+	interceptors []Interceptor
 }
 
 func NewNodeClient(rpcUrl string, chainId uint8) (*NodeClient, error) {
@@ -51,15 +60,23 @@ func NewNodeClientWithHttpClient(rpcUrl string, chainId uint8, client *http.Clie
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse RPC url '%s': %w", rpcUrl, err)
 	}
-	return &NodeClient{
-		client:  client,
-		baseUrl: baseUrl,
-		chainId: chainId,
-	}, nil
+	rc := &NodeClient{
+		client:      client,
+		baseUrl:     baseUrl,
+		retryPolicy: DefaultRetryPolicy(),
+	}
+	rc.chainId.Store(uint32(chainId))
+	return rc, nil
 }
 
+// Info retrieves the node info about the network and it's current state
 func (rc *NodeClient) Info() (info NodeInfo, err error) {
-	response, err := rc.Get(rc.baseUrl.String())
+	return rc.InfoCtx(context.Background())
+}
+
+// InfoCtx is [NodeClient.Info] with a [context.Context] for cancellation and deadlines
+func (rc *NodeClient) InfoCtx(ctx context.Context) (info NodeInfo, err error) {
+	response, err := rc.GetCtx(ctx, rc.baseUrl.String())
 	if err != nil {
 		err = fmt.Errorf("GET %s, %w", rc.baseUrl.String(), err)
 		return
@@ -76,19 +93,25 @@ func (rc *NodeClient) Info() (info NodeInfo, err error) {
 	_ = response.Body.Close()
 	err = json.Unmarshal(blob, &info)
 	if err == nil {
-		rc.chainId = info.ChainId
+		rc.chainId.Store(uint32(info.ChainId))
 	}
 	return
 }
 
+// Account retrieves information about the account such as [SequenceNumber] and [crypto.AuthenticationKey]
 func (rc *NodeClient) Account(address AccountAddress, ledgerVersion ...uint64) (info AccountInfo, err error) {
+	return rc.AccountCtx(context.Background(), address, ledgerVersion...)
+}
+
+// AccountCtx is [NodeClient.Account] with a [context.Context] for cancellation and deadlines
+func (rc *NodeClient) AccountCtx(ctx context.Context, address AccountAddress, ledgerVersion ...uint64) (info AccountInfo, err error) {
 	au := rc.baseUrl.JoinPath("accounts", address.String())
 	if len(ledgerVersion) > 0 {
 		params := url.Values{}
 		params.Set("ledger_version", strconv.FormatUint(ledgerVersion[0], 10))
 		au.RawQuery = params.Encode()
 	}
-	response, err := rc.Get(au.String())
+	response, err := rc.GetCtx(ctx, au.String())
 	if err != nil {
 		err = fmt.Errorf("GET %s, %w", au.String(), err)
 		return
@@ -110,7 +133,13 @@ func (rc *NodeClient) Account(address AccountAddress, ledgerVersion ...uint64) (
 	return
 }
 
+// AccountResource retrieves a single resource given its struct name
 func (rc *NodeClient) AccountResource(address AccountAddress, resourceType string, ledgerVersion ...uint64) (data map[string]any, err error) {
+	return rc.AccountResourceCtx(context.Background(), address, resourceType, ledgerVersion...)
+}
+
+// AccountResourceCtx is [NodeClient.AccountResource] with a [context.Context] for cancellation and deadlines
+func (rc *NodeClient) AccountResourceCtx(ctx context.Context, address AccountAddress, resourceType string, ledgerVersion ...uint64) (data map[string]any, err error) {
 	au := rc.baseUrl.JoinPath("accounts", address.String(), "resource", resourceType)
 	// TODO: offer a list of known-good resourceType string constants
 	if len(ledgerVersion) > 0 {
@@ -118,7 +147,7 @@ func (rc *NodeClient) AccountResource(address AccountAddress, resourceType strin
 		params.Set("ledger_version", strconv.FormatUint(ledgerVersion[0], 10))
 		au.RawQuery = params.Encode()
 	}
-	response, err := rc.Get(au.String())
+	response, err := rc.GetCtx(ctx, au.String())
 	if err != nil {
 		err = fmt.Errorf("GET %s, %w", au.String(), err)
 		return
@@ -139,14 +168,111 @@ func (rc *NodeClient) AccountResource(address AccountAddress, resourceType strin
 
 // AccountResources fetches resources for an account into a JSON-like map[string]any in AccountResourceInfo.Data
 // For fetching raw Move structs as BCS, See #AccountResourcesBCS
+//
+// Only the first page of resources is returned, use [NodeClient.AccountResourcesIterator] for accounts with many
+// resources.
 func (rc *NodeClient) AccountResources(address AccountAddress, ledgerVersion ...uint64) (resources []AccountResourceInfo, err error) {
+	return rc.AccountResourcesCtx(context.Background(), address, ledgerVersion...)
+}
+
+// AccountResourcesCtx is [NodeClient.AccountResources] with a [context.Context] for cancellation and deadlines
+func (rc *NodeClient) AccountResourcesCtx(ctx context.Context, address AccountAddress, ledgerVersion ...uint64) (resources []AccountResourceInfo, err error) {
+	resources, _, err = rc.AccountResourcesPageCtx(ctx, address, "", nil, ledgerVersion...)
+	return
+}
+
+// AccountResourcesPage fetches a page of resources for an account, along with the cursor for the next page.
+// Start is a cursor from a previous page. Empty for the first page.
+// Limit is a number of resources to return. Nil for the node's default.
+// The returned cursor is empty after the last page.
+func (rc *NodeClient) AccountResourcesPage(address AccountAddress, start string, limit *uint64, ledgerVersion ...uint64) (resources []AccountResourceInfo, cursor string, err error) {
+	return rc.AccountResourcesPageCtx(context.Background(), address, start, limit, ledgerVersion...)
+}
+
+// AccountResourcesPageCtx is [NodeClient.AccountResourcesPage] with a [context.Context] for cancellation and deadlines
+func (rc *NodeClient) AccountResourcesPageCtx(ctx context.Context, address AccountAddress, start string, limit *uint64, ledgerVersion ...uint64) (resources []AccountResourceInfo, cursor string, err error) {
+	blob, cursor, _, err := rc.getAccountResourcesPage(ctx, address, start, limit, false, ledgerVersion...)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(blob, &resources)
+	return
+}
+
+// AccountResourcesIterator pages through all resources of an account, following cursors until done.
+// PageSize is the number of resources fetched per request, 0 for the node's default.
+//
+// Every page is read at the same ledger version, which is the latest version when the first page is fetched unless
+// given.
+//
+//	it := client.AccountResourcesIterator(address, 0)
+//	for it.Next() {
+//		resource := it.Value()
+//	}
+//	if err := it.Err(); err != nil {
+//		return err
+//	}
+func (rc *NodeClient) AccountResourcesIterator(address AccountAddress, pageSize uint64, ledgerVersion ...uint64) *Iterator[AccountResourceInfo] {
+	return rc.AccountResourcesIteratorCtx(context.Background(), address, pageSize, ledgerVersion...)
+}
+
+// AccountResourcesIteratorCtx is [NodeClient.AccountResourcesIterator] with a [context.Context] for cancellation and
+// deadlines of every page
+func (rc *NodeClient) AccountResourcesIteratorCtx(ctx context.Context, address AccountAddress, pageSize uint64, ledgerVersion ...uint64) *Iterator[AccountResourceInfo] {
+	return newAccountResourcesIterator(ctx, rc, address, pageSize, false, ledgerVersion, func(blob []byte) (page []AccountResourceInfo, err error) {
+		err = json.Unmarshal(blob, &page)
+		return
+	})
+}
+
+// newAccountResourcesIterator follows cursors through the pages of an account's resources, pinning every page to the
+// ledger version of the first
+func newAccountResourcesIterator[T any](ctx context.Context, rc *NodeClient, address AccountAddress, pageSize uint64, asBcs bool, ledgerVersion []uint64, decode func(blob []byte) ([]T, error)) *Iterator[T] {
+	var limit *uint64
+	if pageSize != 0 {
+		limit = &pageSize
+	}
+	cursor := ""
+	return newIterator(ctx, func(ctx context.Context) ([]T, bool, error) {
+		blob, next, version, err := rc.getAccountResourcesPage(ctx, address, cursor, limit, asBcs, ledgerVersion...)
+		if err != nil {
+			return nil, false, err
+		}
+		if len(ledgerVersion) == 0 && version != nil {
+			ledgerVersion = []uint64{*version}
+		}
+		page, err := decode(blob)
+		if err != nil {
+			return nil, false, err
+		}
+		cursor = next
+		return page, cursor != "", nil
+	})
+}
+
+// getAccountResourcesPage fetches a page of resources as JSON or BCS, returning the body, the cursor for the next page,
+// and the ledger version the page was read at if known
+func (rc *NodeClient) getAccountResourcesPage(ctx context.Context, address AccountAddress, start string, limit *uint64, asBcs bool, ledgerVersion ...uint64) (blob []byte, cursor string, version *uint64, err error) {
 	au := rc.baseUrl.JoinPath("accounts", address.String(), "resources")
+	params := url.Values{}
 	if len(ledgerVersion) > 0 {
-		params := url.Values{}
 		params.Set("ledger_version", strconv.FormatUint(ledgerVersion[0], 10))
+	}
+	if start != "" {
+		params.Set("start", start)
+	}
+	if limit != nil {
+		params.Set("limit", strconv.FormatUint(*limit, 10))
+	}
+	if len(params) != 0 {
 		au.RawQuery = params.Encode()
 	}
-	response, err := rc.Get(au.String())
+	var response *http.Response
+	if asBcs {
+		response, err = rc.GetBCSCtx(ctx, au.String())
+	} else {
+		response, err = rc.GetCtx(ctx, au.String())
+	}
 	if err != nil {
 		err = fmt.Errorf("GET %s, %w", au.String(), err)
 		return
@@ -155,57 +281,131 @@ func (rc *NodeClient) AccountResources(address AccountAddress, ledgerVersion ...
 		err = NewHttpError(response)
 		return
 	}
-	blob, err := io.ReadAll(response.Body)
+	blob, err = io.ReadAll(response.Body)
 	if err != nil {
 		err = fmt.Errorf("error getting response data, %w", err)
 		return
 	}
 	_ = response.Body.Close()
-	err = json.Unmarshal(blob, &resources)
+	cursor = response.Header.Get(HeaderAptosCursor)
+	if responseVersion, ok := responseLedgerVersion(response); ok {
+		version = &responseVersion
+	}
 	return
 }
 
-func (rc *NodeClient) Get(getUrl string) (*http.Response, error) {
-	req, err := http.NewRequest("GET", getUrl, nil)
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Set(ClientHeader, ClientHeaderValue)
-	return rc.client.Do(req)
+// AccountModules fetches all modules published by an account, along with their ABIs.
+//
+// The modules are cached, so that later calls to [NodeClient.AccountModule] don't go to the network.
+func (rc *NodeClient) AccountModules(address AccountAddress, ledgerVersion ...uint64) (modules []*api.MoveBytecode, err error) {
+	return rc.AccountModulesCtx(context.Background(), address, ledgerVersion...)
 }
 
-func (rc *NodeClient) GetBCS(getUrl string) (*http.Response, error) {
-	req, err := http.NewRequest("GET", getUrl, nil)
+// AccountModulesCtx is [NodeClient.AccountModules] with a [context.Context] for cancellation and deadlines
+func (rc *NodeClient) AccountModulesCtx(ctx context.Context, address AccountAddress, ledgerVersion ...uint64) (modules []*api.MoveBytecode, err error) {
+	au := rc.baseUrl.JoinPath("accounts", address.String(), "modules")
+	if len(ledgerVersion) > 0 {
+		params := url.Values{}
+		params.Set("ledger_version", strconv.FormatUint(ledgerVersion[0], 10))
+		au.RawQuery = params.Encode()
+	}
+	response, err := rc.GetCtx(ctx, au.String())
 	if err != nil {
-		return nil, err
+		err = fmt.Errorf("GET %s, %w", au.String(), err)
+		return
+	}
+	if response.StatusCode >= 400 {
+		err = NewHttpError(response)
+		return
+	}
+	blob, err := io.ReadAll(response.Body)
+	if err != nil {
+		err = fmt.Errorf("error getting response data, %w", err)
+		return
+	}
+	_ = response.Body.Close()
+	err = json.Unmarshal(blob, &modules)
+	if err != nil {
+		return
+	}
+	for _, module := range modules {
+		if module.Abi != nil {
+			rc.modules.put(newModuleCacheKey(address, module.Abi.Name, ledgerVersion...), module)
+		}
 	}
-	req.Header.Set("Accept", "application/x-bcs")
-	req.Header.Set(ClientHeader, ClientHeaderValue)
-	return rc.client.Do(req)
+	return
 }
 
-func (rc *NodeClient) Post(postUrl string, contentType string, body io.Reader) (resp *http.Response, err error) {
-	if body == nil {
-		body = http.NoBody
+// AccountModule fetches a single module published by an account, along with its ABI.
+//
+// Modules are cached by address and name, as they only change on a package upgrade.  Use
+// [NodeClient.ClearModuleCache] to pick up upgrades.  The returned module is shared with the cache, and must not be
+// modified.
+//
+//	module, err := client.AccountModule(AccountOne, "coin")
+//	for _, function := range module.Abi.ExposedFunctions {
+//		fmt.Println(function.Name, function.Params)
+//	}
+func (rc *NodeClient) AccountModule(address AccountAddress, moduleName string, ledgerVersion ...uint64) (module *api.MoveBytecode, err error) {
+	return rc.AccountModuleCtx(context.Background(), address, moduleName, ledgerVersion...)
+}
+
+// AccountModuleCtx is [NodeClient.AccountModule] with a [context.Context] for cancellation and deadlines
+func (rc *NodeClient) AccountModuleCtx(ctx context.Context, address AccountAddress, moduleName string, ledgerVersion ...uint64) (module *api.MoveBytecode, err error) {
+	key := newModuleCacheKey(address, moduleName, ledgerVersion...)
+	if cached, ok := rc.modules.get(key); ok {
+		return cached, nil
+	}
+
+	au := rc.baseUrl.JoinPath("accounts", address.String(), "module", moduleName)
+	if len(ledgerVersion) > 0 {
+		params := url.Values{}
+		params.Set("ledger_version", strconv.FormatUint(ledgerVersion[0], 10))
+		au.RawQuery = params.Encode()
+	}
+	response, err := rc.GetCtx(ctx, au.String())
+	if err != nil {
+		err = fmt.Errorf("GET %s, %w", au.String(), err)
+		return
+	}
+	if response.StatusCode >= 400 {
+		err = NewHttpError(response)
+		return
+	}
+	blob, err := io.ReadAll(response.Body)
+	if err != nil {
+		err = fmt.Errorf("error getting response data, %w", err)
+		return
 	}
-	req, err := http.NewRequest("POST", postUrl, body)
+	_ = response.Body.Close()
+	module = &api.MoveBytecode{}
+	err = json.Unmarshal(blob, module)
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Set("Content-Type", contentType)
-	req.Header.Set(ClientHeader, ClientHeaderValue)
-	return rc.client.Do(req)
+	rc.modules.put(key, module)
+	return
 }
 
-// AccountResourcesBCS fetches account resources as raw Move struct BCS blobs in AccountResourceRecord.Data []byte
-func (rc *NodeClient) AccountResourcesBCS(address AccountAddress, ledgerVersion ...uint64) (resources []AccountResourceRecord, err error) {
-	au := rc.baseUrl.JoinPath("accounts", address.String(), "resources")
+// AccountModuleBCS fetches the raw bytecode of a single module published by an account, without its ABI
+func (rc *NodeClient) AccountModuleBCS(address AccountAddress, moduleName string, ledgerVersion ...uint64) (bytecode []byte, err error) {
+	return rc.AccountModuleBCSCtx(context.Background(), address, moduleName, ledgerVersion...)
+}
+
+// AccountModuleBCSCtx is [NodeClient.AccountModuleBCS] with a [context.Context] for cancellation and deadlines
+func (rc *NodeClient) AccountModuleBCSCtx(ctx context.Context, address AccountAddress, moduleName string, ledgerVersion ...uint64) (bytecode []byte, err error) {
+	key := newModuleCacheKey(address, moduleName, ledgerVersion...)
+	if cached, ok := rc.modules.get(key); ok {
+		return cached.Bytecode, nil
+	}
+
+	au := rc.baseUrl.JoinPath("accounts", address.String(), "module", moduleName)
 	if len(ledgerVersion) > 0 {
 		params := url.Values{}
 		params.Set("ledger_version", strconv.FormatUint(ledgerVersion[0], 10))
 		au.RawQuery = params.Encode()
 	}
-	response, err := rc.GetBCS(au.String())
+	response, err := rc.GetBCSCtx(ctx, au.String())
 	if err != nil {
 		err = fmt.Errorf("GET %s, %w", au.String(), err)
 		return
@@ -214,16 +414,146 @@ func (rc *NodeClient) AccountResourcesBCS(address AccountAddress, ledgerVersion
 		err = NewHttpError(response)
 		return
 	}
-	blob, err := io.ReadAll(response.Body)
+	bytecode, err = io.ReadAll(response.Body)
 	if err != nil {
 		err = fmt.Errorf("error getting response data, %w", err)
 		return
 	}
 	_ = response.Body.Close()
+	return
+}
+
+// Get sends a GET request with the SDK's client header, transient failures are retried according to the [RetryPolicy]
+func (rc *NodeClient) Get(getUrl string) (*http.Response, error) {
+	return rc.GetCtx(context.Background(), getUrl)
+}
+
+// GetCtx is [NodeClient.Get] with a [context.Context], cancelling the context aborts the in-flight request
+func (rc *NodeClient) GetCtx(ctx context.Context, getUrl string) (*http.Response, error) {
+	response, _, err := rc.do(ctx, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, "GET", getUrl, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set(ClientHeader, ClientHeaderValue)
+		return req, nil
+	}, true)
+	return response, err
+}
+
+// GetBCS sends a GET request asking for a BCS response rather than JSON
+func (rc *NodeClient) GetBCS(getUrl string) (*http.Response, error) {
+	return rc.GetBCSCtx(context.Background(), getUrl)
+}
+
+// GetBCSCtx is [NodeClient.GetBCS] with a [context.Context], cancelling the context aborts the in-flight request
+func (rc *NodeClient) GetBCSCtx(ctx context.Context, getUrl string) (*http.Response, error) {
+	response, _, err := rc.do(ctx, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, "GET", getUrl, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Accept", "application/x-bcs")
+		req.Header.Set(ClientHeader, ClientHeaderValue)
+		return req, nil
+	}, true)
+	return response, err
+}
+
+// Post sends a POST request with the given content type and the SDK's client header
+//
+// POSTs are not retried, as they may not be safe to repeat.
+func (rc *NodeClient) Post(postUrl string, contentType string, body io.Reader) (resp *http.Response, err error) {
+	return rc.PostCtx(context.Background(), postUrl, contentType, body)
+}
+
+// PostCtx is [NodeClient.Post] with a [context.Context], cancelling the context aborts the in-flight request
+func (rc *NodeClient) PostCtx(ctx context.Context, postUrl string, contentType string, body io.Reader) (resp *http.Response, err error) {
+	if body == nil {
+		body = http.NoBody
+	}
+	resp, _, err = rc.do(ctx, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, "POST", postUrl, body)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", contentType)
+		req.Header.Set(ClientHeader, ClientHeaderValue)
+		return req, nil
+	}, false)
+	return resp, err
+}
+
+// postRetryCtx is [NodeClient.PostCtx] for requests that are safe to repeat, such as view functions, retrying
+// transient failures according to the [RetryPolicy].  It returns the number of attempts made.
+func (rc *NodeClient) postRetryCtx(ctx context.Context, postUrl string, contentType string, body []byte) (response *http.Response, attempts int, err error) {
+	return rc.postRetryAcceptCtx(ctx, postUrl, contentType, "", body)
+}
+
+// postRetryAcceptCtx is [NodeClient.postRetryCtx] asking for a specific response content type e.g. BCS
+func (rc *NodeClient) postRetryAcceptCtx(ctx context.Context, postUrl string, contentType string, accept string, body []byte) (response *http.Response, attempts int, err error) {
+	return rc.do(ctx, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, "POST", postUrl, bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", contentType)
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		req.Header.Set(ClientHeader, ClientHeaderValue)
+		return req, nil
+	}, true)
+}
+
+// AccountResourcesBCS fetches account resources as raw Move struct BCS blobs in AccountResourceRecord.Data []byte
+//
+// Only the first page of resources is returned, use [NodeClient.AccountResourcesBCSIterator] for accounts with many
+// resources.
+func (rc *NodeClient) AccountResourcesBCS(address AccountAddress, ledgerVersion ...uint64) (resources []AccountResourceRecord, err error) {
+	return rc.AccountResourcesBCSCtx(context.Background(), address, ledgerVersion...)
+}
+
+// AccountResourcesBCSCtx is [NodeClient.AccountResourcesBCS] with a [context.Context] for cancellation and deadlines
+func (rc *NodeClient) AccountResourcesBCSCtx(ctx context.Context, address AccountAddress, ledgerVersion ...uint64) (resources []AccountResourceRecord, err error) {
+	resources, _, err = rc.AccountResourcesBCSPageCtx(ctx, address, "", nil, ledgerVersion...)
+	return
+}
+
+// AccountResourcesBCSPage fetches a page of resources for an account as raw Move struct BCS blobs, along with the cursor
+// for the next page.  See [NodeClient.AccountResourcesPage] for the paging arguments.
+func (rc *NodeClient) AccountResourcesBCSPage(address AccountAddress, start string, limit *uint64, ledgerVersion ...uint64) (resources []AccountResourceRecord, cursor string, err error) {
+	return rc.AccountResourcesBCSPageCtx(context.Background(), address, start, limit, ledgerVersion...)
+}
+
+// AccountResourcesBCSPageCtx is [NodeClient.AccountResourcesBCSPage] with a [context.Context] for cancellation and
+// deadlines
+func (rc *NodeClient) AccountResourcesBCSPageCtx(ctx context.Context, address AccountAddress, start string, limit *uint64, ledgerVersion ...uint64) (resources []AccountResourceRecord, cursor string, err error) {
+	blob, cursor, _, err := rc.getAccountResourcesPage(ctx, address, start, limit, true, ledgerVersion...)
+	if err != nil {
+		return
+	}
+	resources, err = deserializeAccountResourceRecords(blob)
+	return
+}
+
+// AccountResourcesBCSIterator pages through all resources of an account as raw Move struct BCS blobs, following cursors
+// until done.  See [NodeClient.AccountResourcesIterator] for the paging arguments.
+func (rc *NodeClient) AccountResourcesBCSIterator(address AccountAddress, pageSize uint64, ledgerVersion ...uint64) *Iterator[AccountResourceRecord] {
+	return rc.AccountResourcesBCSIteratorCtx(context.Background(), address, pageSize, ledgerVersion...)
+}
+
+// AccountResourcesBCSIteratorCtx is [NodeClient.AccountResourcesBCSIterator] with a [context.Context] for cancellation
+// and deadlines of every page
+func (rc *NodeClient) AccountResourcesBCSIteratorCtx(ctx context.Context, address AccountAddress, pageSize uint64, ledgerVersion ...uint64) *Iterator[AccountResourceRecord] {
+	return newAccountResourcesIterator(ctx, rc, address, pageSize, true, ledgerVersion, deserializeAccountResourceRecords)
+}
+
+func deserializeAccountResourceRecords(blob []byte) ([]AccountResourceRecord, error) {
 	deserializer := bcs.NewDeserializer(blob)
 	// See resource_test.go TestMoveResourceBCS
-	resources = bcs.DeserializeSequence[AccountResourceRecord](deserializer)
-	return
+	resources := bcs.DeserializeSequence[AccountResourceRecord](deserializer)
+	return resources, deserializer.Error()
 }
 
 // TransactionByHash gets info on a transaction
@@ -231,10 +561,8 @@ func (rc *NodeClient) AccountResourcesBCS(address AccountAddress, ledgerVersion
 //
 //	data, err := c.TransactionByHash("0xabcd")
 //	if err != nil {
-//		if httpErr, ok := err.(aptos.HttpError) {
-//			if httpErr.StatusCode == 404 {
-//				// if we're sure this has been submitted, assume it is still pending elsewhere in the mempool
-//			}
+//		if errors.Is(err, aptos.ErrTransactionNotFound) {
+//			// if we're sure this has been submitted, assume it is still pending elsewhere in the mempool
 //		}
 //	} else {
 //		if data["type"] == "pending_transaction" {
@@ -242,18 +570,46 @@ func (rc *NodeClient) AccountResourcesBCS(address AccountAddress, ledgerVersion
 //		}
 //	}
 func (rc *NodeClient) TransactionByHash(txnHash string) (data *api.Transaction, err error) {
+	return rc.TransactionByHashCtx(context.Background(), txnHash)
+}
+
+// TransactionByHashCtx is [NodeClient.TransactionByHash] with a [context.Context] for cancellation and deadlines
+func (rc *NodeClient) TransactionByHashCtx(ctx context.Context, txnHash string) (data *api.Transaction, err error) {
 	restUrl := rc.baseUrl.JoinPath("transactions/by_hash", txnHash)
-	return rc.getTransactionCommon(restUrl)
+	return rc.getTransactionCommon(ctx, restUrl)
 }
 
+// TransactionByVersion gets info on a committed transaction from its ledger version
 func (rc *NodeClient) TransactionByVersion(version uint64) (data *api.Transaction, err error) {
+	return rc.TransactionByVersionCtx(context.Background(), version)
+}
+
+// TransactionByVersionCtx is [NodeClient.TransactionByVersion] with a [context.Context] for cancellation and deadlines
+func (rc *NodeClient) TransactionByVersionCtx(ctx context.Context, version uint64) (data *api.Transaction, err error) {
+	restUrl := rc.baseUrl.JoinPath("transactions/by_version", strconv.FormatUint(version, 10))
+	return rc.getTransactionCommon(ctx, restUrl)
+}
+
+// TransactionByVersionBCS gets a committed transaction from its ledger version as BCS, decoded with its TransactionInfo,
+// events, and write set.  This is cheaper for the node to serve than [NodeClient.TransactionByVersion].
+func (rc *NodeClient) TransactionByVersionBCS(version uint64) (data *TransactionOnChainData, err error) {
+	return rc.TransactionByVersionBCSCtx(context.Background(), version)
+}
+
+// TransactionByVersionBCSCtx is [NodeClient.TransactionByVersionBCS] with a [context.Context] for cancellation and deadlines
+func (rc *NodeClient) TransactionByVersionBCSCtx(ctx context.Context, version uint64) (data *TransactionOnChainData, err error) {
 	restUrl := rc.baseUrl.JoinPath("transactions/by_version", strconv.FormatUint(version, 10))
-	return rc.getTransactionCommon(restUrl)
+	txnData := &transactionData{}
+	err = rc.getBCSCommon(ctx, restUrl, txnData)
+	if err != nil {
+		return nil, err
+	}
+	return &txnData.TransactionOnChainData, nil
 }
 
-func (rc *NodeClient) getTransactionCommon(restUrl *url.URL) (data *api.Transaction, err error) {
+func (rc *NodeClient) getTransactionCommon(ctx context.Context, restUrl *url.URL) (data *api.Transaction, err error) {
 	// Fetch transaction
-	response, err := rc.Get(restUrl.String())
+	response, err := rc.GetCtx(ctx, restUrl.String())
 	if err != nil {
 		err = fmt.Errorf("GET %s, %w", restUrl.String(), err)
 		return
@@ -276,23 +632,74 @@ func (rc *NodeClient) getTransactionCommon(restUrl *url.URL) (data *api.Transact
 	return
 }
 
+// BlockByVersion fetches the block containing the given ledger version
 func (rc *NodeClient) BlockByVersion(ledgerVersion uint64, withTransactions bool) (data *api.Block, err error) {
+	return rc.BlockByVersionCtx(context.Background(), ledgerVersion, withTransactions)
+}
+
+// BlockByVersionCtx is [NodeClient.BlockByVersion] with a [context.Context] for cancellation and deadlines
+func (rc *NodeClient) BlockByVersionCtx(ctx context.Context, ledgerVersion uint64, withTransactions bool) (data *api.Block, err error) {
 	restUrl := rc.baseUrl.JoinPath("blocks/by_version", strconv.FormatUint(ledgerVersion, 10))
-	return rc.getBlockCommon(restUrl, withTransactions)
+	return rc.getBlockCommon(ctx, restUrl, withTransactions)
 }
 
+// BlockByHeight fetches a block by height
 func (rc *NodeClient) BlockByHeight(blockHeight uint64, withTransactions bool) (data *api.Block, err error) {
+	return rc.BlockByHeightCtx(context.Background(), blockHeight, withTransactions)
+}
+
+// BlockByHeightCtx is [NodeClient.BlockByHeight] with a [context.Context] for cancellation and deadlines
+func (rc *NodeClient) BlockByHeightCtx(ctx context.Context, blockHeight uint64, withTransactions bool) (data *api.Block, err error) {
+	restUrl := rc.baseUrl.JoinPath("blocks/by_height", strconv.FormatUint(blockHeight, 10))
+	return rc.getBlockCommon(ctx, restUrl, withTransactions)
+}
+
+// BlockByHeightBCS fetches a block by height as BCS, with all of its transactions if withTransactions is set
+func (rc *NodeClient) BlockByHeightBCS(blockHeight uint64, withTransactions bool) (data *OnChainBlock, err error) {
+	return rc.BlockByHeightBCSCtx(context.Background(), blockHeight, withTransactions)
+}
+
+// BlockByHeightBCSCtx is [NodeClient.BlockByHeightBCS] with a [context.Context] for cancellation and deadlines
+func (rc *NodeClient) BlockByHeightBCSCtx(ctx context.Context, blockHeight uint64, withTransactions bool) (block *OnChainBlock, err error) {
 	restUrl := rc.baseUrl.JoinPath("blocks/by_height", strconv.FormatUint(blockHeight, 10))
-	return rc.getBlockCommon(restUrl, withTransactions)
+	params := url.Values{}
+	params.Set("with_transactions", strconv.FormatBool(withTransactions))
+	restUrl.RawQuery = params.Encode()
+
+	block = &OnChainBlock{}
+	err = rc.getBCSCommon(ctx, restUrl, block)
+	if err != nil {
+		return nil, err
+	}
+	if !withTransactions {
+		return block, nil
+	}
+
+	// The node limits the transactions in a block response, so fill in the rest
+	numTransactions := block.LastVersion - block.FirstVersion + 1
+	for uint64(len(block.Transactions)) < numTransactions {
+		cursor := block.FirstVersion + uint64(len(block.Transactions))
+		numToPull := numTransactions - uint64(len(block.Transactions))
+		transactions, innerError := rc.TransactionsBCSCtx(ctx, &cursor, &numToPull)
+		if innerError != nil {
+			// We will still return the block, since we did so much work for it
+			return block, innerError
+		}
+		if len(transactions) == 0 {
+			return block, fmt.Errorf("no transactions returned after version %d for block %d", cursor, blockHeight)
+		}
+		block.Transactions = append(block.Transactions, transactions...)
+	}
+	return block, nil
 }
 
-func (rc *NodeClient) getBlockCommon(restUrl *url.URL, withTransactions bool) (block *api.Block, err error) {
+func (rc *NodeClient) getBlockCommon(ctx context.Context, restUrl *url.URL, withTransactions bool) (block *api.Block, err error) {
 	params := url.Values{}
 	params.Set("with_transactions", strconv.FormatBool(withTransactions))
 	restUrl.RawQuery = params.Encode()
 
 	// Fetch block
-	response, err := rc.Get(restUrl.String())
+	response, err := rc.GetCtx(ctx, restUrl.String())
 	if err != nil {
 		err = fmt.Errorf("GET %s, %w", restUrl.String(), err)
 		return
@@ -313,36 +720,69 @@ func (rc *NodeClient) getBlockCommon(restUrl *url.URL, withTransactions bool) (b
 	_ = response.Body.Close() // We don't care about the error about closing the body
 	block = &api.Block{}
 	err = json.Unmarshal(blob, block)
+	if err != nil || !withTransactions {
+		return
+	}
 
 	// Now, let's fill in any missing transactions in the block
 	numTransactions := block.LastVersion - block.FirstVersion + 1
 	retrievedTransactions := uint64(len(block.Transactions))
 
-	// Transaction is always not pending, so it will never be nil
-	cursor := block.Transactions[len(block.Transactions)-1].Version()
-
 	// TODO: I maybe should pull these concurrently, but not for now
 	for retrievedTransactions < numTransactions {
+		// Continue after the last retrieved transaction
+		cursor := block.FirstVersion + retrievedTransactions
 		numToPull := numTransactions - retrievedTransactions
-		transactions, innerError := rc.Transactions(cursor, &numToPull)
+		transactions, innerError := rc.TransactionsCtx(ctx, &cursor, &numToPull)
 		if innerError != nil {
 			// We will still return the block, since we did so much work for it
 			return block, innerError
 		}
+		if len(transactions) == 0 {
+			return block, fmt.Errorf("no transactions returned after version %d for block %d", cursor, block.BlockHeight)
+		}
 
 		// Add transactions to the list
 		block.Transactions = append(block.Transactions, transactions...)
 		retrievedTransactions = uint64(len(block.Transactions))
-		cursor = block.Transactions[len(block.Transactions)-1].Version()
 	}
 	return
 }
 
-// WaitForTransaction does a long-GET for one transaction and wait for it to complete.
-// Initially poll at 10 Hz for up to 1 second if node replies with 404 (wait for txn to propagate).
+// WaitForTransaction waits for one transaction to complete.
 // Accept option arguments PollPeriod and PollTimeout like PollForTransactions.
+//
+// By default, it polls like [NodeClient.PollForTransaction], returning the transaction whether or not it succeeded.
+// With option [WaitModeCommitted], it instead waits on the node's long-poll endpoint until the transaction is committed
+// or expired, rather than for a fixed timeout.  A committed transaction that failed is returned along with a
+// [TransactionFailedError].  Once the ledger timestamp passes the transaction's expiration without committing it, it
+// returns [ErrTransactionExpired]; pass [TransactionExpiration] to detect this even if the node never saw the
+// transaction.  A transaction the node doesn't know is assumed to still be propagating, until PollTimeout if its
+// expiration is unknown, and any other error from the node is returned immediately.
+//
+//	txn, err := client.WaitForTransaction(submitResponse.Hash, WaitModeCommitted,
+//		TransactionExpiration(submitResponse.ExpirationTimestampSecs))
+//	var failed *TransactionFailedError
+//	switch {
+//	case errors.Is(err, ErrTransactionExpired):
+//		// Safe to resubmit with the same sequence number
+//	case errors.As(err, &failed):
+//		// Committed, but aborted with failed.VmStatus
+//	}
 func (rc *NodeClient) WaitForTransaction(txnHash string, options ...any) (data *api.UserTransaction, err error) {
-	return rc.PollForTransaction(txnHash, options...)
+	return rc.WaitForTransactionCtx(context.Background(), txnHash, options...)
+}
+
+// WaitForTransactionCtx is [NodeClient.WaitForTransaction] with a [context.Context], cancelling the context stops waiting
+func (rc *NodeClient) WaitForTransactionCtx(ctx context.Context, txnHash string, options ...any) (data *api.UserTransaction, err error) {
+	period, timeout, mode, expiration, err := getWaitOptions(options...)
+	if err != nil {
+		return nil, err
+	}
+	if mode == WaitModeCommitted {
+		return rc.waitForCommittedTransaction(ctx, txnHash, period, timeout, expiration)
+	}
+	return rc.PollForTransactionCtx(ctx, txnHash, PollPeriod(period), PollTimeout(timeout))
 }
 
 // PollPeriod is an option to PollForTransactions
@@ -351,36 +791,97 @@ type PollPeriod time.Duration
 // PollTimeout is an option to PollForTransactions
 type PollTimeout time.Duration
 
+// PollOption configures polling by [NodeClient.PollForTransactionsWithOptions].  Unlike the untyped options of
+// [NodeClient.PollForTransactions], a wrong option is a compile error.
+//
+//	err := client.PollForTransactionsWithOptions(hashes, WithPollPeriod(500*time.Millisecond), WithPollTimeout(5*time.Second))
+type PollOption func(config *pollConfig) error
+
+// pollConfig is the polling as configured by PollOptions
+type pollConfig struct {
+	period  time.Duration
+	timeout time.Duration
+}
+
+// WithPollPeriod sets how long to wait between polls, 100 milliseconds by default
+func WithPollPeriod(period time.Duration) PollOption {
+	return func(config *pollConfig) error {
+		if period <= 0 {
+			return fmt.Errorf("PollPeriod must be positive, got %s", period)
+		}
+		config.period = period
+		return nil
+	}
+}
+
+// WithPollTimeout sets how long to poll before giving up, 10 seconds by default
+func WithPollTimeout(timeout time.Duration) PollOption {
+	return func(config *pollConfig) error {
+		config.timeout = timeout
+		return nil
+	}
+}
+
+// getTransactionPollOptions applies the untyped poll options over the defaults, a PollOption can be among them
 func getTransactionPollOptions(defaultPeriod, defaultTimeout time.Duration, options ...any) (period time.Duration, timeout time.Duration, err error) {
-	period = defaultPeriod
-	timeout = defaultTimeout
+	config := &pollConfig{period: defaultPeriod, timeout: defaultTimeout}
 	for i, arg := range options {
 		switch value := arg.(type) {
+		case PollOption:
+			err = value(config)
+			if err != nil {
+				return
+			}
 		case PollPeriod:
-			period = time.Duration(value)
+			config.period = time.Duration(value)
 		case PollTimeout:
-			timeout = time.Duration(value)
+			config.timeout = time.Duration(value)
 		default:
 			err = fmt.Errorf("PollForTransactions arg %d bad type %T", i+1, arg)
 			return
 		}
 	}
-	return
+	return config.period, config.timeout, nil
+}
+
+// sleepCtx waits for the period, returning early with the context's error if it is cancelled
+func sleepCtx(ctx context.Context, period time.Duration) error {
+	timer := time.NewTimer(period)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
 }
 
+// PollForTransaction waits up to 10 seconds for a transaction to be done, polling at 10Hz
+// Accepts options PollPeriod and PollTimeout which should wrap time.Duration values, or any [PollOption].
 func (rc *NodeClient) PollForTransaction(hash string, options ...any) (*api.UserTransaction, error) {
+	return rc.PollForTransactionCtx(context.Background(), hash, options...)
+}
+
+// PollForTransactionCtx is [NodeClient.PollForTransaction] with a [context.Context], cancelling the context stops polling
+func (rc *NodeClient) PollForTransactionCtx(ctx context.Context, hash string, options ...any) (*api.UserTransaction, error) {
 	period, timeout, err := getTransactionPollOptions(100*time.Millisecond, 10*time.Second, options...)
 	if err != nil {
 		return nil, err
 	}
 	start := time.Now()
 	deadline := start.Add(timeout)
+	// Retries of an individual request must not outlive the poll timeout
+	requestCtx, cancel := context.WithDeadline(ctx, deadline)
+	defer cancel()
 	for {
 		if time.Now().After(deadline) {
 			return nil, errors.New("timeout waiting for faucet transactions")
 		}
-		time.Sleep(period)
-		txn, err := rc.TransactionByHash(hash)
+		err = sleepCtx(ctx, period)
+		if err != nil {
+			return nil, err
+		}
+		txn, err := rc.TransactionByHashCtx(requestCtx, hash)
 		if err == nil {
 			if txn.Type == api.TransactionVariantPendingTransaction {
 				// not done yet!
@@ -394,29 +895,64 @@ func (rc *NodeClient) PollForTransaction(hash string, options ...any) (*api.User
 }
 
 // PollForTransactions waits up to 10 seconds for transactions to be done, polling at 10Hz
-// Accepts options PollPeriod and PollTimeout which should wrap time.Duration values.
+// Accepts options PollPeriod and PollTimeout which should wrap time.Duration values, or any [PollOption].
 func (rc *NodeClient) PollForTransactions(txnHashes []string, options ...any) error {
-	period, timeout, err := getTransactionPollOptions(100*time.Millisecond, 10*time.Second, options...)
+	return rc.PollForTransactionsCtx(context.Background(), txnHashes, options...)
+}
+
+// PollForTransactionsCtx is [NodeClient.PollForTransactions] with a [context.Context], cancelling the context stops polling
+func (rc *NodeClient) PollForTransactionsCtx(ctx context.Context, txnHashes []string, options ...any) error {
+	period, timeout, err := getTransactionPollOptions(100*time.Millisecond, 10*time.Second, options...)
 	if err != nil {
 		return err
 	}
+	return rc.pollForTransactions(ctx, txnHashes, period, timeout)
+}
+
+// PollForTransactionsWithOptions waits for transactions to be done like [NodeClient.PollForTransactions], with typed
+// options so that mistakes are caught at compile time
+func (rc *NodeClient) PollForTransactionsWithOptions(txnHashes []string, options ...PollOption) error {
+	return rc.PollForTransactionsWithOptionsCtx(context.Background(), txnHashes, options...)
+}
+
+// PollForTransactionsWithOptionsCtx is [NodeClient.PollForTransactionsWithOptions] with a [context.Context], cancelling
+// the context stops polling
+func (rc *NodeClient) PollForTransactionsWithOptionsCtx(ctx context.Context, txnHashes []string, options ...PollOption) error {
+	config := &pollConfig{period: 100 * time.Millisecond, timeout: 10 * time.Second}
+	for _, option := range options {
+		err := option(config)
+		if err != nil {
+			return err
+		}
+	}
+	return rc.pollForTransactions(ctx, txnHashes, config.period, config.timeout)
+}
+
+// pollForTransactions polls every period until the transactions are done, or the timeout
+func (rc *NodeClient) pollForTransactions(ctx context.Context, txnHashes []string, period time.Duration, timeout time.Duration) (err error) {
 	hashSet := make(map[string]bool, len(txnHashes))
 	for _, hash := range txnHashes {
 		hashSet[hash] = true
 	}
 	start := time.Now()
 	deadline := start.Add(timeout)
+	// Retries of an individual request must not outlive the poll timeout
+	requestCtx, cancel := context.WithDeadline(ctx, deadline)
+	defer cancel()
 	for len(hashSet) > 0 {
 		if time.Now().After(deadline) {
 			return errors.New("timeout waiting for faucet transactions")
 		}
-		time.Sleep(period)
+		err = sleepCtx(ctx, period)
+		if err != nil {
+			return err
+		}
 		for _, hash := range txnHashes {
 			if !hashSet[hash] {
 				// already done
 				continue
 			}
-			txn, err := rc.TransactionByHash(hash)
+			txn, err := rc.TransactionByHashCtx(requestCtx, hash)
 			if err == nil {
 				if txn.Type == api.TransactionVariantPendingTransaction {
 					// not done yet!
@@ -435,6 +971,11 @@ func (rc *NodeClient) PollForTransactions(txnHashes []string, options ...any) er
 // Start is a version number. Nil for most recent transactions.
 // Limit is a number of transactions to return. 'about a hundred' by default.
 func (rc *NodeClient) Transactions(start *uint64, limit *uint64) (data []*api.Transaction, err error) {
+	return rc.TransactionsCtx(context.Background(), start, limit)
+}
+
+// TransactionsCtx is [NodeClient.Transactions] with a [context.Context] for cancellation and deadlines
+func (rc *NodeClient) TransactionsCtx(ctx context.Context, start *uint64, limit *uint64) (data []*api.Transaction, err error) {
 	au := rc.baseUrl.JoinPath("transactions")
 	params := url.Values{}
 	if start != nil {
@@ -446,7 +987,98 @@ func (rc *NodeClient) Transactions(start *uint64, limit *uint64) (data []*api.Tr
 	if len(params) != 0 {
 		au.RawQuery = params.Encode()
 	}
-	response, err := rc.Get(au.String())
+	response, err := rc.GetCtx(ctx, au.String())
+	if err != nil {
+		err = fmt.Errorf("GET %s, %w", au.String(), err)
+		return nil, err
+	}
+	if response.StatusCode >= 400 {
+		err = NewHttpError(response)
+		return nil, err
+	}
+	blob, err := io.ReadAll(response.Body)
+	if err != nil {
+		err = fmt.Errorf("error getting response data, %w", err)
+		return nil, err
+	}
+	_ = response.Body.Close()
+	err = json.Unmarshal(blob, &data)
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+// TransactionsBCS gets recent transactions as BCS, decoded with their TransactionInfo, events, and write sets.
+// Start is a version number. Nil for most recent transactions.
+// Limit is a number of transactions to return. 'about a hundred' by default.
+func (rc *NodeClient) TransactionsBCS(start *uint64, limit *uint64) (data []*TransactionOnChainData, err error) {
+	return rc.TransactionsBCSCtx(context.Background(), start, limit)
+}
+
+// TransactionsBCSCtx is [NodeClient.TransactionsBCS] with a [context.Context] for cancellation and deadlines
+func (rc *NodeClient) TransactionsBCSCtx(ctx context.Context, start *uint64, limit *uint64) (data []*TransactionOnChainData, err error) {
+	au := rc.baseUrl.JoinPath("transactions")
+	params := url.Values{}
+	if start != nil {
+		params.Set("start", strconv.FormatUint(*start, 10))
+	}
+	if limit != nil {
+		params.Set("limit", strconv.FormatUint(*limit, 10))
+	}
+	if len(params) != 0 {
+		au.RawQuery = params.Encode()
+	}
+	list := &transactionOnChainDataList{}
+	err = rc.getBCSCommon(ctx, au, list)
+	if err != nil {
+		return nil, err
+	}
+	return list.Transactions, nil
+}
+
+// getBCSCommon fetches a BCS response and deserializes it into dest
+func (rc *NodeClient) getBCSCommon(ctx context.Context, au *url.URL, dest bcs.Unmarshaler) error {
+	response, err := rc.GetBCSCtx(ctx, au.String())
+	if err != nil {
+		return fmt.Errorf("GET %s, %w", au.String(), err)
+	}
+	if response.StatusCode >= 400 {
+		return NewHttpError(response)
+	}
+	blob, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("error getting response data, %w", err)
+	}
+	_ = response.Body.Close()
+	err = bcs.Deserialize(dest, blob)
+	if err != nil {
+		return fmt.Errorf("error deserializing BCS response from %s, %w", au.String(), err)
+	}
+	return nil
+}
+
+// AccountTransactions gets the committed transactions sent by an account, in order of sequence number.
+// Start is a sequence number. Nil for the most recent transactions.
+// Limit is a number of transactions to return. 'about a hundred' by default.
+func (rc *NodeClient) AccountTransactions(address AccountAddress, start *uint64, limit *uint64) (data []*api.Transaction, err error) {
+	return rc.AccountTransactionsCtx(context.Background(), address, start, limit)
+}
+
+// AccountTransactionsCtx is [NodeClient.AccountTransactions] with a [context.Context] for cancellation and deadlines
+func (rc *NodeClient) AccountTransactionsCtx(ctx context.Context, address AccountAddress, start *uint64, limit *uint64) (data []*api.Transaction, err error) {
+	au := rc.baseUrl.JoinPath("accounts", address.String(), "transactions")
+	params := url.Values{}
+	if start != nil {
+		params.Set("start", strconv.FormatUint(*start, 10))
+	}
+	if limit != nil {
+		params.Set("limit", strconv.FormatUint(*limit, 10))
+	}
+	if len(params) != 0 {
+		au.RawQuery = params.Encode()
+	}
+	response, err := rc.GetCtx(ctx, au.String())
 	if err != nil {
 		err = fmt.Errorf("GET %s, %w", au.String(), err)
 		return nil, err
@@ -468,6 +1100,277 @@ func (rc *NodeClient) Transactions(start *uint64, limit *uint64) (data []*api.Tr
 	return
 }
 
+// AccountTransactionsIterator pages through the transactions sent by an account, starting at the given sequence number.
+// PageSize is the number of transactions fetched per request, 0 for the node's default.
+//
+//	it := client.AccountTransactionsIterator(address, 0, 100)
+//	for it.Next() {
+//		txn := it.Value()
+//	}
+//	if err := it.Err(); err != nil {
+//		return err
+//	}
+func (rc *NodeClient) AccountTransactionsIterator(address AccountAddress, start uint64, pageSize uint64) *Iterator[*api.Transaction] {
+	return rc.AccountTransactionsIteratorCtx(context.Background(), address, start, pageSize)
+}
+
+// AccountTransactionsIteratorCtx is [NodeClient.AccountTransactionsIterator] with a [context.Context] for cancellation
+// and deadlines of every page
+func (rc *NodeClient) AccountTransactionsIteratorCtx(ctx context.Context, address AccountAddress, start uint64, pageSize uint64) *Iterator[*api.Transaction] {
+	next := start
+	var limit *uint64
+	if pageSize != 0 {
+		limit = &pageSize
+	}
+	return newIterator(ctx, func(ctx context.Context) ([]*api.Transaction, bool, error) {
+		page, err := rc.AccountTransactionsCtx(ctx, address, &next, limit)
+		if err != nil {
+			return nil, false, err
+		}
+		if len(page) == 0 {
+			return nil, false, nil
+		}
+		// Continue after the last sequence number, the node may return fewer than asked for
+		last, err := page[len(page)-1].UserTransaction()
+		if err != nil {
+			return nil, false, fmt.Errorf("unexpected account transaction: %w", err)
+		}
+		next = last.SequenceNumber + 1
+		return page, true, nil
+	})
+}
+
+// EventsByCreationNumber gets the V1 events of the event handle created with the given creation number on an account,
+// in order of sequence number.
+// Start is a sequence number. Nil for the most recent events.
+// Limit is a number of events to return. 'about a hundred' by default.
+func (rc *NodeClient) EventsByCreationNumber(address AccountAddress, creationNumber uint64, start *uint64, limit *uint64) (data []*api.VersionedEvent, err error) {
+	return rc.EventsByCreationNumberCtx(context.Background(), address, creationNumber, start, limit)
+}
+
+// EventsByCreationNumberCtx is [NodeClient.EventsByCreationNumber] with a [context.Context] for cancellation and deadlines
+func (rc *NodeClient) EventsByCreationNumberCtx(ctx context.Context, address AccountAddress, creationNumber uint64, start *uint64, limit *uint64) (data []*api.VersionedEvent, err error) {
+	au := rc.baseUrl.JoinPath("accounts", address.String(), "events", strconv.FormatUint(creationNumber, 10))
+	return rc.getEvents(ctx, au, start, limit)
+}
+
+// EventsByHandle gets the V1 events of an event handle field of a resource on an account, in order of sequence number.
+// Start is a sequence number. Nil for the most recent events.
+// Limit is a number of events to return. 'about a hundred' by default.
+//
+//	client.EventsByHandle(address, "0x1::coin::CoinStore<0x1::aptos_coin::AptosCoin>", "deposit_events", nil, nil)
+func (rc *NodeClient) EventsByHandle(address AccountAddress, eventHandle string, fieldName string, start *uint64, limit *uint64) (data []*api.VersionedEvent, err error) {
+	return rc.EventsByHandleCtx(context.Background(), address, eventHandle, fieldName, start, limit)
+}
+
+// EventsByHandleCtx is [NodeClient.EventsByHandle] with a [context.Context] for cancellation and deadlines
+func (rc *NodeClient) EventsByHandleCtx(ctx context.Context, address AccountAddress, eventHandle string, fieldName string, start *uint64, limit *uint64) (data []*api.VersionedEvent, err error) {
+	au := rc.baseUrl.JoinPath("accounts", address.String(), "events", eventHandle, fieldName)
+	return rc.getEvents(ctx, au, start, limit)
+}
+
+func (rc *NodeClient) getEvents(ctx context.Context, au *url.URL, start *uint64, limit *uint64) (data []*api.VersionedEvent, err error) {
+	params := url.Values{}
+	if start != nil {
+		params.Set("start", strconv.FormatUint(*start, 10))
+	}
+	if limit != nil {
+		params.Set("limit", strconv.FormatUint(*limit, 10))
+	}
+	if len(params) != 0 {
+		au.RawQuery = params.Encode()
+	}
+	response, err := rc.GetCtx(ctx, au.String())
+	if err != nil {
+		err = fmt.Errorf("GET %s, %w", au.String(), err)
+		return nil, err
+	}
+	if response.StatusCode >= 400 {
+		err = NewHttpError(response)
+		return nil, err
+	}
+	blob, err := io.ReadAll(response.Body)
+	if err != nil {
+		err = fmt.Errorf("error getting response data, %w", err)
+		return nil, err
+	}
+	_ = response.Body.Close()
+	err = json.Unmarshal(blob, &data)
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+// EventsByCreationNumberIterator pages through the events of an event handle by creation number, starting at the given
+// sequence number.  PageSize is the number of events fetched per request, 0 for the node's default.
+func (rc *NodeClient) EventsByCreationNumberIterator(address AccountAddress, creationNumber uint64, start uint64, pageSize uint64) *Iterator[*api.VersionedEvent] {
+	return rc.EventsByCreationNumberIteratorCtx(context.Background(), address, creationNumber, start, pageSize)
+}
+
+// EventsByCreationNumberIteratorCtx is [NodeClient.EventsByCreationNumberIterator] with a [context.Context] for
+// cancellation and deadlines of every page
+func (rc *NodeClient) EventsByCreationNumberIteratorCtx(ctx context.Context, address AccountAddress, creationNumber uint64, start uint64, pageSize uint64) *Iterator[*api.VersionedEvent] {
+	return rc.eventsIterator(ctx, start, pageSize, func(ctx context.Context, start *uint64, limit *uint64) ([]*api.VersionedEvent, error) {
+		return rc.EventsByCreationNumberCtx(ctx, address, creationNumber, start, limit)
+	})
+}
+
+// EventsByHandleIterator pages through the events of an event handle field, starting at the given sequence number.
+// PageSize is the number of events fetched per request, 0 for the node's default.
+//
+//	it := client.EventsByHandleIterator(address, "0x1::coin::CoinStore<0x1::aptos_coin::AptosCoin>", "deposit_events", 0, 100)
+//	for it.Next() {
+//		event := it.Value()
+//	}
+func (rc *NodeClient) EventsByHandleIterator(address AccountAddress, eventHandle string, fieldName string, start uint64, pageSize uint64) *Iterator[*api.VersionedEvent] {
+	return rc.EventsByHandleIteratorCtx(context.Background(), address, eventHandle, fieldName, start, pageSize)
+}
+
+// EventsByHandleIteratorCtx is [NodeClient.EventsByHandleIterator] with a [context.Context] for cancellation and
+// deadlines of every page
+func (rc *NodeClient) EventsByHandleIteratorCtx(ctx context.Context, address AccountAddress, eventHandle string, fieldName string, start uint64, pageSize uint64) *Iterator[*api.VersionedEvent] {
+	return rc.eventsIterator(ctx, start, pageSize, func(ctx context.Context, start *uint64, limit *uint64) ([]*api.VersionedEvent, error) {
+		return rc.EventsByHandleCtx(ctx, address, eventHandle, fieldName, start, limit)
+	})
+}
+
+func (rc *NodeClient) eventsIterator(ctx context.Context, start uint64, pageSize uint64, getEvents func(ctx context.Context, start *uint64, limit *uint64) ([]*api.VersionedEvent, error)) *Iterator[*api.VersionedEvent] {
+	next := start
+	var limit *uint64
+	if pageSize != 0 {
+		limit = &pageSize
+	}
+	return newIterator(ctx, func(ctx context.Context) ([]*api.VersionedEvent, bool, error) {
+		page, err := getEvents(ctx, &next, limit)
+		if err != nil {
+			return nil, false, err
+		}
+		if len(page) == 0 {
+			return nil, false, nil
+		}
+		// Continue after the last sequence number, the node may return fewer than asked for
+		next = page[len(page)-1].SequenceNumber + 1
+		return page, true, nil
+	})
+}
+
+// FollowEventsByHandle tails an event handle field, calling handler with each event in order of sequence number starting
+// at the given sequence number.  Once caught up, it polls for new events.
+//
+// It runs until the context is cancelled, or the handler or a request returns an error, which is returned.
+// Accepts option PollPeriod, which defaults to 1 second.
+//
+//	err := client.FollowEventsByHandle(ctx, address, "0x1::coin::CoinStore<0x1::aptos_coin::AptosCoin>", "deposit_events", 0,
+//		func(event *api.VersionedEvent) error {
+//			fmt.Printf("deposit of %v at version %d\n", event.Data["amount"], event.Version)
+//			return nil
+//		})
+func (rc *NodeClient) FollowEventsByHandle(ctx context.Context, address AccountAddress, eventHandle string, fieldName string, start uint64, handler func(event *api.VersionedEvent) error, options ...any) error {
+	period := time.Second
+	for i, arg := range options {
+		switch value := arg.(type) {
+		case PollPeriod:
+			period = time.Duration(value)
+		default:
+			return fmt.Errorf("FollowEventsByHandle arg %d bad type %T", i+1, arg)
+		}
+	}
+	next := start
+	for {
+		events, err := rc.EventsByHandleCtx(ctx, address, eventHandle, fieldName, &next, nil)
+		if err != nil {
+			return err
+		}
+		for _, event := range events {
+			err = handler(event)
+			if err != nil {
+				return err
+			}
+			next = event.SequenceNumber + 1
+		}
+		if len(events) == 0 {
+			err = sleepCtx(ctx, period)
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// TableItem reads an item from a Move table by its handle, returning the value decoded as JSON.
+//
+// The key is encoded as JSON the same way as view function arguments e.g. u64 and larger as strings, and addresses as
+// hex strings.
+//
+//	keyType := TypeTag{Value: &AddressTag{}}
+//	valueType := TypeTag{Value: &U64Tag{}}
+//	value, err := client.TableItem(handle, keyType, valueType, "0x1")
+func (rc *NodeClient) TableItem(handle string, keyType TypeTag, valueType TypeTag, key any, ledgerVersion ...uint64) (data any, err error) {
+	return rc.TableItemCtx(context.Background(), handle, keyType, valueType, key, ledgerVersion...)
+}
+
+// TableItemCtx is [NodeClient.TableItem] with a [context.Context] for cancellation and deadlines
+func (rc *NodeClient) TableItemCtx(ctx context.Context, handle string, keyType TypeTag, valueType TypeTag, key any, ledgerVersion ...uint64) (data any, err error) {
+	request := map[string]any{
+		"key_type":   keyType.String(),
+		"value_type": valueType.String(),
+		"key":        key,
+	}
+	blob, err := rc.postTableItem(ctx, handle, "item", request, "", ledgerVersion...)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(blob, &data)
+	return
+}
+
+// TableItemBCS reads an item from a Move table by its handle and the BCS encoded key, returning the BCS encoded value
+//
+//	key, _ := bcs.SerializeU64(1)
+//	valueBytes, err := client.TableItemBCS(handle, key)
+//	value := bcs.NewDeserializer(valueBytes).U64()
+func (rc *NodeClient) TableItemBCS(handle string, key []byte, ledgerVersion ...uint64) (data []byte, err error) {
+	return rc.TableItemBCSCtx(context.Background(), handle, key, ledgerVersion...)
+}
+
+// TableItemBCSCtx is [NodeClient.TableItemBCS] with a [context.Context] for cancellation and deadlines
+func (rc *NodeClient) TableItemBCSCtx(ctx context.Context, handle string, key []byte, ledgerVersion ...uint64) (data []byte, err error) {
+	request := map[string]any{
+		"key": BytesToHex(key),
+	}
+	return rc.postTableItem(ctx, handle, "raw_item", request, "application/x-bcs", ledgerVersion...)
+}
+
+func (rc *NodeClient) postTableItem(ctx context.Context, handle string, endpoint string, request map[string]any, accept string, ledgerVersion ...uint64) (data []byte, err error) {
+	rblob, err := json.Marshal(request)
+	if err != nil {
+		return
+	}
+	au := rc.baseUrl.JoinPath("tables", handle, endpoint)
+	if len(ledgerVersion) > 0 {
+		params := url.Values{}
+		params.Set("ledger_version", strconv.FormatUint(ledgerVersion[0], 10))
+		au.RawQuery = params.Encode()
+	}
+	response, _, err := rc.postRetryAcceptCtx(ctx, au.String(), "application/json", accept, rblob)
+	if err != nil {
+		err = fmt.Errorf("POST %s, %w", au.String(), err)
+		return
+	}
+	if response.StatusCode >= 400 {
+		err = NewHttpError(response)
+		return nil, err
+	}
+	data, err = io.ReadAll(response.Body)
+	if err != nil {
+		err = fmt.Errorf("error getting response data, %w", err)
+		return
+	}
+	_ = response.Body.Close()
+	return
+}
+
 // testing only
 // There exists an aptos-node API for submitting JSON and having the node Rust code encode it to BCS, we should only use this for testing to validate our local BCS. Actual GO-SDK usage should use BCS encoding locally in Go code.
 func (rc *NodeClient) transactionEncode(request map[string]any) (data []byte, err error) {
@@ -496,20 +1399,35 @@ func (rc *NodeClient) transactionEncode(request map[string]any) (data []byte, er
 	return
 }
 
+// SubmitTransaction submits an already signed transaction to the blockchain
+//
+// Transient failures are retried according to the [RetryPolicy].  If a retried submission is rejected, but the
+// transaction already reached the node from an earlier attempt, e.g. it is already in mempool, the submission is
+// treated as successful.
 func (rc *NodeClient) SubmitTransaction(signedTxn *SignedTransaction) (data *api.SubmitTransactionResponse, err error) {
+	return rc.SubmitTransactionCtx(context.Background(), signedTxn)
+}
+
+// SubmitTransactionCtx is [NodeClient.SubmitTransaction] with a [context.Context] for cancellation and deadlines
+func (rc *NodeClient) SubmitTransactionCtx(ctx context.Context, signedTxn *SignedTransaction) (data *api.SubmitTransactionResponse, err error) {
 	sblob, err := bcs.Serialize(signedTxn)
 	if err != nil {
 		return
 	}
-	bodyReader := bytes.NewReader(sblob)
 	au := rc.baseUrl.JoinPath("transactions")
-	response, err := rc.Post(au.String(), ContentTypeAptosSignedTxnBcs, bodyReader)
+	response, attempts, err := rc.postRetryCtx(ctx, au.String(), ContentTypeAptosSignedTxnBcs, sblob)
 	if err != nil {
 		err = fmt.Errorf("POST %s, %w", au.String(), err)
 		return
 	}
 	if response.StatusCode >= 400 {
 		err = NewHttpError(response)
+		if attempts > 1 && response.StatusCode < 500 {
+			// An earlier attempt may have reached the node before failing, look for it
+			if existing, lookupErr := rc.submittedTransaction(ctx, signedTxn); lookupErr == nil {
+				return existing, nil
+			}
+		}
 		return nil, err
 	}
 	blob, err := io.ReadAll(response.Body)
@@ -523,16 +1441,242 @@ func (rc *NodeClient) SubmitTransaction(signedTxn *SignedTransaction) (data *api
 	return
 }
 
-func (rc *NodeClient) GetChainId() (chainId uint8, err error) {
-	if rc.chainId == 0 {
-		info, err := rc.Info()
+// MaxSubmitBatchSize is the number of transactions sent per request by [NodeClient.SubmitTransactions], matching the
+// node's default limit
+const MaxSubmitBatchSize = 100
+
+// BatchSubmissionFailure is a transaction rejected from a batch submission, see [NodeClient.SubmitTransactions]
+type BatchSubmissionFailure struct {
+	Index       int     // Index of the transaction in the submitted slice
+	Message     string  // Why the node rejected the transaction, empty if it wasn't submitted
+	ErrorCode   string  // Machine-readable error code e.g. "sequence_number_too_old", empty if it wasn't submitted
+	VmErrorCode *uint64 // The Move VM status code, if the rejection came from the VM
+
+	// BatchErr is set when the transaction wasn't submitted, because its batch failed as a whole or an earlier batch
+	// did.  The transaction can be submitted again.
+	BatchErr error
+}
+
+// BatchSubmissionResult is the outcome of a batch submission, every transaction is either accepted or failed
+type BatchSubmissionResult struct {
+	Accepted []int                    // Indices of the accepted transactions
+	Failures []BatchSubmissionFailure // Rejected transactions, in order of index
+}
+
+// batchSubmissionResponse is the node's response to POST /transactions/batch
+type batchSubmissionResponse struct {
+	TransactionFailures []struct {
+		Error struct {
+			Message     string  `json:"message"`
+			ErrorCode   string  `json:"error_code"`
+			VmErrorCode *uint64 `json:"vm_error_code"`
+		} `json:"error"`
+		TransactionIndex int `json:"transaction_index"`
+	} `json:"transaction_failures"`
+}
+
+// SubmitTransactions submits many already signed transactions, in batches of [MaxSubmitBatchSize].  The result lists
+// which transactions were accepted, and why each of the others was rejected.
+//
+// An error is only returned if a batch as a whole failed.  The result is still returned with it, listing the
+// transactions of earlier batches that were accepted, and the failed batch's and later transactions as failures with
+// [BatchSubmissionFailure.BatchErr] set, as they weren't submitted.
+//
+//	result, err := client.SubmitTransactions(signedTxns)
+//	for _, failure := range result.Failures {
+//		if failure.BatchErr != nil {
+//			fmt.Printf("transaction %d not submitted: %s\n", failure.Index, failure.BatchErr)
+//		} else {
+//			fmt.Printf("transaction %d rejected: %s\n", failure.Index, failure.Message)
+//		}
+//	}
+func (rc *NodeClient) SubmitTransactions(signedTxns []*SignedTransaction) (result *BatchSubmissionResult, err error) {
+	return rc.SubmitTransactionsCtx(context.Background(), signedTxns)
+}
+
+// SubmitTransactionsCtx is [NodeClient.SubmitTransactions] with a [context.Context] for cancellation and deadlines
+func (rc *NodeClient) SubmitTransactionsCtx(ctx context.Context, signedTxns []*SignedTransaction) (result *BatchSubmissionResult, err error) {
+	result = &BatchSubmissionResult{
+		Accepted: make([]int, 0, len(signedTxns)),
+		Failures: make([]BatchSubmissionFailure, 0),
+	}
+	for start := 0; start < len(signedTxns); start += MaxSubmitBatchSize {
+		end := min(start+MaxSubmitBatchSize, len(signedTxns))
+		err = rc.submitTransactionBatch(ctx, signedTxns[start:end], start, result)
+		if err != nil {
+			// This batch and the ones after it weren't submitted
+			for i := start; i < len(signedTxns); i++ {
+				result.Failures = append(result.Failures, BatchSubmissionFailure{Index: i, BatchErr: err})
+			}
+			return result, err
+		}
+	}
+	return
+}
+
+// submitTransactionBatch submits a single batch, offset is the index of the batch's first transaction in the result
+func (rc *NodeClient) submitTransactionBatch(ctx context.Context, signedTxns []*SignedTransaction, offset int, result *BatchSubmissionResult) (err error) {
+	ser := bcs.Serializer{}
+	bcs.SerializeSequence(signedTxns, &ser)
+	if ser.Error() != nil {
+		return ser.Error()
+	}
+	au := rc.baseUrl.JoinPath("transactions/batch")
+	response, attempts, err := rc.postRetryCtx(ctx, au.String(), ContentTypeAptosSignedTxnBcs, ser.ToBytes())
+	if err != nil {
+		return fmt.Errorf("POST %s, %w", au.String(), err)
+	}
+	if response.StatusCode >= 400 {
+		return NewHttpError(response)
+	}
+	blob, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("error getting response data, %w", err)
+	}
+	_ = response.Body.Close()
+	data := batchSubmissionResponse{}
+	err = json.Unmarshal(blob, &data)
+	if err != nil {
+		return err
+	}
+
+	failures := make(map[int]BatchSubmissionFailure, len(data.TransactionFailures))
+	for _, failure := range data.TransactionFailures {
+		failures[failure.TransactionIndex] = BatchSubmissionFailure{
+			Index:       offset + failure.TransactionIndex,
+			Message:     failure.Error.Message,
+			ErrorCode:   failure.Error.ErrorCode,
+			VmErrorCode: failure.Error.VmErrorCode,
+		}
+	}
+	for i, signedTxn := range signedTxns {
+		failure, failed := failures[i]
+		if failed && attempts > 1 {
+			// An earlier attempt may have reached the node before failing, look for it
+			if _, lookupErr := rc.submittedTransaction(ctx, signedTxn); lookupErr == nil {
+				failed = false
+			}
+		}
+		if failed {
+			result.Failures = append(result.Failures, failure)
+		} else {
+			result.Accepted = append(result.Accepted, offset+i)
+		}
+	}
+	return nil
+}
+
+// submittedTransaction looks up a signed transaction by hash, returning it as a submission response if it is pending
+// or committed on the node
+func (rc *NodeClient) submittedTransaction(ctx context.Context, signedTxn *SignedTransaction) (data *api.SubmitTransactionResponse, err error) {
+	hash, err := signedTxn.Hash()
+	if err != nil {
+		return
+	}
+	au := rc.baseUrl.JoinPath("transactions/by_hash", hash)
+	response, err := rc.GetCtx(ctx, au.String())
+	if err != nil {
+		err = fmt.Errorf("GET %s, %w", au.String(), err)
+		return
+	}
+	if response.StatusCode >= 400 {
+		err = NewHttpError(response)
+		return nil, err
+	}
+	blob, err := io.ReadAll(response.Body)
+	if err != nil {
+		err = fmt.Errorf("error getting response data, %w", err)
+		return
+	}
+	_ = response.Body.Close()
+
+	err = json.Unmarshal(blob, &data)
+	return
+}
+
+// SimulateTransaction simulates a transaction without signing it, returning the simulated outcome such as gas used,
+// VM status, write set changes, and events.
+//
+// The public keys are used to build an authenticator with zeroed signatures, see [SimulationTransactionAuthenticator]
+// for the order they must be passed in for multi-agent and fee payer transactions.
+//
+//	rawTxn, _ := client.BuildTransaction(sender.AccountAddress(), payload)
+//	simulated, err := client.SimulateTransaction(rawTxn, sender.PubKey())
+//	if err == nil && !simulated[0].Success {
+//		// The transaction would fail with simulated[0].VmStatus
+//	}
+func (rc *NodeClient) SimulateTransaction(rawTxn RawTransactionImpl, signers ...crypto.PublicKey) (data []*api.UserTransaction, err error) {
+	return rc.SimulateTransactionCtx(context.Background(), rawTxn, signers...)
+}
+
+// SimulateTransactionCtx is [NodeClient.SimulateTransaction] with a [context.Context] for cancellation and deadlines
+func (rc *NodeClient) SimulateTransactionCtx(ctx context.Context, rawTxn RawTransactionImpl, signers ...crypto.PublicKey) (data []*api.UserTransaction, err error) {
+	return rc.simulateTransaction(ctx, rawTxn, nil, signers...)
+}
+
+// simulateTransaction allows for passing the node's estimation query parameters e.g. estimate_max_gas_amount
+func (rc *NodeClient) simulateTransaction(ctx context.Context, rawTxn RawTransactionImpl, params url.Values, signers ...crypto.PublicKey) (data []*api.UserTransaction, err error) {
+	auth, err := SimulationTransactionAuthenticator(rawTxn, signers...)
+	if err != nil {
+		return
+	}
+	// The signed transaction holds the plain raw transaction, the rest of a multi-agent transaction is in the
+	// authenticator
+	signedTxn := &SignedTransaction{
+		Transaction:   rawTxn,
+		Authenticator: auth,
+	}
+	if withData, ok := rawTxn.(*RawTransactionWithData); ok {
+		signedTxn.Transaction, err = withData.rawTransaction()
 		if err != nil {
-			return 0, err
+			return
 		}
-		// Cache the ChainId for later calls, because performance
-		rc.chainId = info.ChainId
 	}
-	return rc.chainId, nil
+	sblob, err := bcs.Serialize(signedTxn)
+	if err != nil {
+		return
+	}
+	au := rc.baseUrl.JoinPath("transactions/simulate")
+	if len(params) != 0 {
+		au.RawQuery = params.Encode()
+	}
+	response, _, err := rc.postRetryCtx(ctx, au.String(), ContentTypeAptosSignedTxnBcs, sblob)
+	if err != nil {
+		err = fmt.Errorf("POST %s, %w", au.String(), err)
+		return
+	}
+	if response.StatusCode >= 400 {
+		err = NewHttpError(response)
+		return nil, err
+	}
+	blob, err := io.ReadAll(response.Body)
+	if err != nil {
+		err = fmt.Errorf("error getting response data, %w", err)
+		return
+	}
+	_ = response.Body.Close()
+
+	err = json.Unmarshal(blob, &data)
+	return
+}
+
+// GetChainId retrieves the ChainId of the network
+// Note this will be cached forever, or taken directly from the config
+func (rc *NodeClient) GetChainId() (chainId uint8, err error) {
+	return rc.GetChainIdCtx(context.Background())
+}
+
+// GetChainIdCtx is [NodeClient.GetChainId] with a [context.Context] for cancellation and deadlines
+func (rc *NodeClient) GetChainIdCtx(ctx context.Context) (chainId uint8, err error) {
+	if chainId := rc.chainId.Load(); chainId != 0 {
+		return uint8(chainId), nil
+	}
+	// InfoCtx caches the ChainId for later calls, because performance
+	info, err := rc.InfoCtx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return info.ChainId, nil
 }
 
 type MaxGasAmount uint64
@@ -549,176 +1693,231 @@ type SequenceNumber uint64
 
 type ChainIdOption uint8
 
+// GasPricePriority selects which of the node's gas price estimates to use, see [EstimateGasInfo]
+type GasPricePriority uint8
+
+const (
+	GasPricePriorityDeprioritized GasPricePriority = 0
+	GasPricePriorityNormal        GasPricePriority = 1
+	GasPricePriorityPrioritized   GasPricePriority = 2
+)
+
+// EstimateGasUnitPrice is an option to BuildTransaction to fetch the gas unit price on-chain with EstimateGasPrice
+//
+//	rawTxn, err := client.BuildTransaction(sender, payload, EstimateGasUnitPrice(GasPricePriorityPrioritized))
+type EstimateGasUnitPrice GasPricePriority
+
+// EstimateMaxGasAmount is an option to BuildTransaction to simulate the transaction, and set MaxGasAmount to the
+// simulated gas used times the Multiplier for headroom.  Multiplier must be at least 1.  It can't be combined with
+// MaxGasAmount, which it would replace.
+//
+// Simulation needs the public keys of the signers, in the order described by [SimulationTransactionAuthenticator].
+// BuildSignAndSubmitTransaction fills in the sender's public key if Signers is empty.
+//
+//	rawTxn, err := client.BuildTransaction(sender.AccountAddress(), payload, EstimateMaxGasAmount{
+//		Multiplier: 1.5,
+//		Signers:    []crypto.PublicKey{sender.PubKey()},
+//	})
+type EstimateMaxGasAmount struct {
+	Multiplier float64
+	Signers    []crypto.PublicKey
+}
+
+// estimateGasUnitPrice fetches the gas estimate for the given priority
+func (rc *NodeClient) estimateGasUnitPrice(ctx context.Context, priority GasPricePriority) (uint64, error) {
+	info, err := rc.EstimateGasPriceCtx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	switch priority {
+	case GasPricePriorityDeprioritized:
+		return info.DeprioritizedGasEstimate, nil
+	case GasPricePriorityNormal:
+		return info.GasEstimate, nil
+	case GasPricePriorityPrioritized:
+		return info.PrioritizedGasEstimate, nil
+	default:
+		return 0, fmt.Errorf("unknown gas price priority %d", priority)
+	}
+}
+
+// estimateMaxGasAmount simulates the transaction, letting the node pick the maximum max gas amount the sender can
+// afford, and returns the gas used scaled by the multiplier
+func (rc *NodeClient) estimateMaxGasAmount(ctx context.Context, rawTxn RawTransactionImpl, estimate *EstimateMaxGasAmount) (uint64, error) {
+	if estimate.Multiplier < 1 {
+		return 0, fmt.Errorf("EstimateMaxGasAmount multiplier must be at least 1, got %f", estimate.Multiplier)
+	}
+	params := url.Values{}
+	params.Set("estimate_max_gas_amount", "true")
+	simulated, err := rc.simulateTransaction(ctx, rawTxn, params, estimate.Signers...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to simulate for max gas amount: %w", err)
+	}
+	if len(simulated) == 0 {
+		return 0, errors.New("failed to simulate for max gas amount: no simulation results")
+	}
+	if !simulated[0].Success {
+		return 0, fmt.Errorf("failed to simulate for max gas amount: %s", simulated[0].VmStatus)
+	}
+	return uint64(math.Ceil(float64(simulated[0].GasUsed) * estimate.Multiplier)), nil
+}
+
 // BuildTransaction builds a raw transaction for signing
-// Accepts options: MaxGasAmount, GasUnitPrice, ExpirationSeconds, SequenceNumber, ChainIdOption, FeePayer, AdditionalSigners
+// Accepts options: MaxGasAmount, GasUnitPrice, ExpirationSeconds, SequenceNumber, ChainIdOption, EstimateGasUnitPrice,
+// EstimateMaxGasAmount, an *AccountSequenceNumber to take the sequence number from, and any [BuildOption].
+// [NodeClient.BuildTransactionWithOptions] takes only BuildOptions, so that mistakes are caught at compile time.
 func (rc *NodeClient) BuildTransaction(sender AccountAddress, payload TransactionPayload, options ...any) (rawTxn *RawTransaction, err error) {
+	return rc.BuildTransactionCtx(context.Background(), sender, payload, options...)
+}
 
-	maxGasAmount := uint64(100_000) // Default to 0.001 APT max gas amount
-	gasUnitPrice := uint64(100)     // Default to min gas price
-	expirationSeconds := int64(300) // Default to 5 minutes
-	sequenceNumber := uint64(0)
-	haveSequenceNumber := false
-	chainId := uint8(0)
-	haveChainId := false
-
-	for opti, option := range options {
-		switch ovalue := option.(type) {
-		case MaxGasAmount:
-			maxGasAmount = uint64(ovalue)
-		case GasUnitPrice:
-			gasUnitPrice = uint64(ovalue)
-		case ExpirationSeconds:
-			expirationSeconds = int64(ovalue)
-			if expirationSeconds < 0 {
-				err = errors.New("ExpirationSeconds cannot be less than 0")
-				return nil, err
-			}
-		case SequenceNumber:
-			sequenceNumber = uint64(ovalue)
-			haveSequenceNumber = true
-		case ChainIdOption:
-			chainId = uint8(ovalue)
-			haveChainId = true
-		default:
-			err = fmt.Errorf("BuildTransaction arg [%d] unknown option type %T", opti+4, option)
-			return nil, err
-		}
+// BuildTransactionCtx is [NodeClient.BuildTransaction] with a [context.Context] for cancellation and deadlines
+func (rc *NodeClient) BuildTransactionCtx(ctx context.Context, sender AccountAddress, payload TransactionPayload, options ...any) (rawTxn *RawTransaction, err error) {
+	buildOptions, err := buildOptionsFromAny("BuildTransaction", options)
+	if err != nil {
+		return nil, err
 	}
+	return rc.BuildTransactionWithOptionsCtx(ctx, sender, payload, buildOptions...)
+}
 
-	// Fetch ChainId which may be cached
-	if !haveChainId {
-		chainId, err = rc.GetChainId()
-		if err != nil {
-			return nil, err
-		}
+// BuildTransactionWithOptions builds a raw transaction for signing, fetching anything not set by the options on-chain.
+// A fee payer or secondary signers need [NodeClient.BuildTransactionMultiAgentWithOptions].
+func (rc *NodeClient) BuildTransactionWithOptions(sender AccountAddress, payload TransactionPayload, options ...BuildOption) (rawTxn *RawTransaction, err error) {
+	return rc.BuildTransactionWithOptionsCtx(context.Background(), sender, payload, options...)
+}
+
+// BuildTransactionWithOptionsCtx is [NodeClient.BuildTransactionWithOptions] with a [context.Context] for cancellation
+// and deadlines
+func (rc *NodeClient) BuildTransactionWithOptionsCtx(ctx context.Context, sender AccountAddress, payload TransactionPayload, options ...BuildOption) (rawTxn *RawTransaction, err error) {
+	config, err := newBuildConfig(options)
+	if err != nil {
+		return nil, err
+	}
+	if config.feePayer != nil || len(config.secondarySigners) != 0 {
+		return nil, errors.New("FeePayer and AdditionalSigners need BuildTransactionMultiAgent, BuildTransaction only builds single signer transactions")
+	}
+	rawTxn, err = rc.buildRawTransaction(ctx, sender, payload, config)
+	if err != nil {
+		return nil, err
 	}
 
-	// Fetch sequence number unless provided
-	if !haveSequenceNumber {
-		info, err := rc.Account(sender)
-		if err != nil {
-			return nil, err
-		}
-		sequenceNumber, err = info.SequenceNumber()
+	// Simulate for max gas if requested
+	if config.estimateMaxGas != nil {
+		rawTxn.MaxGasAmount, err = rc.estimateMaxGasAmount(ctx, rawTxn, config.estimateMaxGas)
 		if err != nil {
+			config.releaseSequenceNumber(rawTxn.SequenceNumber)
 			return nil, err
 		}
 	}
+	return rawTxn, nil
+}
 
-	// TODO: fetch gas price on-chain
-	// TODO: optionally simulate for max gas
+// BuildTransactionMultiAgent builds a raw transaction for signing with fee payer or multi-agent
+// Accepts options: MaxGasAmount, GasUnitPrice, ExpirationSeconds, SequenceNumber, ChainIdOption, FeePayer, AdditionalSigners,
+// EstimateGasUnitPrice, EstimateMaxGasAmount, an *AccountSequenceNumber to take the sequence number from, and any
+// [BuildOption].  [NodeClient.BuildTransactionMultiAgentWithOptions] takes only BuildOptions.
+func (rc *NodeClient) BuildTransactionMultiAgent(sender AccountAddress, payload TransactionPayload, options ...any) (rawTxnImpl *RawTransactionWithData, err error) {
+	return rc.BuildTransactionMultiAgentCtx(context.Background(), sender, payload, options...)
+}
 
-	expirationTimestampSeconds := uint64(time.Now().Unix() + expirationSeconds)
+// BuildTransactionMultiAgentCtx is [NodeClient.BuildTransactionMultiAgent] with a [context.Context] for cancellation and deadlines
+func (rc *NodeClient) BuildTransactionMultiAgentCtx(ctx context.Context, sender AccountAddress, payload TransactionPayload, options ...any) (rawTxnImpl *RawTransactionWithData, err error) {
+	buildOptions, err := buildOptionsFromAny("BuildTransactionMultiAgent", options)
+	if err != nil {
+		return nil, err
+	}
+	return rc.BuildTransactionMultiAgentWithOptionsCtx(ctx, sender, payload, buildOptions...)
+}
 
-	// Base raw transaction used for all requests
-	return &RawTransaction{
-		Sender:                     sender,
-		SequenceNumber:             sequenceNumber,
-		Payload:                    payload,
-		MaxGasAmount:               maxGasAmount,
-		GasUnitPrice:               gasUnitPrice,
-		ExpirationTimestampSeconds: expirationTimestampSeconds,
-		ChainId:                    chainId,
-	}, nil
+// BuildTransactionMultiAgentWithOptions builds a raw transaction for signing with a fee payer, see [WithFeePayer], or
+// secondary signers, see [WithSecondarySigners], fetching anything not set by the options on-chain
+func (rc *NodeClient) BuildTransactionMultiAgentWithOptions(sender AccountAddress, payload TransactionPayload, options ...BuildOption) (rawTxnImpl *RawTransactionWithData, err error) {
+	return rc.BuildTransactionMultiAgentWithOptionsCtx(context.Background(), sender, payload, options...)
 }
 
-// BuildTransactionMultiAgent builds a raw transaction for signing with fee payer or multi-agent
-// Accepts options: MaxGasAmount, GasUnitPrice, ExpirationSeconds, SequenceNumber, ChainIdOption, FeePayer, AdditionalSigners
-func (rc *NodeClient) BuildTransactionMultiAgent(sender AccountAddress, payload TransactionPayload, options ...any) (rawTxnImpl *RawTransactionWithData, err error) {
+// BuildTransactionMultiAgentWithOptionsCtx is [NodeClient.BuildTransactionMultiAgentWithOptions] with a
+// [context.Context] for cancellation and deadlines
+func (rc *NodeClient) BuildTransactionMultiAgentWithOptionsCtx(ctx context.Context, sender AccountAddress, payload TransactionPayload, options ...BuildOption) (rawTxnImpl *RawTransactionWithData, err error) {
+	config, err := newBuildConfig(options)
+	if err != nil {
+		return nil, err
+	}
+	rawTxn, err := rc.buildRawTransaction(ctx, sender, payload, config)
+	if err != nil {
+		return nil, err
+	}
 
-	maxGasAmount := uint64(100_000) // Default to 0.001 APT max gas amount
-	gasUnitPrice := uint64(100)     // Default to min gas price
-	expirationSeconds := int64(300) // Default to 5 minutes
-	sequenceNumber := uint64(0)
-	haveSequenceNumber := false
-	chainId := uint8(0)
-	haveChainId := false
-	var feePayer *AccountAddress
-	var additionalSigners []AccountAddress
-
-	for opti, option := range options {
-		switch ovalue := option.(type) {
-		case MaxGasAmount:
-			maxGasAmount = uint64(ovalue)
-		case GasUnitPrice:
-			gasUnitPrice = uint64(ovalue)
-		case ExpirationSeconds:
-			expirationSeconds = int64(ovalue)
-			if expirationSeconds < 0 {
-				err = errors.New("ExpirationSeconds cannot be less than 0")
-				return nil, err
-			}
-		case SequenceNumber:
-			sequenceNumber = uint64(ovalue)
-			haveSequenceNumber = true
-		case ChainIdOption:
-			chainId = uint8(ovalue)
-			haveChainId = true
-		case FeePayer:
-			feePayer = ovalue
-		case AdditionalSigners:
-			additionalSigners = ovalue
-		default:
-			err = fmt.Errorf("APTTransferTransaction arg [%d] unknown option type %T", opti+4, option)
+	// Based on the options, choose which to use
+	if config.feePayer != nil {
+		rawTxnImpl = &RawTransactionWithData{
+			Variant: MultiAgentWithFeePayerRawTransactionWithDataVariant,
+			Inner: &MultiAgentWithFeePayerRawTransactionWithData{
+				RawTxn:           rawTxn,
+				FeePayer:         config.feePayer,
+				SecondarySigners: config.secondarySigners,
+			},
+		}
+	} else {
+		rawTxnImpl = &RawTransactionWithData{
+			Variant: MultiAgentRawTransactionWithDataVariant,
+			Inner: &MultiAgentRawTransactionWithData{
+				RawTxn:           rawTxn,
+				SecondarySigners: config.secondarySigners,
+			},
+		}
+	}
+
+	// Simulate for max gas if requested, the inner RawTxn is shared so it can be updated in place
+	if config.estimateMaxGas != nil {
+		rawTxn.MaxGasAmount, err = rc.estimateMaxGasAmount(ctx, rawTxnImpl, config.estimateMaxGas)
+		if err != nil {
+			config.releaseSequenceNumber(rawTxn.SequenceNumber)
 			return nil, err
 		}
 	}
+	return rawTxnImpl, nil
+}
 
+// buildRawTransaction builds the sender's raw transaction from the config, fetching the chain id, gas price, and
+// sequence number on-chain as needed
+func (rc *NodeClient) buildRawTransaction(ctx context.Context, sender AccountAddress, payload TransactionPayload, config *buildConfig) (rawTxn *RawTransaction, err error) {
 	// Fetch ChainId which may be cached
-	if !haveChainId {
-		chainId, err = rc.GetChainId()
+	chainId := config.chainId
+	if !config.haveChainId {
+		chainId, err = rc.GetChainIdCtx(ctx)
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	// Fetch sequence number unless provided
-	if !haveSequenceNumber {
-		info, err := rc.Account(sender)
+	// Fetch gas price on-chain if requested
+	gasUnitPrice := config.gasUnitPrice
+	if config.gasPricePriority != nil {
+		gasUnitPrice, err = rc.estimateGasUnitPrice(ctx, *config.gasPricePriority)
 		if err != nil {
 			return nil, err
 		}
-		sequenceNumber, err = info.SequenceNumber()
+	}
+
+	// Fetch sequence number unless provided, last so that fewer errors need a sequence number from a manager to be
+	// released.  Failures from here on must release it.
+	sequenceNumber := config.sequenceNumber
+	if !config.haveSequenceNumber {
+		sequenceNumber, err = rc.nextSequenceNumber(ctx, sender, config.sequenceNumbers)
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	// TODO: fetch gas price on-chain
-	// TODO: optionally simulate for max gas
-
-	expirationTimestampSeconds := uint64(time.Now().Unix() + expirationSeconds)
-
 	// Base raw transaction used for all requests
-	rawTxn := &RawTransaction{
+	return &RawTransaction{
 		Sender:                     sender,
 		SequenceNumber:             sequenceNumber,
 		Payload:                    payload,
-		MaxGasAmount:               maxGasAmount,
+		MaxGasAmount:               config.maxGasAmount,
 		GasUnitPrice:               gasUnitPrice,
-		ExpirationTimestampSeconds: expirationTimestampSeconds,
+		ExpirationTimestampSeconds: config.expiration(),
 		ChainId:                    chainId,
-	}
-
-	// Based on the options, choose which to use
-	if feePayer != nil {
-		return &RawTransactionWithData{
-			Variant: MultiAgentWithFeePayerRawTransactionWithDataVariant,
-			Inner: &MultiAgentWithFeePayerRawTransactionWithData{
-				RawTxn:           rawTxn,
-				FeePayer:         feePayer,
-				SecondarySigners: additionalSigners,
-			},
-		}, nil
-	} else {
-		return &RawTransactionWithData{
-			Variant: MultiAgentRawTransactionWithDataVariant,
-			Inner: &MultiAgentRawTransactionWithData{
-				RawTxn:           rawTxn,
-				SecondarySigners: additionalSigners,
-			},
-		}, nil
-	}
+	}, nil
 }
 
 type ViewPayload struct {
@@ -738,7 +1937,13 @@ func (vp *ViewPayload) MarshalBCS(ser *bcs.Serializer) {
 	}
 }
 
+// View runs a view function on chain returning a list of return values
 func (rc *NodeClient) View(payload *ViewPayload, ledgerVersion ...uint64) (data []any, err error) {
+	return rc.ViewCtx(context.Background(), payload, ledgerVersion...)
+}
+
+// ViewCtx is [NodeClient.View] with a [context.Context] for cancellation and deadlines
+func (rc *NodeClient) ViewCtx(ctx context.Context, payload *ViewPayload, ledgerVersion ...uint64) (data []any, err error) {
 	serializer := bcs.Serializer{}
 	payload.MarshalBCS(&serializer)
 	err = serializer.Error()
@@ -746,14 +1951,13 @@ func (rc *NodeClient) View(payload *ViewPayload, ledgerVersion ...uint64) (data
 		return
 	}
 	sblob := serializer.ToBytes()
-	bodyReader := bytes.NewReader(sblob)
 	au := rc.baseUrl.JoinPath("view")
 	if len(ledgerVersion) > 0 {
 		params := url.Values{}
 		params.Set("ledger_version", strconv.FormatUint(ledgerVersion[0], 10))
 		au.RawQuery = params.Encode()
 	}
-	response, err := rc.Post(au.String(), ContentTypeAptosViewFunctionBcs, bodyReader)
+	response, _, err := rc.postRetryCtx(ctx, au.String(), ContentTypeAptosViewFunctionBcs, sblob)
 	if err != nil {
 		err = fmt.Errorf("POST %s, %w", au.String(), err)
 		return
@@ -772,9 +1976,59 @@ func (rc *NodeClient) View(payload *ViewPayload, ledgerVersion ...uint64) (data
 	return
 }
 
+// ViewBCS calls a view function like [NodeClient.View], returning each return value BCS encoded rather than as JSON.
+// This avoids the JSON representation of numbers and vectors, e.g. u64 as strings.
+//
+//	values, err := client.ViewBCS(payload)
+//	balance := bcs.NewDeserializer(values[0]).U64()
+func (rc *NodeClient) ViewBCS(payload *ViewPayload, ledgerVersion ...uint64) (values [][]byte, err error) {
+	return rc.ViewBCSCtx(context.Background(), payload, ledgerVersion...)
+}
+
+// ViewBCSCtx is [NodeClient.ViewBCS] with a [context.Context] for cancellation and deadlines
+func (rc *NodeClient) ViewBCSCtx(ctx context.Context, payload *ViewPayload, ledgerVersion ...uint64) (values [][]byte, err error) {
+	sblob, err := bcs.Serialize(payload)
+	if err != nil {
+		return
+	}
+	au := rc.baseUrl.JoinPath("view")
+	if len(ledgerVersion) > 0 {
+		params := url.Values{}
+		params.Set("ledger_version", strconv.FormatUint(ledgerVersion[0], 10))
+		au.RawQuery = params.Encode()
+	}
+	response, _, err := rc.postRetryAcceptCtx(ctx, au.String(), ContentTypeAptosViewFunctionBcs, "application/x-bcs", sblob)
+	if err != nil {
+		err = fmt.Errorf("POST %s, %w", au.String(), err)
+		return
+	}
+	if response.StatusCode >= 400 {
+		err = NewHttpError(response)
+		return nil, err
+	}
+	blob, err := io.ReadAll(response.Body)
+	if err != nil {
+		err = fmt.Errorf("error getting response data, %w", err)
+		return
+	}
+	_ = response.Body.Close()
+	des := bcs.NewDeserializer(blob)
+	values = bcs.DeserializeSequenceWithFunction(des, func(des *bcs.Deserializer, value *[]byte) {
+		*value = des.ReadBytes()
+	})
+	err = des.Error()
+	return
+}
+
+// EstimateGasPrice retrieves the gas estimate from the network
 func (rc *NodeClient) EstimateGasPrice() (info EstimateGasInfo, err error) {
+	return rc.EstimateGasPriceCtx(context.Background())
+}
+
+// EstimateGasPriceCtx is [NodeClient.EstimateGasPrice] with a [context.Context] for cancellation and deadlines
+func (rc *NodeClient) EstimateGasPriceCtx(ctx context.Context) (info EstimateGasInfo, err error) {
 	au := rc.baseUrl.JoinPath("estimate_gas_price")
-	response, err := rc.Get(au.String())
+	response, err := rc.GetCtx(ctx, au.String())
 	if err != nil {
 		err = fmt.Errorf("GET %s, %w", au.String(), err)
 		return
@@ -798,12 +2052,18 @@ func (rc *NodeClient) EstimateGasPrice() (info EstimateGasInfo, err error) {
 	return
 }
 
+// AccountAPTBalance retrieves the APT balance in the account
 func (rc *NodeClient) AccountAPTBalance(account AccountAddress) (balance uint64, err error) {
+	return rc.AccountAPTBalanceCtx(context.Background(), account)
+}
+
+// AccountAPTBalanceCtx is [NodeClient.AccountAPTBalance] with a [context.Context] for cancellation and deadlines
+func (rc *NodeClient) AccountAPTBalanceCtx(ctx context.Context, account AccountAddress) (balance uint64, err error) {
 	accountBytes, err := bcs.Serialize(&account)
 	if err != nil {
 		return 0, err
 	}
-	values, err := rc.View(&ViewPayload{Module: ModuleId{
+	return ViewIntoCtx[uint64](ctx, rc, &ViewPayload{Module: ModuleId{
 		Address: AccountOne,
 		Name:    "coin",
 	},
@@ -811,20 +2071,73 @@ func (rc *NodeClient) AccountAPTBalance(account AccountAddress) (balance uint64,
 		ArgTypes: []TypeTag{AptosCoinTypeTag},
 		Args:     [][]byte{accountBytes},
 	})
+}
+
+// BuildSignAndSubmitTransaction builds, signs, and submits a transaction in one call
+// Accepts the options of [NodeClient.BuildTransaction]
+func (rc *NodeClient) BuildSignAndSubmitTransaction(sender TransactionSigner, payload TransactionPayload, options ...any) (data *api.SubmitTransactionResponse, err error) {
+	return rc.BuildSignAndSubmitTransactionCtx(context.Background(), sender, payload, options...)
+}
+
+// BuildSignAndSubmitTransactionCtx is [NodeClient.BuildSignAndSubmitTransaction] with a [context.Context] for cancellation and deadlines
+func (rc *NodeClient) BuildSignAndSubmitTransactionCtx(ctx context.Context, sender TransactionSigner, payload TransactionPayload, options ...any) (data *api.SubmitTransactionResponse, err error) {
+	buildOptions, err := buildOptionsFromAny("BuildSignAndSubmitTransaction", options)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
-	return StrToUint64(values[0].(string))
+	return rc.BuildSignAndSubmitTransactionWithOptionsCtx(ctx, sender, payload, buildOptions...)
 }
 
-func (rc *NodeClient) BuildSignAndSubmitTransaction(sender TransactionSigner, payload TransactionPayload, options ...any) (data *api.SubmitTransactionResponse, err error) {
-	rawTxn, err := rc.BuildTransaction(sender.AccountAddress(), payload, options...)
+// BuildSignAndSubmitTransactionWithOptions builds, signs, and submits a transaction in one call, see
+// [NodeClient.BuildTransactionWithOptions]
+func (rc *NodeClient) BuildSignAndSubmitTransactionWithOptions(sender TransactionSigner, payload TransactionPayload, options ...BuildOption) (data *api.SubmitTransactionResponse, err error) {
+	return rc.BuildSignAndSubmitTransactionWithOptionsCtx(context.Background(), sender, payload, options...)
+}
+
+// BuildSignAndSubmitTransactionWithOptionsCtx is [NodeClient.BuildSignAndSubmitTransactionWithOptions] with a
+// [context.Context] for cancellation and deadlines
+func (rc *NodeClient) BuildSignAndSubmitTransactionWithOptionsCtx(ctx context.Context, sender TransactionSigner, payload TransactionPayload, options ...BuildOption) (data *api.SubmitTransactionResponse, err error) {
+	config, err := newBuildConfig(options)
+	if err != nil {
+		return nil, err
+	}
+	// The sender is known here, so simulation can use its public key
+	if config.estimateMaxGas != nil && len(config.estimateMaxGas.Signers) == 0 {
+		options = append(options[:len(options):len(options)], WithSimulatedMaxGasAmount(config.estimateMaxGas.Multiplier, sender.PubKey()))
+	}
+	rawTxn, err := rc.BuildTransactionWithOptionsCtx(ctx, sender.AccountAddress(), payload, options...)
 	if err != nil {
 		return nil, err
 	}
 	signedTxn, err := rawTxn.SignedTransaction(sender)
 	if err != nil {
+		config.releaseSequenceNumber(rawTxn.SequenceNumber)
 		return nil, err
 	}
-	return rc.SubmitTransaction(signedTxn)
+	data, err = rc.SubmitTransactionCtx(ctx, signedTxn)
+	if err != nil && config.sequenceNumbers != nil {
+		// Resync the sequence number manager if the sequence number was rejected, so the next build gets a good one,
+		// otherwise give the sequence number back if the node rejected the transaction.  A transaction that may have
+		// reached mempool, e.g. after a timeout or a 5xx, keeps its sequence number.
+		resynced, _ := config.sequenceNumbers.HandleErrorCtx(ctx, err)
+		if !resynced && isSubmissionRejected(err) {
+			config.releaseSequenceNumber(rawTxn.SequenceNumber)
+		}
+	}
+	return data, err
+}
+
+// nextSequenceNumber takes the sender's next sequence number from the manager if there is one, otherwise from chain
+func (rc *NodeClient) nextSequenceNumber(ctx context.Context, sender AccountAddress, sequenceNumbers *AccountSequenceNumber) (uint64, error) {
+	if sequenceNumbers != nil {
+		if sequenceNumbers.Address() != sender {
+			return 0, fmt.Errorf("AccountSequenceNumber is for %s, not the sender %s", sequenceNumbers.Address(), sender)
+		}
+		return sequenceNumbers.NextCtx(ctx)
+	}
+	info, err := rc.AccountCtx(ctx, sender)
+	if err != nil {
+		return 0, err
+	}
+	return info.SequenceNumber()
 }
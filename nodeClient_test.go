@@ -1,7 +1,20 @@
 package aptos
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/aptos-labs/aptos-go-sdk/api"
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+	"github.com/aptos-labs/aptos-go-sdk/crypto"
 	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -20,3 +33,530 @@ func TestPollForTransaction(t *testing.T) {
 	assert.Less(t, dt, 20*time.Millisecond)
 	assert.Error(t, err)
 }
+
+func TestPollForTransactionsCtx(t *testing.T) {
+	// No aptos-node is needed, cancelling the context should stop polling well before the timeout
+	client, err := NewClient(LocalnetConfig)
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err = client.PollForTransactionsCtx(ctx, []string{"alice", "bob"}, PollTimeout(5*time.Second), PollPeriod(2*time.Millisecond))
+	dt := time.Now().Sub(start)
+
+	assert.Less(t, dt, time.Second)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestPollForTransactionsWithOptions(t *testing.T) {
+	// No aptos-node is needed, polling times out
+	client, err := NewClient(LocalnetConfig)
+	assert.NoError(t, err)
+
+	start := time.Now()
+	err = client.PollForTransactionsWithOptions([]string{"alice"}, WithPollTimeout(10*time.Millisecond), WithPollPeriod(2*time.Millisecond))
+	assert.Error(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), 9*time.Millisecond)
+
+	// Typed options mix with the untyped ones
+	err = client.PollForTransactions([]string{"alice"}, WithPollTimeout(10*time.Millisecond), PollPeriod(2*time.Millisecond))
+	assert.Error(t, err)
+
+	err = client.PollForTransactionsWithOptions([]string{"alice"}, WithPollPeriod(0))
+	assert.ErrorContains(t, err, "PollPeriod")
+}
+
+func TestWaitForTransactionCommitted(t *testing.T) {
+	expiration := uint64(1718000000)
+	var waitRequests atomic.Int32
+	var pendingRequests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(HeaderAptosLedgerVersion, "100")
+		w.Header().Set(HeaderAptosLedgerTimestampUsec, strconv.FormatUint((expiration-1)*1_000_000, 10))
+		pending := fmt.Sprintf(`{"type":"pending_transaction","hash":"0x1","sequence_number":"0","expiration_timestamp_secs":"%d"}`, expiration)
+		switch r.URL.Path {
+		case "/v1/transactions/wait_by_hash/0x1":
+			// Pending on the first long-poll, then committed
+			if waitRequests.Add(1) == 1 {
+				_, _ = w.Write([]byte(pending))
+				return
+			}
+			_, _ = w.Write([]byte(`{"type":"user_transaction","version":"7","hash":"0x1","success":true,"vm_status":"Executed successfully"}`))
+		case "/v1/transactions/wait_by_hash/0x6":
+			// Pending right away, like a node at its limit of long-poll connections
+			pendingRequests.Add(1)
+			_, _ = w.Write([]byte(pending))
+		case "/v1/transactions/wait_by_hash/0x2":
+			_, _ = w.Write([]byte(`{"type":"user_transaction","version":"8","hash":"0x2","success":false,"vm_status":"Move abort in 0x1::coin: EINSUFFICIENT_BALANCE(0x10006)"}`))
+		case "/v1/transactions/by_hash/0x3":
+			// The ledger has passed the expiration without the transaction
+			w.Header().Set(HeaderAptosLedgerTimestampUsec, strconv.FormatUint(expiration*1_000_000, 10))
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Transaction not found","error_code":"transaction_not_found"}`))
+		case "/v1/transactions/by_hash/0x4":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Transaction not found","error_code":"transaction_not_found"}`))
+		case "/v1/transactions/by_hash/0x5":
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"message":"invalid hash","error_code":"invalid_input"}`))
+		default:
+			// Like a node without the wait_by_hash endpoint
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewNodeClient(server.URL+"/v1", 4)
+	assert.NoError(t, err)
+
+	txn, err := client.WaitForTransaction("0x1", WaitModeCommitted, PollPeriod(time.Millisecond))
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(7), txn.Version)
+	assert.Equal(t, int32(2), waitRequests.Load())
+
+	txn, err = client.WaitForTransaction("0x2", WaitModeCommitted)
+	var failed *TransactionFailedError
+	assert.True(t, errors.As(err, &failed))
+	assert.Equal(t, uint64(8), failed.Transaction.Version)
+	assert.Contains(t, failed.VmStatus, "EINSUFFICIENT_BALANCE")
+	assert.Equal(t, failed.Transaction, txn)
+
+	start := time.Now()
+	_, err = client.WaitForTransaction("0x3", WaitModeCommitted, TransactionExpiration(expiration), PollPeriod(time.Millisecond))
+	assert.ErrorIs(t, err, ErrTransactionExpired)
+	assert.Less(t, time.Since(start), time.Second)
+
+	// Not found is assumed to be propagating until the timeout, when the expiration is unknown
+	start = time.Now()
+	_, err = client.WaitForTransaction("0x4", WaitModeCommitted, PollPeriod(time.Millisecond), PollTimeout(20*time.Millisecond))
+	assert.ErrorIs(t, err, ErrTransactionNotFound)
+	assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+
+	// Other errors are returned right away
+	_, err = client.WaitForTransaction("0x5", WaitModeCommitted, PollTimeout(5*time.Second))
+	var httpErr *HttpError
+	assert.True(t, errors.As(err, &httpErr))
+	assert.Equal(t, http.StatusBadRequest, httpErr.StatusCode)
+
+	_, err = client.WaitForTransaction("0x1", TransactionExpiration(expiration))
+	assert.Error(t, err)
+
+	// A long-poll answered right away still waits the poll period, rather than looping on the node
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err = client.WaitForTransactionCtx(ctx, "0x6", WaitModeCommitted, PollPeriod(20*time.Millisecond))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.LessOrEqual(t, pendingRequests.Load(), int32(7))
+}
+
+func TestSimulateTransaction(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/transactions/simulate", r.URL.Path)
+		assert.Equal(t, ContentTypeAptosSignedTxnBcs, r.Header.Get("Content-Type"))
+		_, _ = w.Write([]byte(`[{"type":"user_transaction","version":"0","hash":"0x1","gas_used":"7","success":false,"vm_status":"Move abort","sequence_number":"0"}]`))
+	}))
+	defer server.Close()
+
+	client, err := NewNodeClient(server.URL+"/v1", 4)
+	assert.NoError(t, err)
+	sender, err := NewEd25519Account()
+	assert.NoError(t, err)
+	payload, err := CoinTransferPayload(nil, AccountOne, 1)
+	assert.NoError(t, err)
+	rawTxn, err := client.BuildTransaction(sender.AccountAddress(), TransactionPayload{Payload: payload}, SequenceNumber(0))
+	assert.NoError(t, err)
+
+	simulated, err := client.SimulateTransaction(rawTxn, sender.PubKey())
+	assert.NoError(t, err)
+	assert.Len(t, simulated, 1)
+	assert.Equal(t, uint64(7), simulated[0].GasUsed)
+	assert.False(t, simulated[0].Success)
+	assert.Equal(t, "Move abort", simulated[0].VmStatus)
+}
+
+func TestBuildTransactionEstimateGas(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/estimate_gas_price":
+			_, _ = w.Write([]byte(`{"deprioritized_gas_estimate":100,"gas_estimate":150,"prioritized_gas_estimate":200}`))
+		case "/v1/transactions/simulate":
+			assert.Equal(t, "true", r.URL.Query().Get("estimate_max_gas_amount"))
+			_, _ = w.Write([]byte(`[{"type":"user_transaction","gas_used":"1000","success":true,"vm_status":"Executed successfully"}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewNodeClient(server.URL+"/v1", 4)
+	assert.NoError(t, err)
+	sender, err := NewEd25519Account()
+	assert.NoError(t, err)
+	payload, err := CoinTransferPayload(nil, AccountOne, 1)
+	assert.NoError(t, err)
+
+	rawTxn, err := client.BuildTransaction(sender.AccountAddress(), TransactionPayload{Payload: payload},
+		SequenceNumber(0),
+		EstimateGasUnitPrice(GasPricePriorityPrioritized),
+		EstimateMaxGasAmount{Multiplier: 1.5, Signers: []crypto.PublicKey{sender.PubKey()}},
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(200), rawTxn.GasUnitPrice)
+	assert.Equal(t, uint64(1500), rawTxn.MaxGasAmount)
+
+	// Conflicting gas price options
+	_, err = client.BuildTransaction(sender.AccountAddress(), TransactionPayload{Payload: payload},
+		SequenceNumber(0), GasUnitPrice(100), EstimateGasUnitPrice(GasPricePriorityNormal))
+	assert.Error(t, err)
+
+	// An explicit max gas amount isn't replaced by the simulated one
+	_, err = client.BuildTransaction(sender.AccountAddress(), TransactionPayload{Payload: payload},
+		SequenceNumber(0), MaxGasAmount(2000), EstimateMaxGasAmount{Multiplier: 1.5, Signers: []crypto.PublicKey{sender.PubKey()}})
+	assert.ErrorContains(t, err, "MaxGasAmount and EstimateMaxGasAmount")
+
+	// Headroom must not shrink the max gas amount
+	_, err = client.BuildTransaction(sender.AccountAddress(), TransactionPayload{Payload: payload},
+		SequenceNumber(0), EstimateMaxGasAmount{Multiplier: 0.5, Signers: []crypto.PublicKey{sender.PubKey()}})
+	assert.Error(t, err)
+}
+
+func TestAccountTransactionsIterator(t *testing.T) {
+	// The node caps pages at 2 transactions, the account has sent 5
+	address := AccountOne
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/accounts/"+address.String()+"/transactions", r.URL.Path)
+		start, _ := strconv.ParseUint(r.URL.Query().Get("start"), 10, 64)
+		txns := make([]string, 0)
+		for sn := start; sn < 5 && sn < start+2; sn++ {
+			txns = append(txns, fmt.Sprintf(`{"type":"user_transaction","version":"%d","hash":"0x%d","success":true,"sequence_number":"%d"}`, 100+sn, sn, sn))
+		}
+		_, _ = w.Write([]byte("[" + strings.Join(txns, ",") + "]"))
+	}))
+	defer server.Close()
+
+	client, err := NewNodeClient(server.URL+"/v1", 4)
+	assert.NoError(t, err)
+
+	start := uint64(1)
+	limit := uint64(2)
+	txns, err := client.AccountTransactions(address, &start, &limit)
+	assert.NoError(t, err)
+	assert.Len(t, txns, 2)
+	assert.Equal(t, uint64(101), *txns[0].Version())
+
+	txns, err = client.AccountTransactionsIterator(address, 0, 100).Collect()
+	assert.NoError(t, err)
+	assert.Len(t, txns, 5)
+	for i, txn := range txns {
+		userTxn, err := txn.UserTransaction()
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(i), userTxn.SequenceNumber)
+	}
+}
+
+func TestEventsByHandle(t *testing.T) {
+	const handle = "0x1::coin::CoinStore<0x1::aptos_coin::AptosCoin>"
+	var eventCount atomic.Uint64
+	eventCount.Store(3)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/accounts/" + AccountOne.String() + "/events/" + handle + "/deposit_events",
+			"/v1/accounts/" + AccountOne.String() + "/events/2":
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		start, _ := strconv.ParseUint(r.URL.Query().Get("start"), 10, 64)
+		events := make([]string, 0)
+		// The node caps pages at 2 events
+		for sn := start; sn < eventCount.Load() && sn < start+2; sn++ {
+			events = append(events, fmt.Sprintf(`{"version":"%d","guid":{"creation_number":"2","account_address":"0x1"},"sequence_number":"%d","type":"0x1::coin::DepositEvent","data":{"amount":"%d"}}`, 100+sn, sn, sn*10))
+		}
+		_, _ = w.Write([]byte("[" + strings.Join(events, ",") + "]"))
+	}))
+	defer server.Close()
+
+	client, err := NewNodeClient(server.URL+"/v1", 4)
+	assert.NoError(t, err)
+
+	start := uint64(1)
+	events, err := client.EventsByHandle(AccountOne, handle, "deposit_events", &start, nil)
+	assert.NoError(t, err)
+	assert.Len(t, events, 2)
+	assert.Equal(t, uint64(101), events[0].Version)
+	assert.Equal(t, uint64(1), events[0].SequenceNumber)
+	assert.Equal(t, uint64(2), events[0].Guid.CreationNumber)
+	assert.Equal(t, "10", events[0].Data["amount"])
+
+	events, err = client.EventsByCreationNumberIterator(AccountOne, 2, 0, 0).Collect()
+	assert.NoError(t, err)
+	assert.Len(t, events, 3)
+
+	// Follow picks up events emitted after it caught up
+	done := errors.New("done")
+	followed := make([]uint64, 0)
+	err = client.FollowEventsByHandle(context.Background(), AccountOne, handle, "deposit_events", 1, func(event *api.VersionedEvent) error {
+		followed = append(followed, event.SequenceNumber)
+		if event.SequenceNumber == 2 {
+			eventCount.Store(5)
+		}
+		if event.SequenceNumber == 4 {
+			return done
+		}
+		return nil
+	}, PollPeriod(time.Millisecond))
+	assert.ErrorIs(t, err, done)
+	assert.Equal(t, []uint64{1, 2, 3, 4}, followed)
+}
+
+func TestTableItem(t *testing.T) {
+	const handle = "0x1b854694ae746cdbd8d44186ca4929b2b337df21d1c74633be19b2710552fdca"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.Equal(t, "7", r.URL.Query().Get("ledger_version"))
+		var request map[string]any
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		switch r.URL.Path {
+		case "/v1/tables/" + handle + "/item":
+			assert.Equal(t, "address", request["key_type"])
+			assert.Equal(t, "u128", request["value_type"])
+			assert.Equal(t, "0x1", request["key"])
+			_, _ = w.Write([]byte(`"18446744073709551616"`))
+		case "/v1/tables/" + handle + "/raw_item":
+			assert.Equal(t, "application/x-bcs", r.Header.Get("Accept"))
+			assert.Equal(t, "0x0100000000000000", request["key"])
+			_, _ = w.Write([]byte{42, 0, 0, 0, 0, 0, 0, 0})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewNodeClient(server.URL+"/v1", 4)
+	assert.NoError(t, err)
+
+	value, err := client.TableItem(handle, TypeTag{Value: &AddressTag{}}, TypeTag{Value: &U128Tag{}}, "0x1", 7)
+	assert.NoError(t, err)
+	assert.Equal(t, "18446744073709551616", value)
+
+	key, err := bcs.SerializeU64(1)
+	assert.NoError(t, err)
+	valueBytes, err := client.TableItemBCS(handle, key, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(42), bcs.NewDeserializer(valueBytes).U64())
+}
+
+func TestAccountModuleCache(t *testing.T) {
+	const coinModule = `{"bytecode":"0xa11ceb0b","abi":{"address":"0x1","name":"coin","friends":[],"exposed_functions":[{"name":"transfer","visibility":"public","is_entry":true,"is_view":false,"generic_type_params":[{"constraints":[]}],"params":["&signer","address","u64"],"return":[]}],"structs":[]}}`
+	const accountModule = `{"bytecode":"0xa11ceb0c","abi":{"address":"0x1","name":"account","friends":[],"exposed_functions":[],"structs":[]}}`
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		switch r.URL.Path {
+		case "/v1/accounts/" + AccountOne.String() + "/module/coin":
+			if r.Header.Get("Accept") == "application/x-bcs" {
+				_, _ = w.Write([]byte{0xa1, 0x1c, 0xeb, 0x0b})
+				return
+			}
+			_, _ = w.Write([]byte(coinModule))
+		case "/v1/accounts/" + AccountOne.String() + "/modules":
+			_, _ = w.Write([]byte("[" + coinModule + "," + accountModule + "]"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewNodeClient(server.URL+"/v1", 4)
+	assert.NoError(t, err)
+
+	bytecode, err := client.AccountModuleBCS(AccountOne, "coin")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0xa1, 0x1c, 0xeb, 0x0b}, bytecode)
+	assert.Equal(t, int32(1), requests.Load())
+
+	module, err := client.AccountModule(AccountOne, "coin")
+	assert.NoError(t, err)
+	assert.Equal(t, "coin", module.Abi.Name)
+	assert.Equal(t, []string{"&signer", "address", "u64"}, module.Abi.ExposedFunctions[0].Params)
+	_, err = client.AccountModule(AccountOne, "coin")
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), requests.Load())
+
+	// A module at a pinned version is cached separately
+	_, err = client.AccountModule(AccountOne, "coin", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), requests.Load())
+
+	// Listing modules fills the cache
+	modules, err := client.AccountModules(AccountOne)
+	assert.NoError(t, err)
+	assert.Len(t, modules, 2)
+	module, err = client.AccountModule(AccountOne, "account")
+	assert.NoError(t, err)
+	assert.Equal(t, "account", module.Abi.Name)
+	bytecode, err = client.AccountModuleBCS(AccountOne, "account")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0xa1, 0x1c, 0xeb, 0x0c}, bytecode)
+	assert.Equal(t, int32(4), requests.Load())
+
+	client.ClearModuleCache()
+	_, err = client.AccountModule(AccountOne, "coin")
+	assert.NoError(t, err)
+	assert.Equal(t, int32(5), requests.Load())
+}
+
+func TestSubmitTransactions(t *testing.T) {
+	sender, err := NewEd25519Account()
+	assert.NoError(t, err)
+	signedTxns := make([]*SignedTransaction, 150)
+	for i := range signedTxns {
+		rawTxn := &RawTransaction{
+			Sender:                     sender.Address,
+			SequenceNumber:             uint64(i),
+			Payload:                    TransactionPayload{Payload: &EntryFunction{Module: ModuleId{Address: AccountOne, Name: "aptos_account"}, Function: "transfer"}},
+			MaxGasAmount:               1000,
+			GasUnitPrice:               100,
+			ExpirationTimestampSeconds: 1714158778,
+			ChainId:                    4,
+		}
+		signedTxns[i], err = rawTxn.SignedTransaction(sender)
+		assert.NoError(t, err)
+	}
+
+	batchSizes := make([]int, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/transactions/batch", r.URL.Path)
+		assert.Equal(t, ContentTypeAptosSignedTxnBcs, r.Header.Get("Content-Type"))
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		// The body is a BCS sequence of signed transactions, only the length is needed here
+		batchSizes = append(batchSizes, int(bcs.NewDeserializer(body).Uleb128()))
+
+		// Reject the second transaction of every batch
+		w.WriteHeader(http.StatusPartialContent)
+		_, _ = w.Write([]byte(`{"transaction_failures":[{"error":{"message":"Invalid transaction: Type: Validation Code: SEQUENCE_NUMBER_TOO_OLD","error_code":"vm_error","vm_error_code":3},"transaction_index":1}]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewNodeClient(server.URL+"/v1", 4)
+	assert.NoError(t, err)
+
+	result, err := client.SubmitTransactions(signedTxns)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{100, 50}, batchSizes)
+	assert.Len(t, result.Accepted, 148)
+	assert.Len(t, result.Failures, 2)
+	assert.Equal(t, 1, result.Failures[0].Index)
+	assert.Equal(t, 101, result.Failures[1].Index)
+	assert.Equal(t, "vm_error", result.Failures[0].ErrorCode)
+	assert.Equal(t, uint64(3), *result.Failures[0].VmErrorCode)
+	assert.Contains(t, result.Failures[0].Message, "SEQUENCE_NUMBER_TOO_OLD")
+}
+
+func TestSubmitTransactionsBatchFailed(t *testing.T) {
+	sender, err := NewEd25519Account()
+	assert.NoError(t, err)
+	signedTxns := make([]*SignedTransaction, 250)
+	for i := range signedTxns {
+		rawTxn := &RawTransaction{
+			Sender:                     sender.Address,
+			SequenceNumber:             uint64(i),
+			Payload:                    TransactionPayload{Payload: &EntryFunction{Module: ModuleId{Address: AccountOne, Name: "aptos_account"}, Function: "transfer"}},
+			MaxGasAmount:               1000,
+			GasUnitPrice:               100,
+			ExpirationTimestampSeconds: 1714158778,
+			ChainId:                    4,
+		}
+		signedTxns[i], err = rawTxn.SignedTransaction(sender)
+		assert.NoError(t, err)
+	}
+
+	// The first batch is accepted, and the second is unavailable through every retry
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.WriteHeader(http.StatusAccepted)
+			_, _ = w.Write([]byte(`{"transaction_failures":[]}`))
+			return
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(`{"message":"Service unavailable","error_code":"internal_error"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewNodeClient(server.URL+"/v1", 4)
+	assert.NoError(t, err)
+	client.SetRetryPolicy(&RetryPolicy{MaxRetries: 3, InitialBackoff: time.Millisecond})
+
+	result, err := client.SubmitTransactions(signedTxns)
+	assert.Error(t, err)
+	assert.Equal(t, int32(5), requests.Load())
+	assert.NotNil(t, result)
+	assert.Len(t, result.Accepted, 100)
+	assert.Equal(t, 99, result.Accepted[99])
+
+	// The failed batch and the one after it weren't submitted
+	assert.Len(t, result.Failures, 150)
+	for i, failure := range result.Failures {
+		assert.Equal(t, 100+i, failure.Index)
+		assert.Equal(t, err, failure.BatchErr)
+		assert.Empty(t, failure.ErrorCode)
+		var apiErr *AptosApiError
+		assert.True(t, errors.As(failure.BatchErr, &apiErr))
+		assert.Equal(t, AptosErrorCodeInternalError, apiErr.ErrorCode)
+	}
+}
+
+func TestWithLedgerInfo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(HeaderAptosChainId, "4")
+		w.Header().Set(HeaderAptosLedgerVersion, "1234")
+		w.Header().Set(HeaderAptosLedgerOldestVersion, "0")
+		w.Header().Set(HeaderAptosLedgerTimestampUsec, "1718000000000000")
+		w.Header().Set(HeaderAptosEpoch, "3")
+		w.Header().Set(HeaderAptosBlockHeight, "567")
+		w.Header().Set(HeaderAptosOldestBlockHeight, "1")
+		switch r.URL.Path {
+		case "/v1/view":
+			w.Header().Set(HeaderAptosGasUsed, "9")
+			_, _ = w.Write([]byte(`["100"]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Account not found","error_code":"account_not_found","vm_error_code":null}`))
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewNodeClient(server.URL+"/v1", 4)
+	assert.NoError(t, err)
+
+	info := &LedgerInfo{}
+	_, err = client.ViewCtx(WithLedgerInfo(context.Background(), info), &ViewPayload{
+		Module:   ModuleId{Address: AccountOne, Name: "coin"},
+		Function: "balance",
+		ArgTypes: []TypeTag{AptosCoinTypeTag},
+		Args:     [][]byte{AccountOne[:]},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, LedgerInfo{
+		ChainId:             4,
+		LedgerVersion:       1234,
+		OldestLedgerVersion: 0,
+		LedgerTimestampUsec: 1718000000000000,
+		Epoch:               3,
+		BlockHeight:         567,
+		OldestBlockHeight:   1,
+		GasUsed:             info.GasUsed,
+	}, *info)
+	assert.Equal(t, uint64(9), *info.GasUsed)
+
+	// Error responses carry the ledger info too
+	info = &LedgerInfo{}
+	_, err = client.AccountCtx(WithLedgerInfo(context.Background(), info), AccountOne)
+	assert.Error(t, err)
+	assert.Equal(t, uint64(1234), info.LedgerVersion)
+	assert.Nil(t, info.GasUsed)
+}
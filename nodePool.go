@@ -0,0 +1,356 @@
+package aptos
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// NodeSelection is the strategy a [NodePool] uses to pick a node for each request
+type NodeSelection int
+
+const (
+	NodeSelectionRoundRobin    NodeSelection = iota // Rotate through the healthy nodes
+	NodeSelectionLowestLatency                      // Pick the healthy node with the lowest observed latency
+)
+
+// NodePoolConfig configures a [NodePool]
+type NodePoolConfig struct {
+	Selection       NodeSelection // How to pick a node for each request
+	MaxLedgerLag    uint64        // Nodes further behind the most recent ledger version are unhealthy, 0 disables the check
+	FailureCooldown time.Duration // How long a failed node is avoided, defaults to 30 seconds
+}
+
+// NodeStatus is a snapshot of a node in a [NodePool]
+type NodeStatus struct {
+	Url           string
+	Healthy       bool
+	LedgerVersion uint64        // Most recent ledger version seen from the node, 0 if unknown
+	Latency       time.Duration // Moving average of the node's response time, 0 if unknown
+}
+
+// ErrLedgerVersionNotReached is returned when no node has reached the minimum ledger version of a read,
+// see [WithMinLedgerVersion]
+var ErrLedgerVersionNotReached = errors.New("node has not reached the minimum ledger version")
+
+const defaultNodeFailureCooldown = 30 * time.Second
+
+type poolNode struct {
+	baseUrl        *url.URL
+	ledgerVersion  uint64
+	latency        time.Duration
+	unhealthyUntil time.Time
+	lagging        bool
+}
+
+func (node *poolNode) healthy(now time.Time) bool {
+	return !node.lagging && !now.Before(node.unhealthyUntil)
+}
+
+// NodePool is a set of fullnodes backing a single [NodeClient], see [NewNodeClientWithPool].
+//
+// Requests go to a healthy node picked by the [NodeSelection], and idempotent requests fail over to another node on
+// transient errors.  Nodes are marked unhealthy when requests to them fail, and by health checks, which can be run
+// in the background with [NodePool.RunHealthChecks].
+type NodePool struct {
+	send   RoundTrip // Sends health checks through the node client's interceptors
+	config NodePoolConfig
+	nodes  []*poolNode
+	mutex  sync.Mutex
+	next   int
+}
+
+// NewNodeClientWithPool creates a [NodeClient] backed by a pool of fullnodes, all of which must serve the same chain
+//
+//	client, err := NewNodeClientWithPool([]string{
+//		"https://fullnode-1.example.com/v1",
+//		"https://fullnode-2.example.com/v1",
+//	}, 1, NodePoolConfig{Selection: NodeSelectionLowestLatency, MaxLedgerLag: 100})
+//	go client.Pool().RunHealthChecks(ctx, 10*time.Second)
+func NewNodeClientWithPool(rpcUrls []string, chainId uint8, config NodePoolConfig) (*NodeClient, error) {
+	// Set cookie jar so cookie stickiness applies to connections
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return nil, err
+	}
+	defaultClient := &http.Client{
+		Jar:     jar,
+		Timeout: 60 * time.Second,
+	}
+	return NewNodeClientWithPoolAndHttpClient(rpcUrls, chainId, config, defaultClient)
+}
+
+// NewNodeClientWithPoolAndHttpClient is [NewNodeClientWithPool] with a custom [http.Client]
+func NewNodeClientWithPoolAndHttpClient(rpcUrls []string, chainId uint8, config NodePoolConfig, client *http.Client) (*NodeClient, error) {
+	if len(rpcUrls) == 0 {
+		return nil, errors.New("node pool requires at least one RPC url")
+	}
+	if config.FailureCooldown == 0 {
+		config.FailureCooldown = defaultNodeFailureCooldown
+	}
+	pool := &NodePool{
+		config: config,
+		nodes:  make([]*poolNode, len(rpcUrls)),
+	}
+	for i, rpcUrl := range rpcUrls {
+		baseUrl, err := url.Parse(rpcUrl)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse RPC url '%s': %w", rpcUrl, err)
+		}
+		pool.nodes[i] = &poolNode{baseUrl: baseUrl}
+	}
+
+	rc, err := NewNodeClientWithHttpClient(rpcUrls[0], chainId, client)
+	if err != nil {
+		return nil, err
+	}
+	rc.pool = pool
+	pool.send = rc.send
+	return rc, nil
+}
+
+// Pool returns the client's node pool, nil if it was created with a single RPC url
+func (rc *NodeClient) Pool() *NodePool {
+	return rc.pool
+}
+
+// Nodes returns a snapshot of the status of each node in the pool
+func (pool *NodePool) Nodes() []NodeStatus {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+	now := time.Now()
+	statuses := make([]NodeStatus, len(pool.nodes))
+	for i, node := range pool.nodes {
+		statuses[i] = NodeStatus{
+			Url:           node.baseUrl.String(),
+			Healthy:       node.healthy(now),
+			LedgerVersion: node.ledgerVersion,
+			Latency:       node.latency,
+		}
+	}
+	return statuses
+}
+
+// CheckHealth checks every node in the pool concurrently.  A node is healthy if its /-/healthy endpoint succeeds, and it
+// is no more than [NodePoolConfig.MaxLedgerLag] versions behind the most recent node.
+func (pool *NodePool) CheckHealth(ctx context.Context) {
+	wg := sync.WaitGroup{}
+	for _, node := range pool.nodes {
+		wg.Add(1)
+		go func(node *poolNode) {
+			defer wg.Done()
+			pool.checkNode(ctx, node)
+		}(node)
+	}
+	wg.Wait()
+
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+	maxVersion := uint64(0)
+	for _, node := range pool.nodes {
+		maxVersion = max(maxVersion, node.ledgerVersion)
+	}
+	for _, node := range pool.nodes {
+		node.lagging = pool.config.MaxLedgerLag > 0 && maxVersion-node.ledgerVersion > pool.config.MaxLedgerLag
+	}
+}
+
+// RunHealthChecks checks the health of the pool every period until the context is cancelled.  It returns the context's
+// error once cancelled, or an error right away if the period isn't positive.
+//
+//	go client.Pool().RunHealthChecks(ctx, 10*time.Second)
+func (pool *NodePool) RunHealthChecks(ctx context.Context, period time.Duration) error {
+	if period <= 0 {
+		return fmt.Errorf("health check period must be positive, got %s", period)
+	}
+	for {
+		pool.CheckHealth(ctx)
+		if err := sleepCtx(ctx, period); err != nil {
+			return err
+		}
+	}
+}
+
+func (pool *NodePool) checkNode(ctx context.Context, node *poolNode) {
+	start := time.Now()
+	err := pool.getHealthy(ctx, node)
+	var info NodeInfo
+	if err == nil {
+		info, err = pool.getInfo(ctx, node)
+	}
+	if err != nil {
+		if ctx.Err() == nil {
+			pool.recordFailure(node)
+		}
+		return
+	}
+
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+	node.unhealthyUntil = time.Time{}
+	node.ledgerVersion = max(node.ledgerVersion, info.LedgerVersion())
+	node.latency = averageLatency(node.latency, time.Since(start)/2)
+}
+
+func (pool *NodePool) getHealthy(ctx context.Context, node *poolNode) error {
+	response, err := pool.get(ctx, node.baseUrl.JoinPath("-/healthy"))
+	if err != nil {
+		return err
+	}
+	_, _ = io.Copy(io.Discard, response.Body)
+	_ = response.Body.Close()
+	return nil
+}
+
+func (pool *NodePool) getInfo(ctx context.Context, node *poolNode) (info NodeInfo, err error) {
+	response, err := pool.get(ctx, node.baseUrl)
+	if err != nil {
+		return
+	}
+	blob, err := io.ReadAll(response.Body)
+	_ = response.Body.Close()
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(blob, &info)
+	return
+}
+
+func (pool *NodePool) get(ctx context.Context, getUrl *url.URL) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", getUrl.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set(ClientHeader, ClientHeaderValue)
+	response, err := pool.send(req)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode >= 400 {
+		return nil, NewHttpError(response)
+	}
+	return response, nil
+}
+
+// pick chooses the node for the next attempt of a request.  It prefers healthy nodes that have not been tried yet and
+// are known to have reached the minimum ledger version, relaxing each of those in turn if no node qualifies.
+func (pool *NodePool) pick(tried []*poolNode, minLedgerVersion uint64) *poolNode {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+	now := time.Now()
+	untried := func(node *poolNode) bool {
+		for _, t := range tried {
+			if t == node {
+				return false
+			}
+		}
+		return true
+	}
+	filters := [][]func(node *poolNode) bool{
+		{untried, func(node *poolNode) bool { return node.healthy(now) && node.ledgerVersion >= minLedgerVersion }},
+		{untried, func(node *poolNode) bool { return node.healthy(now) }},
+		{untried},
+		{},
+	}
+	for _, filter := range filters {
+		candidates := make([]*poolNode, 0, len(pool.nodes))
+	nodes:
+		for _, node := range pool.nodes {
+			for _, f := range filter {
+				if !f(node) {
+					continue nodes
+				}
+			}
+			candidates = append(candidates, node)
+		}
+		if len(candidates) > 0 {
+			return pool.choose(candidates)
+		}
+	}
+	return pool.nodes[0]
+}
+
+// choose picks among candidates according to the selection strategy, the mutex must be held
+func (pool *NodePool) choose(candidates []*poolNode) *poolNode {
+	switch pool.config.Selection {
+	case NodeSelectionLowestLatency:
+		// Nodes without a latency measurement are tried first, to measure them
+		best := candidates[0]
+		for _, node := range candidates[1:] {
+			if node.latency < best.latency {
+				best = node
+			}
+		}
+		return best
+	default:
+		node := candidates[pool.next%len(candidates)]
+		pool.next++
+		return node
+	}
+}
+
+// route points a request built against the client's base url at the node, requests to other hosts are untouched
+func (pool *NodePool) route(req *http.Request, baseUrl *url.URL, node *poolNode) bool {
+	if req.URL.Scheme != baseUrl.Scheme || req.URL.Host != baseUrl.Host || !strings.HasPrefix(req.URL.Path, baseUrl.Path) {
+		return false
+	}
+	routed := *req.URL
+	routed.Scheme = node.baseUrl.Scheme
+	routed.Host = node.baseUrl.Host
+	routed.User = node.baseUrl.User
+	routed.Path = node.baseUrl.Path + strings.TrimPrefix(req.URL.Path, baseUrl.Path)
+	routed.RawPath = ""
+	req.URL = &routed
+	req.Host = ""
+	return true
+}
+
+// recordResponse updates a node's latency and ledger version after a request that reached it
+func (pool *NodePool) recordResponse(node *poolNode, latency time.Duration, ledgerVersion uint64) {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+	node.latency = averageLatency(node.latency, latency)
+	node.ledgerVersion = max(node.ledgerVersion, ledgerVersion)
+}
+
+// recordFailure avoids a node for the failure cooldown
+func (pool *NodePool) recordFailure(node *poolNode) {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+	node.unhealthyUntil = time.Now().Add(pool.config.FailureCooldown)
+}
+
+// averageLatency is an exponential moving average, weighting the latest sample by 1/4
+func averageLatency(average time.Duration, sample time.Duration) time.Duration {
+	if average == 0 {
+		return sample
+	}
+	return (3*average + sample) / 4
+}
+
+type minLedgerVersionKey struct{}
+
+// WithMinLedgerVersion pins reads made with the context to nodes that have reached at least the given ledger version,
+// e.g. the version of a transaction that was just committed.  Reads from nodes that are behind are retried, on another
+// node if the client has a [NodePool], and fail with [ErrLedgerVersionNotReached] once retries are exhausted.
+//
+//	txn, _ := client.WaitForTransaction(hash)
+//	ctx := WithMinLedgerVersion(context.Background(), txn.Version)
+//	balance, err := client.AccountAPTBalanceCtx(ctx, address)
+func WithMinLedgerVersion(ctx context.Context, ledgerVersion uint64) context.Context {
+	return context.WithValue(ctx, minLedgerVersionKey{}, ledgerVersion)
+}
+
+// minLedgerVersion returns the minimum ledger version set by [WithMinLedgerVersion], if any
+func minLedgerVersion(ctx context.Context) (uint64, bool) {
+	ledgerVersion, ok := ctx.Value(minLedgerVersionKey{}).(uint64)
+	return ledgerVersion, ok
+}
@@ -0,0 +1,203 @@
+package aptos
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testPoolNode serves node info at the given ledger version, counting the requests it receives
+type testPoolNode struct {
+	server        *httptest.Server
+	requests      atomic.Int32
+	status        atomic.Int32
+	ledgerVersion atomic.Uint64
+	delay         time.Duration
+}
+
+func newTestPoolNode(t *testing.T, ledgerVersion uint64, delay time.Duration) *testPoolNode {
+	node := &testPoolNode{delay: delay}
+	node.status.Store(http.StatusOK)
+	node.ledgerVersion.Store(ledgerVersion)
+	node.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		node.requests.Add(1)
+		time.Sleep(node.delay)
+		version := node.ledgerVersion.Load()
+		w.Header().Set(HeaderAptosLedgerVersion, fmt.Sprintf("%d", version))
+		w.WriteHeader(int(node.status.Load()))
+		switch r.URL.Path {
+		case "/v1/-/healthy":
+			_, _ = w.Write([]byte(`{"message":"aptos-node:ok"}`))
+		default:
+			_, _ = fmt.Fprintf(w, `{"chain_id":4,"epoch":"1","ledger_version":"%d","oldest_ledger_version":"0","ledger_timestamp":"1","node_role":"full_node","oldest_block_height":"0","block_height":"1","git_hash":"abc"}`, version)
+		}
+	}))
+	t.Cleanup(node.server.Close)
+	return node
+}
+
+func newTestPoolClient(t *testing.T, config NodePoolConfig, nodes ...*testPoolNode) *NodeClient {
+	urls := make([]string, len(nodes))
+	for i, node := range nodes {
+		urls[i] = node.server.URL + "/v1"
+	}
+	client, err := NewNodeClientWithPool(urls, 4, config)
+	assert.NoError(t, err)
+	client.SetRetryPolicy(testRetryPolicy())
+	return client
+}
+
+func TestNodePoolRoundRobin(t *testing.T) {
+	node1 := newTestPoolNode(t, 10, 0)
+	node2 := newTestPoolNode(t, 10, 0)
+	client := newTestPoolClient(t, NodePoolConfig{}, node1, node2)
+
+	for i := 0; i < 4; i++ {
+		_, err := client.Info()
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, int32(2), node1.requests.Load())
+	assert.Equal(t, int32(2), node2.requests.Load())
+}
+
+func TestNodePoolFailover(t *testing.T) {
+	node1 := newTestPoolNode(t, 10, 0)
+	node2 := newTestPoolNode(t, 10, 0)
+	node1.status.Store(http.StatusServiceUnavailable)
+	client := newTestPoolClient(t, NodePoolConfig{}, node1, node2)
+
+	for i := 0; i < 3; i++ {
+		info, err := client.Info()
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(10), info.LedgerVersion())
+	}
+	// The failed node is avoided after the first failure
+	assert.Equal(t, int32(1), node1.requests.Load())
+	assert.Equal(t, int32(3), node2.requests.Load())
+
+	statuses := client.Pool().Nodes()
+	assert.False(t, statuses[0].Healthy)
+	assert.True(t, statuses[1].Healthy)
+
+	// A passing health check restores the node
+	node1.status.Store(http.StatusOK)
+	client.Pool().CheckHealth(context.Background())
+	assert.True(t, client.Pool().Nodes()[0].Healthy)
+}
+
+func TestNodePoolHealthCheckLag(t *testing.T) {
+	node1 := newTestPoolNode(t, 1000, 0)
+	node2 := newTestPoolNode(t, 10, 0)
+	client := newTestPoolClient(t, NodePoolConfig{MaxLedgerLag: 100}, node1, node2)
+
+	client.Pool().CheckHealth(context.Background())
+	statuses := client.Pool().Nodes()
+	assert.True(t, statuses[0].Healthy)
+	assert.Equal(t, uint64(1000), statuses[0].LedgerVersion)
+	assert.False(t, statuses[1].Healthy)
+
+	for i := 0; i < 2; i++ {
+		_, err := client.Info()
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, int32(4), node1.requests.Load())
+	assert.Equal(t, int32(2), node2.requests.Load())
+}
+
+func TestNodePoolRunHealthChecks(t *testing.T) {
+	node1 := newTestPoolNode(t, 10, 0)
+	node2 := newTestPoolNode(t, 10, 0)
+	node1.status.Store(http.StatusServiceUnavailable)
+	client := newTestPoolClient(t, NodePoolConfig{}, node1, node2)
+	_, err := client.Info()
+	assert.NoError(t, err)
+	assert.False(t, client.Pool().Nodes()[0].Healthy)
+
+	// The checks restore the node once it recovers
+	node1.status.Store(http.StatusOK)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		assert.ErrorIs(t, client.Pool().RunHealthChecks(ctx, time.Millisecond), context.Canceled)
+		close(done)
+	}()
+	assert.Eventually(t, func() bool { return client.Pool().Nodes()[0].Healthy }, 5*time.Second, time.Millisecond)
+	cancel()
+	<-done
+
+	// A period that isn't positive is rejected, rather than checking in a tight loop
+	before := node1.requests.Load()
+	assert.Error(t, client.Pool().RunHealthChecks(context.Background(), 0))
+	assert.Error(t, client.Pool().RunHealthChecks(context.Background(), -time.Second))
+	assert.Equal(t, before, node1.requests.Load())
+}
+
+func TestNodePoolLowestLatency(t *testing.T) {
+	slow := newTestPoolNode(t, 10, 20*time.Millisecond)
+	fast := newTestPoolNode(t, 10, 0)
+	client := newTestPoolClient(t, NodePoolConfig{Selection: NodeSelectionLowestLatency}, slow, fast)
+
+	client.Pool().CheckHealth(context.Background())
+	statuses := client.Pool().Nodes()
+	assert.Greater(t, statuses[0].Latency, statuses[1].Latency)
+
+	slowRequests := slow.requests.Load()
+	for i := 0; i < 3; i++ {
+		_, err := client.Info()
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, slowRequests, slow.requests.Load())
+}
+
+func TestNodePoolMinLedgerVersion(t *testing.T) {
+	behind := newTestPoolNode(t, 5, 0)
+	ahead := newTestPoolNode(t, 10, 0)
+	client := newTestPoolClient(t, NodePoolConfig{}, behind, ahead)
+
+	for i := 0; i < 3; i++ {
+		info, err := client.InfoCtx(WithMinLedgerVersion(context.Background(), 10))
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(10), info.LedgerVersion())
+	}
+
+	_, err := client.InfoCtx(WithMinLedgerVersion(context.Background(), 20))
+	assert.ErrorIs(t, err, ErrLedgerVersionNotReached)
+}
+
+func TestMinLedgerVersionSingleNode(t *testing.T) {
+	node := newTestPoolNode(t, 5, 0)
+	client, err := NewNodeClient(node.server.URL+"/v1", 4)
+	assert.NoError(t, err)
+	client.SetRetryPolicy(testRetryPolicy())
+
+	// The node catches up while the read is being retried
+	go func() {
+		time.Sleep(2 * time.Millisecond)
+		node.ledgerVersion.Store(10)
+	}()
+	info, err := client.InfoCtx(WithMinLedgerVersion(context.Background(), 10))
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(10), info.LedgerVersion())
+}
+
+func TestNodePoolRoute(t *testing.T) {
+	pool := &NodePool{}
+	baseUrl, _ := url.Parse("https://primary.example.com/v1")
+	nodeUrl, _ := url.Parse("http://secondary.example.com:8080/api/v1")
+	node := &poolNode{baseUrl: nodeUrl}
+
+	req, _ := http.NewRequest("GET", "https://primary.example.com/v1/accounts/0x1?ledger_version=5", nil)
+	assert.True(t, pool.route(req, baseUrl, node))
+	assert.Equal(t, "http://secondary.example.com:8080/api/v1/accounts/0x1?ledger_version=5", req.URL.String())
+
+	// Other services, such as the faucet, are not routed
+	req, _ = http.NewRequest("POST", "https://faucet.example.com/mint", nil)
+	assert.False(t, pool.route(req, baseUrl, node))
+	assert.Equal(t, "https://faucet.example.com/mint", req.URL.String())
+}
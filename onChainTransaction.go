@@ -0,0 +1,678 @@
+package aptos
+
+import (
+	"fmt"
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+	"slices"
+)
+
+//region Transaction
+
+type TransactionVariant uint32
+
+const (
+	TransactionVariantUser             TransactionVariant = 0
+	TransactionVariantGenesis          TransactionVariant = 1
+	TransactionVariantBlockMetadata    TransactionVariant = 2
+	TransactionVariantStateCheckpoint  TransactionVariant = 3
+	TransactionVariantValidator        TransactionVariant = 4
+	TransactionVariantBlockMetadataExt TransactionVariant = 5
+	TransactionVariantBlockEpilogue    TransactionVariant = 6
+)
+
+// TransactionImpl is an interface describing all the different types of on-chain Transaction
+type TransactionImpl interface {
+	bcs.Struct
+	TransactionType() TransactionVariant // This is specifically to ensure that wrong types don't end up here
+}
+
+// Transaction is any transaction committed to the ledger, e.g. a SignedTransaction sent by a user or a
+// BlockMetadataTransaction starting a block.  It is the BCS counterpart of [api.Transaction].
+// Implements bcs.Struct
+type Transaction struct {
+	Inner TransactionImpl
+}
+
+// Type returns the variant of the transaction
+func (txn *Transaction) Type() TransactionVariant {
+	return txn.Inner.TransactionType()
+}
+
+// UserTransaction returns the inner SignedTransaction, or an error if it's another type of transaction
+func (txn *Transaction) UserTransaction() (*SignedTransaction, error) {
+	if signedTxn, ok := txn.Inner.(*SignedTransaction); ok {
+		return signedTxn, nil
+	}
+	return nil, fmt.Errorf("transaction is not a user transaction, it's variant %d", txn.Inner.TransactionType())
+}
+
+// Hash takes the hash of the Transaction, matching TransactionInfo.TransactionHash
+func (txn *Transaction) Hash() (string, error) {
+	txnBytes, err := bcs.Serialize(txn)
+	if err != nil {
+		return "", err
+	}
+	return BytesToHex(Sha3256Hash([][]byte{transactionPrefix(), txnBytes})), nil
+}
+
+//region Transaction bcs.Struct
+
+func (txn *Transaction) MarshalBCS(ser *bcs.Serializer) {
+	if txn.Inner == nil {
+		ser.SetError(fmt.Errorf("nil transaction"))
+		return
+	}
+	ser.Uleb128(uint32(txn.Inner.TransactionType()))
+	ser.Struct(txn.Inner)
+}
+
+func (txn *Transaction) UnmarshalBCS(des *bcs.Deserializer) {
+	variant := TransactionVariant(des.Uleb128())
+	if des.Error() != nil {
+		return
+	}
+	switch variant {
+	case TransactionVariantUser:
+		txn.Inner = &SignedTransaction{}
+	case TransactionVariantGenesis:
+		txn.Inner = &GenesisTransaction{}
+	case TransactionVariantBlockMetadata:
+		txn.Inner = &BlockMetadataTransaction{}
+	case TransactionVariantStateCheckpoint:
+		txn.Inner = &StateCheckpointTransaction{}
+	case TransactionVariantValidator:
+		txn.Inner = &ValidatorTransaction{}
+	case TransactionVariantBlockMetadataExt:
+		txn.Inner = &BlockMetadataExtTransaction{}
+	case TransactionVariantBlockEpilogue:
+		txn.Inner = &BlockEpilogueTransaction{}
+	default:
+		des.SetError(fmt.Errorf("unknown Transaction variant %d", variant))
+		return
+	}
+	des.Struct(txn.Inner)
+}
+
+//endregion
+//endregion
+
+//region GenesisTransaction
+
+type WriteSetPayloadVariant uint32
+
+const (
+	WriteSetPayloadVariantDirect WriteSetPayloadVariant = 0
+	WriteSetPayloadVariantScript WriteSetPayloadVariant = 1
+)
+
+// GenesisTransaction is the first transaction of a chain, either writing state directly or running a script.  Which
+// fields are set depends on the Variant:
+//
+//   - WriteSetPayloadVariantDirect sets ChangeSet and Events
+//   - WriteSetPayloadVariantScript sets ExecuteAs and Script
+//
+// Implements TransactionImpl, bcs.Struct
+type GenesisTransaction struct {
+	Variant   WriteSetPayloadVariant
+	ChangeSet WriteSet
+	Events    []ContractEvent
+	ExecuteAs AccountAddress
+	Script    Script
+}
+
+func (txn *GenesisTransaction) TransactionType() TransactionVariant {
+	return TransactionVariantGenesis
+}
+
+//region GenesisTransaction bcs.Struct
+
+func (txn *GenesisTransaction) MarshalBCS(ser *bcs.Serializer) {
+	ser.Uleb128(uint32(txn.Variant))
+	switch txn.Variant {
+	case WriteSetPayloadVariantDirect:
+		ser.Struct(&txn.ChangeSet)
+		bcs.SerializeSequence(txn.Events, ser)
+	case WriteSetPayloadVariantScript:
+		ser.Struct(&txn.ExecuteAs)
+		ser.Struct(&txn.Script)
+	default:
+		ser.SetError(fmt.Errorf("unknown WriteSetPayload variant %d", txn.Variant))
+	}
+}
+
+func (txn *GenesisTransaction) UnmarshalBCS(des *bcs.Deserializer) {
+	txn.Variant = WriteSetPayloadVariant(des.Uleb128())
+	if des.Error() != nil {
+		return
+	}
+	switch txn.Variant {
+	case WriteSetPayloadVariantDirect:
+		des.Struct(&txn.ChangeSet)
+		txn.Events = bcs.DeserializeSequence[ContractEvent](des)
+	case WriteSetPayloadVariantScript:
+		des.Struct(&txn.ExecuteAs)
+		des.Struct(&txn.Script)
+	default:
+		des.SetError(fmt.Errorf("unknown WriteSetPayload variant %d", txn.Variant))
+	}
+}
+
+//endregion
+//endregion
+
+//region BlockMetadataTransaction
+
+// BlockMetadataTransaction starts a block, recording its proposer and the votes on the previous block
+// Implements TransactionImpl, bcs.Struct
+type BlockMetadataTransaction struct {
+	Id                       HashValue
+	Epoch                    uint64
+	Round                    uint64
+	Proposer                 AccountAddress
+	PreviousBlockVotesBitvec []byte
+	FailedProposerIndices    []uint32
+	TimestampUsecs           uint64
+}
+
+func (txn *BlockMetadataTransaction) TransactionType() TransactionVariant {
+	return TransactionVariantBlockMetadata
+}
+
+//region BlockMetadataTransaction bcs.Struct
+
+func (txn *BlockMetadataTransaction) MarshalBCS(ser *bcs.Serializer) {
+	ser.Struct(&txn.Id)
+	ser.U64(txn.Epoch)
+	ser.U64(txn.Round)
+	ser.Struct(&txn.Proposer)
+	ser.WriteBytes(txn.PreviousBlockVotesBitvec)
+	bcs.SerializeSequenceWithFunction(txn.FailedProposerIndices, ser, func(ser *bcs.Serializer, index uint32) {
+		ser.U32(index)
+	})
+	ser.U64(txn.TimestampUsecs)
+}
+
+func (txn *BlockMetadataTransaction) UnmarshalBCS(des *bcs.Deserializer) {
+	des.Struct(&txn.Id)
+	txn.Epoch = des.U64()
+	txn.Round = des.U64()
+	des.Struct(&txn.Proposer)
+	txn.PreviousBlockVotesBitvec = des.ReadBytes()
+	txn.FailedProposerIndices = bcs.DeserializeSequenceWithFunction(des, func(des *bcs.Deserializer, index *uint32) {
+		*index = des.U32()
+	})
+	txn.TimestampUsecs = des.U64()
+}
+
+//endregion
+//endregion
+
+//region BlockMetadataExtTransaction
+
+type BlockMetadataExtVariant uint32
+
+const (
+	BlockMetadataExtVariantV0 BlockMetadataExtVariant = 0
+	BlockMetadataExtVariantV1 BlockMetadataExtVariant = 1 // Adds on-chain randomness
+)
+
+// BlockMetadataExtTransaction is the extended BlockMetadataTransaction, which in V1 carries the randomness seed of the
+// block if randomness is enabled
+// Implements TransactionImpl, bcs.Struct
+type BlockMetadataExtTransaction struct {
+	Variant BlockMetadataExtVariant
+	BlockMetadataTransaction
+	Randomness *Randomness
+}
+
+// Randomness is the per-block randomness seed
+type Randomness struct {
+	Epoch      uint64
+	Round      uint64
+	Randomness []byte
+}
+
+func (txn *BlockMetadataExtTransaction) TransactionType() TransactionVariant {
+	return TransactionVariantBlockMetadataExt
+}
+
+//region BlockMetadataExtTransaction bcs.Struct
+
+func (txn *BlockMetadataExtTransaction) MarshalBCS(ser *bcs.Serializer) {
+	ser.Uleb128(uint32(txn.Variant))
+	switch txn.Variant {
+	case BlockMetadataExtVariantV0:
+		ser.Struct(&txn.BlockMetadataTransaction)
+	case BlockMetadataExtVariantV1:
+		ser.Struct(&txn.BlockMetadataTransaction)
+		if txn.Randomness == nil {
+			ser.Bool(false)
+		} else {
+			ser.Bool(true)
+			ser.U64(txn.Randomness.Epoch)
+			ser.U64(txn.Randomness.Round)
+			ser.WriteBytes(txn.Randomness.Randomness)
+		}
+	default:
+		ser.SetError(fmt.Errorf("unknown BlockMetadataExt variant %d", txn.Variant))
+	}
+}
+
+func (txn *BlockMetadataExtTransaction) UnmarshalBCS(des *bcs.Deserializer) {
+	txn.Variant = BlockMetadataExtVariant(des.Uleb128())
+	if des.Error() != nil {
+		return
+	}
+	switch txn.Variant {
+	case BlockMetadataExtVariantV0:
+		des.Struct(&txn.BlockMetadataTransaction)
+	case BlockMetadataExtVariantV1:
+		des.Struct(&txn.BlockMetadataTransaction)
+		if des.Bool() {
+			txn.Randomness = &Randomness{
+				Epoch:      des.U64(),
+				Round:      des.U64(),
+				Randomness: des.ReadBytes(),
+			}
+		}
+	default:
+		des.SetError(fmt.Errorf("unknown BlockMetadataExt variant %d", txn.Variant))
+	}
+}
+
+//endregion
+//endregion
+
+//region StateCheckpointTransaction
+
+// StateCheckpointTransaction ends a block that has no BlockEpilogueTransaction, Hash is the id of the block
+// Implements TransactionImpl, bcs.Struct
+type StateCheckpointTransaction struct {
+	Hash HashValue
+}
+
+func (txn *StateCheckpointTransaction) TransactionType() TransactionVariant {
+	return TransactionVariantStateCheckpoint
+}
+
+//region StateCheckpointTransaction bcs.Struct
+
+func (txn *StateCheckpointTransaction) MarshalBCS(ser *bcs.Serializer) {
+	ser.Struct(&txn.Hash)
+}
+
+func (txn *StateCheckpointTransaction) UnmarshalBCS(des *bcs.Deserializer) {
+	des.Struct(&txn.Hash)
+}
+
+//endregion
+//endregion
+
+//region ValidatorTransaction
+
+type ValidatorTransactionVariant uint32
+
+const (
+	ValidatorTransactionVariantDKGResult         ValidatorTransactionVariant = 0
+	ValidatorTransactionVariantObservedJWKUpdate ValidatorTransactionVariant = 1
+)
+
+// ValidatorTransaction is a transaction proposed by the validators themselves.  Which fields are set depends on the
+// Variant:
+//
+//   - ValidatorTransactionVariantDKGResult sets DKGResult, the result of the distributed key generation for randomness
+//   - ValidatorTransactionVariantObservedJWKUpdate sets JWKUpdate, the JSON web keys of an OIDC provider for keyless
+//
+// Implements TransactionImpl, bcs.Struct
+type ValidatorTransaction struct {
+	Variant   ValidatorTransactionVariant
+	DKGResult *DKGTranscript
+	JWKUpdate *QuorumCertifiedJWKUpdate
+}
+
+// DKGTranscript is the transcript of a distributed key generation for an epoch
+type DKGTranscript struct {
+	Epoch           uint64
+	Author          AccountAddress
+	TranscriptBytes []byte
+}
+
+// QuorumCertifiedJWKUpdate is an update to the JSON web keys of an OIDC provider, signed by a quorum of validators
+type QuorumCertifiedJWKUpdate struct {
+	Issuer           []byte
+	Version          uint64
+	JWKs             []JWK
+	ValidatorBitmask []byte
+	Signature        []byte // Aggregate BLS12-381 signature, nil if not present
+}
+
+// JWK is a JSON web key, Data is the BCS encoded Move struct of type TypeName
+type JWK struct {
+	TypeName string
+	Data     []byte
+}
+
+func (txn *ValidatorTransaction) TransactionType() TransactionVariant {
+	return TransactionVariantValidator
+}
+
+//region ValidatorTransaction bcs.Struct
+
+func (txn *ValidatorTransaction) MarshalBCS(ser *bcs.Serializer) {
+	ser.Uleb128(uint32(txn.Variant))
+	switch txn.Variant {
+	case ValidatorTransactionVariantDKGResult:
+		if txn.DKGResult == nil {
+			ser.SetError(fmt.Errorf("nil DKG result"))
+			return
+		}
+		ser.U64(txn.DKGResult.Epoch)
+		ser.Struct(&txn.DKGResult.Author)
+		ser.WriteBytes(txn.DKGResult.TranscriptBytes)
+	case ValidatorTransactionVariantObservedJWKUpdate:
+		update := txn.JWKUpdate
+		if update == nil {
+			ser.SetError(fmt.Errorf("nil JWK update"))
+			return
+		}
+		ser.WriteBytes(update.Issuer)
+		ser.U64(update.Version)
+		bcs.SerializeSequenceWithFunction(update.JWKs, ser, func(ser *bcs.Serializer, jwk JWK) {
+			ser.WriteString(jwk.TypeName)
+			ser.WriteBytes(jwk.Data)
+		})
+		ser.WriteBytes(update.ValidatorBitmask)
+		if update.Signature == nil {
+			ser.Bool(false)
+		} else {
+			ser.Bool(true)
+			ser.WriteBytes(update.Signature)
+		}
+	default:
+		ser.SetError(fmt.Errorf("unknown ValidatorTransaction variant %d", txn.Variant))
+	}
+}
+
+func (txn *ValidatorTransaction) UnmarshalBCS(des *bcs.Deserializer) {
+	txn.Variant = ValidatorTransactionVariant(des.Uleb128())
+	if des.Error() != nil {
+		return
+	}
+	switch txn.Variant {
+	case ValidatorTransactionVariantDKGResult:
+		txn.DKGResult = &DKGTranscript{}
+		txn.DKGResult.Epoch = des.U64()
+		des.Struct(&txn.DKGResult.Author)
+		txn.DKGResult.TranscriptBytes = des.ReadBytes()
+	case ValidatorTransactionVariantObservedJWKUpdate:
+		update := &QuorumCertifiedJWKUpdate{}
+		update.Issuer = des.ReadBytes()
+		update.Version = des.U64()
+		update.JWKs = bcs.DeserializeSequenceWithFunction(des, func(des *bcs.Deserializer, jwk *JWK) {
+			jwk.TypeName = des.ReadString()
+			jwk.Data = des.ReadBytes()
+		})
+		update.ValidatorBitmask = des.ReadBytes()
+		if des.Bool() {
+			update.Signature = des.ReadBytes()
+		}
+		txn.JWKUpdate = update
+	default:
+		des.SetError(fmt.Errorf("unknown ValidatorTransaction variant %d", txn.Variant))
+	}
+}
+
+//endregion
+//endregion
+
+//region BlockEpilogueTransaction
+
+type BlockEpilogueVariant uint32
+
+const (
+	BlockEpilogueVariantV0 BlockEpilogueVariant = 0
+	BlockEpilogueVariantV1 BlockEpilogueVariant = 1 // Adds the fee distribution
+)
+
+// BlockEpilogueTransaction ends a block, recording why the block ended
+// Implements TransactionImpl, bcs.Struct
+type BlockEpilogueTransaction struct {
+	Variant         BlockEpilogueVariant
+	BlockId         HashValue
+	BlockEndInfo    BlockEndInfo
+	FeeDistribution map[uint64]uint64 // Fees by validator index, V1 only
+}
+
+// BlockEndInfo records the limits a block reached
+type BlockEndInfo struct {
+	BlockGasLimitReached        bool
+	BlockOutputLimitReached     bool
+	BlockEffectiveBlockGasUnits uint64
+	BlockApproxOutputSize       uint64
+}
+
+// The only version of BlockEndInfo
+const blockEndInfoVariantV0 uint32 = 0
+
+func (txn *BlockEpilogueTransaction) TransactionType() TransactionVariant {
+	return TransactionVariantBlockEpilogue
+}
+
+//region BlockEpilogueTransaction bcs.Struct
+
+func (txn *BlockEpilogueTransaction) MarshalBCS(ser *bcs.Serializer) {
+	ser.Uleb128(uint32(txn.Variant))
+	if txn.Variant != BlockEpilogueVariantV0 && txn.Variant != BlockEpilogueVariantV1 {
+		ser.SetError(fmt.Errorf("unknown BlockEpilogue variant %d", txn.Variant))
+		return
+	}
+	ser.Struct(&txn.BlockId)
+	ser.Uleb128(blockEndInfoVariantV0)
+	ser.Bool(txn.BlockEndInfo.BlockGasLimitReached)
+	ser.Bool(txn.BlockEndInfo.BlockOutputLimitReached)
+	ser.U64(txn.BlockEndInfo.BlockEffectiveBlockGasUnits)
+	ser.U64(txn.BlockEndInfo.BlockApproxOutputSize)
+	if txn.Variant == BlockEpilogueVariantV1 {
+		// The fee distribution is an enum with a single V0 variant, of a map ordered by key
+		ser.Uleb128(0)
+		validators := make([]uint64, 0, len(txn.FeeDistribution))
+		for validator := range txn.FeeDistribution {
+			validators = append(validators, validator)
+		}
+		slices.Sort(validators)
+		bcs.SerializeSequenceWithFunction(validators, ser, func(ser *bcs.Serializer, validator uint64) {
+			ser.U64(validator)
+			ser.U64(txn.FeeDistribution[validator])
+		})
+	}
+}
+
+func (txn *BlockEpilogueTransaction) UnmarshalBCS(des *bcs.Deserializer) {
+	txn.Variant = BlockEpilogueVariant(des.Uleb128())
+	if des.Error() != nil {
+		return
+	}
+	if txn.Variant != BlockEpilogueVariantV0 && txn.Variant != BlockEpilogueVariantV1 {
+		des.SetError(fmt.Errorf("unknown BlockEpilogue variant %d", txn.Variant))
+		return
+	}
+	des.Struct(&txn.BlockId)
+	if variant := des.Uleb128(); variant != blockEndInfoVariantV0 {
+		des.SetError(fmt.Errorf("unknown BlockEndInfo variant %d", variant))
+		return
+	}
+	txn.BlockEndInfo = BlockEndInfo{
+		BlockGasLimitReached:        des.Bool(),
+		BlockOutputLimitReached:     des.Bool(),
+		BlockEffectiveBlockGasUnits: des.U64(),
+		BlockApproxOutputSize:       des.U64(),
+	}
+	if txn.Variant == BlockEpilogueVariantV1 {
+		if variant := des.Uleb128(); variant != 0 {
+			des.SetError(fmt.Errorf("unknown FeeDistribution variant %d", variant))
+			return
+		}
+		length := des.Uleb128()
+		txn.FeeDistribution = make(map[uint64]uint64, length)
+		for i := uint32(0); i < length && des.Error() == nil; i++ {
+			validator := des.U64()
+			txn.FeeDistribution[validator] = des.U64()
+		}
+	}
+}
+
+//endregion
+//endregion
+
+//region TransactionOnChainData
+
+// TransactionOnChainData is a committed transaction with everything it changed, as returned by the BCS transaction
+// endpoints, e.g. [NodeClient.TransactionByVersionBCS]
+// Implements bcs.Struct
+type TransactionOnChainData struct {
+	Version             uint64
+	Transaction         Transaction
+	Info                TransactionInfo
+	Events              []ContractEvent
+	AccumulatorRootHash HashValue
+	Changes             WriteSet
+}
+
+// TransactionOutput is the effect of executing a transaction
+type TransactionOutput struct {
+	WriteSet WriteSet
+	Events   []ContractEvent
+	GasUsed  uint64
+	Status   ExecutionStatus
+}
+
+// Output returns the effect of executing the transaction
+func (data *TransactionOnChainData) Output() *TransactionOutput {
+	return &TransactionOutput{
+		WriteSet: data.Changes,
+		Events:   data.Events,
+		GasUsed:  data.Info.GasUsed,
+		Status:   data.Info.Status,
+	}
+}
+
+//region TransactionOnChainData bcs.Struct
+
+func (data *TransactionOnChainData) MarshalBCS(ser *bcs.Serializer) {
+	ser.U64(data.Version)
+	ser.Struct(&data.Transaction)
+	ser.Struct(&data.Info)
+	bcs.SerializeSequence(data.Events, ser)
+	ser.Struct(&data.AccumulatorRootHash)
+	ser.Struct(&data.Changes)
+}
+
+func (data *TransactionOnChainData) UnmarshalBCS(des *bcs.Deserializer) {
+	data.Version = des.U64()
+	des.Struct(&data.Transaction)
+	des.Struct(&data.Info)
+	data.Events = bcs.DeserializeSequence[ContractEvent](des)
+	des.Struct(&data.AccumulatorRootHash)
+	des.Struct(&data.Changes)
+}
+
+//endregion
+
+func marshalTransactionOnChainDataList(ser *bcs.Serializer, transactions []*TransactionOnChainData) {
+	bcs.SerializeSequenceWithFunction(transactions, ser, func(ser *bcs.Serializer, data *TransactionOnChainData) {
+		ser.Struct(data)
+	})
+}
+
+func unmarshalTransactionOnChainDataList(des *bcs.Deserializer) []*TransactionOnChainData {
+	return bcs.DeserializeSequenceWithFunction(des, func(des *bcs.Deserializer, data **TransactionOnChainData) {
+		*data = &TransactionOnChainData{}
+		des.Struct(*data)
+	})
+}
+
+// transactionOnChainDataList is the BCS response of a list of transactions
+type transactionOnChainDataList struct {
+	Transactions []*TransactionOnChainData
+}
+
+func (list *transactionOnChainDataList) MarshalBCS(ser *bcs.Serializer) {
+	marshalTransactionOnChainDataList(ser, list.Transactions)
+}
+
+func (list *transactionOnChainDataList) UnmarshalBCS(des *bcs.Deserializer) {
+	list.Transactions = unmarshalTransactionOnChainDataList(des)
+}
+
+// The response of a single transaction is an enum of a committed or a pending transaction
+const (
+	transactionDataVariantOnChain uint32 = 0
+	transactionDataVariantPending uint32 = 1
+)
+
+// transactionData is the BCS response of a single transaction, only committed transactions are accepted
+type transactionData struct {
+	TransactionOnChainData
+}
+
+func (data *transactionData) MarshalBCS(ser *bcs.Serializer) {
+	ser.Uleb128(transactionDataVariantOnChain)
+	ser.Struct(&data.TransactionOnChainData)
+}
+
+func (data *transactionData) UnmarshalBCS(des *bcs.Deserializer) {
+	switch variant := des.Uleb128(); variant {
+	case transactionDataVariantOnChain:
+		des.Struct(&data.TransactionOnChainData)
+	case transactionDataVariantPending:
+		des.SetError(fmt.Errorf("transaction is pending"))
+	default:
+		des.SetError(fmt.Errorf("unknown TransactionData variant %d", variant))
+	}
+}
+
+//endregion
+
+//region OnChainBlock
+
+// OnChainBlock is a block as returned by the BCS block endpoints, e.g. [NodeClient.BlockByHeightBCS].  It is the BCS
+// counterpart of [api.Block].
+// Implements bcs.Struct
+type OnChainBlock struct {
+	BlockHeight    uint64
+	BlockHash      HashValue
+	BlockTimestamp uint64 // In microseconds
+	FirstVersion   uint64
+	LastVersion    uint64
+	Transactions   []*TransactionOnChainData // nil if not requested
+}
+
+//region OnChainBlock bcs.Struct
+
+func (block *OnChainBlock) MarshalBCS(ser *bcs.Serializer) {
+	ser.U64(block.BlockHeight)
+	ser.Struct(&block.BlockHash)
+	ser.U64(block.BlockTimestamp)
+	ser.U64(block.FirstVersion)
+	ser.U64(block.LastVersion)
+	if block.Transactions == nil {
+		ser.Bool(false)
+	} else {
+		ser.Bool(true)
+		marshalTransactionOnChainDataList(ser, block.Transactions)
+	}
+}
+
+func (block *OnChainBlock) UnmarshalBCS(des *bcs.Deserializer) {
+	block.BlockHeight = des.U64()
+	des.Struct(&block.BlockHash)
+	block.BlockTimestamp = des.U64()
+	block.FirstVersion = des.U64()
+	block.LastVersion = des.U64()
+	if des.Bool() {
+		block.Transactions = unmarshalTransactionOnChainDataList(des)
+	}
+}
+
+//endregion
+//endregion
@@ -0,0 +1,229 @@
+package aptos
+
+import (
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func testHash(b byte) HashValue {
+	hash := make(HashValue, 32)
+	for i := range hash {
+		hash[i] = b
+	}
+	return hash
+}
+
+func testUserTransactionOnChainData(t *testing.T, version uint64) *TransactionOnChainData {
+	sender, err := NewEd25519Account()
+	assert.NoError(t, err)
+	rawTxn := &RawTransaction{
+		Sender:                     sender.Address,
+		SequenceNumber:             version,
+		Payload:                    TransactionPayload{Payload: &EntryFunction{Module: ModuleId{Address: AccountOne, Name: "aptos_account"}, Function: "transfer", ArgTypes: []TypeTag{}, Args: [][]byte{}}},
+		MaxGasAmount:               1000,
+		GasUnitPrice:               100,
+		ExpirationTimestampSeconds: 1714158778,
+		ChainId:                    4,
+	}
+	signedTxn, err := rawTxn.SignedTransaction(sender)
+	assert.NoError(t, err)
+
+	statusCode := uint64(4016)
+	return &TransactionOnChainData{
+		Version:     version,
+		Transaction: Transaction{Inner: signedTxn},
+		Info: TransactionInfo{
+			GasUsed: 9,
+			Status: ExecutionStatus{
+				Variant:    ExecutionStatusMiscellaneousError,
+				StatusCode: &statusCode,
+			},
+			TransactionHash:     testHash(1),
+			EventRootHash:       testHash(2),
+			StateChangeHash:     testHash(3),
+			StateCheckpointHash: testHash(4),
+		},
+		Events: []ContractEvent{
+			{
+				Variant:        ContractEventVariantV1,
+				CreationNumber: 2,
+				Address:        sender.Address,
+				SequenceNumber: 7,
+				Type:           TypeTag{Value: &U64Tag{}},
+				Data:           []byte{1, 2, 3},
+			},
+			{
+				Variant: ContractEventVariantV2,
+				Type:    TypeTag{Value: &AddressTag{}},
+				Data:    sender.Address[:],
+			},
+		},
+		AccumulatorRootHash: testHash(5),
+		Changes: WriteSet{Changes: []WriteSetChange{
+			{
+				StateKey: StateKey{Variant: StateKeyVariantAccessPath, Address: sender.Address, Path: []byte{1}},
+				WriteOp:  WriteOp{Variant: WriteOpVariantModification, Data: []byte{4, 5}},
+			},
+			{
+				StateKey: StateKey{Variant: StateKeyVariantTableItem, Handle: AccountOne, Key: []byte{6}},
+				WriteOp: WriteOp{
+					Variant:  WriteOpVariantCreationWithMetadata,
+					Data:     []byte{7},
+					Metadata: &StateValueMetadata{Variant: StateValueMetadataVariantV1, SlotDeposit: 40000, BytesDeposit: 400, CreationTimeUsecs: 1714158778000000},
+				},
+			},
+			{
+				StateKey: StateKey{Variant: StateKeyVariantRaw, Raw: []byte{8}},
+				WriteOp:  WriteOp{Variant: WriteOpVariantDeletion},
+			},
+		}},
+	}
+}
+
+func TestTransactionOnChainDataBCS(t *testing.T) {
+	blockMetadata := BlockMetadataTransaction{
+		Id:                       testHash(6),
+		Epoch:                    2,
+		Round:                    3,
+		Proposer:                 AccountOne,
+		PreviousBlockVotesBitvec: []byte{0xff},
+		FailedProposerIndices:    []uint32{1, 2},
+		TimestampUsecs:           1714158778000000,
+	}
+	transactions := []TransactionImpl{
+		&GenesisTransaction{Variant: WriteSetPayloadVariantDirect, ChangeSet: WriteSet{Changes: []WriteSetChange{}}, Events: []ContractEvent{}},
+		&blockMetadata,
+		&StateCheckpointTransaction{Hash: testHash(7)},
+		&ValidatorTransaction{Variant: ValidatorTransactionVariantDKGResult, DKGResult: &DKGTranscript{Epoch: 2, Author: AccountOne, TranscriptBytes: []byte{1}}},
+		&ValidatorTransaction{Variant: ValidatorTransactionVariantObservedJWKUpdate, JWKUpdate: &QuorumCertifiedJWKUpdate{
+			Issuer:           []byte("https://accounts.google.com"),
+			Version:          3,
+			JWKs:             []JWK{{TypeName: "0x1::jwks::RSA_JWK", Data: []byte{2}}},
+			ValidatorBitmask: []byte{0x80},
+			Signature:        []byte{3},
+		}},
+		&BlockMetadataExtTransaction{Variant: BlockMetadataExtVariantV1, BlockMetadataTransaction: blockMetadata, Randomness: &Randomness{Epoch: 2, Round: 3, Randomness: []byte{4}}},
+		&BlockEpilogueTransaction{Variant: BlockEpilogueVariantV1, BlockId: testHash(8), BlockEndInfo: BlockEndInfo{BlockGasLimitReached: true, BlockEffectiveBlockGasUnits: 500}, FeeDistribution: map[uint64]uint64{3: 30, 1: 10}},
+	}
+
+	userData := testUserTransactionOnChainData(t, 1)
+	for _, inner := range append([]TransactionImpl{userData.Transaction.Inner}, transactions...) {
+		data := *userData
+		data.Transaction = Transaction{Inner: inner}
+		data.Info.Status = ExecutionStatus{
+			Variant:   ExecutionStatusMoveAbort,
+			Location:  &ModuleId{Address: AccountOne, Name: "coin"},
+			Code:      65542,
+			AbortInfo: &AbortInfo{ReasonName: "EINSUFFICIENT_BALANCE", Description: "Not enough coins"},
+		}
+		data.Info.AuxiliaryInfoHash = testHash(9)
+
+		dataBytes, err := bcs.Serialize(&data)
+		assert.NoError(t, err)
+		decoded := &TransactionOnChainData{}
+		assert.NoError(t, bcs.Deserialize(decoded, dataBytes))
+		assert.Equal(t, inner.TransactionType(), decoded.Transaction.Type())
+		assert.Equal(t, data.Info, decoded.Info)
+		assert.Equal(t, data.Events, decoded.Events)
+		assert.Equal(t, data.Changes, decoded.Changes)
+
+		// Re-serializing is lossless
+		decodedBytes, err := bcs.Serialize(decoded)
+		assert.NoError(t, err)
+		assert.Equal(t, dataBytes, decodedBytes)
+	}
+
+	// The hash of a user transaction matches the hash of its SignedTransaction
+	signedTxn, err := userData.Transaction.UserTransaction()
+	assert.NoError(t, err)
+	expectedHash, err := signedTxn.Hash()
+	assert.NoError(t, err)
+	hash, err := userData.Transaction.Hash()
+	assert.NoError(t, err)
+	assert.Equal(t, expectedHash, hash)
+
+	_, err = (&Transaction{Inner: &StateCheckpointTransaction{Hash: testHash(7)}}).UserTransaction()
+	assert.Error(t, err)
+
+	output := userData.Output()
+	assert.Equal(t, uint64(9), output.GasUsed)
+	assert.False(t, output.Status.Success())
+	assert.True(t, output.WriteSet.Changes[2].WriteOp.IsDeletion())
+}
+
+func TestTransactionsBCS(t *testing.T) {
+	transactions := make([]*TransactionOnChainData, 5)
+	for i := range transactions {
+		transactions[i] = testUserTransactionOnChainData(t, uint64(10+i))
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/x-bcs", r.Header.Get("Accept"))
+		var body []byte
+		var err error
+		switch r.URL.Path {
+		case "/v1/transactions/by_version/12":
+			body, err = bcs.Serialize(&transactionData{*transactions[2]})
+		case "/v1/transactions/by_version/99":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Transaction not found by Transaction version(99)","error_code":"transaction_not_found"}`))
+			return
+		case "/v1/transactions":
+			start, _ := strconv.Atoi(r.URL.Query().Get("start"))
+			limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+			// Return at most 2 at a time, like a node with a small page size
+			end := min(start-10+min(limit, 2), len(transactions))
+			body, err = bcs.Serialize(&transactionOnChainDataList{transactions[start-10 : end]})
+		case "/v1/blocks/by_height/7":
+			assert.Equal(t, "true", r.URL.Query().Get("with_transactions"))
+			body, err = bcs.Serialize(&OnChainBlock{
+				BlockHeight:    7,
+				BlockHash:      testHash(1),
+				BlockTimestamp: 1714158778000000,
+				FirstVersion:   10,
+				LastVersion:    14,
+				Transactions:   transactions[:1],
+			})
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		assert.NoError(t, err)
+		_, _ = w.Write(body)
+	}))
+	defer server.Close()
+
+	client, err := NewNodeClient(server.URL+"/v1", 4)
+	assert.NoError(t, err)
+
+	data, err := client.TransactionByVersionBCS(12)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(12), data.Version)
+	signedTxn, err := data.Transaction.UserTransaction()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(12), signedTxn.Transaction.(*RawTransaction).SequenceNumber)
+	assert.NoError(t, signedTxn.Verify())
+
+	_, err = client.TransactionByVersionBCS(99)
+	assert.ErrorIs(t, err, ErrTransactionNotFound)
+
+	start := uint64(11)
+	limit := uint64(2)
+	list, err := client.TransactionsBCS(&start, &limit)
+	assert.NoError(t, err)
+	assert.Len(t, list, 2)
+	assert.Equal(t, uint64(11), list[0].Version)
+	assert.Equal(t, uint64(12), list[1].Version)
+
+	// The block response has only the first transaction, the rest are filled in
+	block, err := client.BlockByHeightBCS(7, true)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(7), block.BlockHeight)
+	assert.Len(t, block.Transactions, 5)
+	for i, txn := range block.Transactions {
+		assert.Equal(t, uint64(10+i), txn.Version)
+	}
+}
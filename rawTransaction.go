@@ -4,26 +4,18 @@ import (
 	"fmt"
 	"github.com/aptos-labs/aptos-go-sdk/bcs"
 	"github.com/aptos-labs/aptos-go-sdk/crypto"
-	"golang.org/x/crypto/sha3"
 )
 
 //region RawTransaction
 
-var rawTransactionPrehash []byte
-
 const rawTransactionPrehashStr = "APTOS::RawTransaction"
 
+// rawTransactionPrehash is computed up front, so that concurrent signing doesn't race to cache it
+var rawTransactionPrehash = Sha3256Hash([][]byte{[]byte(rawTransactionPrehashStr)})
+
 // RawTransactionPrehash Return the sha3-256 prehash for RawTransaction
 // Do not write to the []byte returned
 func RawTransactionPrehash() []byte {
-	// Cache the prehash
-	if rawTransactionPrehash == nil {
-		b32 := sha3.Sum256([]byte(rawTransactionPrehashStr))
-		out := make([]byte, len(b32))
-		copy(out, b32[:])
-		rawTransactionPrehash = out
-		return out
-	}
 	return rawTransactionPrehash
 }
 
@@ -151,22 +143,15 @@ func (txn *RawTransaction) Sign(signer crypto.Signer) (authenticator *crypto.Acc
 
 //region RawTransactionWithData
 
-var rawTransactionWithDataPrehash []byte
-
 const rawTransactionWithDataPrehashStr = "APTOS::RawTransactionWithData"
 
+// rawTransactionWithDataPrehash is computed up front, so that concurrent signing doesn't race to cache it
+var rawTransactionWithDataPrehash = Sha3256Hash([][]byte{[]byte(rawTransactionWithDataPrehashStr)})
+
 // RawTransactionWithDataPrehash Return the sha3-256 prehash for RawTransactionWithData
 // Do not write to the []byte returned
 func RawTransactionWithDataPrehash() []byte {
-	// Cache the prehash
-	if rawTransactionPrehash == nil {
-		b32 := sha3.Sum256([]byte(rawTransactionPrehashStr))
-		out := make([]byte, len(b32))
-		copy(out, b32[:])
-		rawTransactionPrehash = out
-		return out
-	}
-	return rawTransactionPrehash
+	return rawTransactionWithDataPrehash
 }
 
 type RawTransactionWithDataVariant uint32
@@ -196,8 +181,9 @@ func (txn *RawTransactionWithData) ToMultiAgentSignedTransaction(
 	}
 	multiAgent := txn.Inner.(*MultiAgentRawTransactionWithData)
 
+	// The signed transaction holds the plain raw transaction, the secondary signers are in the authenticator
 	return &SignedTransaction{
-		Transaction: txn,
+		Transaction: multiAgent.RawTxn,
 		Authenticator: &TransactionAuthenticator{
 			Variant: TransactionAuthenticatorMultiAgent,
 			Auth: &MultiAgentTransactionAuthenticator{
@@ -219,8 +205,12 @@ func (txn *RawTransactionWithData) ToFeePayerSignedTransaction(
 	if txn.Variant != MultiAgentWithFeePayerRawTransactionWithDataVariant {
 		return nil, false
 	}
+	feePayerTxn := txn.Inner.(*MultiAgentWithFeePayerRawTransactionWithData)
+
+	// The signed transaction holds the plain raw transaction, the fee payer and secondary signers are in the
+	// authenticator
 	return &SignedTransaction{
-		Transaction: txn,
+		Transaction: feePayerTxn.RawTxn,
 		Authenticator: &TransactionAuthenticator{
 			Variant: TransactionAuthenticatorFeePayer,
 			Auth: &FeePayerTransactionAuthenticator{
@@ -234,6 +224,18 @@ func (txn *RawTransactionWithData) ToFeePayerSignedTransaction(
 	}, true
 }
 
+// rawTransaction returns the inner raw transaction, which is what a [SignedTransaction] holds
+func (txn *RawTransactionWithData) rawTransaction() (*RawTransaction, error) {
+	switch inner := txn.Inner.(type) {
+	case *MultiAgentRawTransactionWithData:
+		return inner.RawTxn, nil
+	case *MultiAgentWithFeePayerRawTransactionWithData:
+		return inner.RawTxn, nil
+	default:
+		return nil, fmt.Errorf("unknown RawTransactionWithData type %T", txn.Inner)
+	}
+}
+
 //region RawTransactionWithData Signer
 
 func (txn *RawTransactionWithData) Sign(signer crypto.Signer) (authenticator *crypto.AccountAuthenticator, err error) {
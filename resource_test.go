@@ -2,9 +2,13 @@ package aptos
 
 import (
 	"encoding/base64"
+	"fmt"
 	"github.com/aptos-labs/aptos-go-sdk/bcs"
 	"github.com/stretchr/testify/assert"
 	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
 	"strings"
 	"testing"
 )
@@ -31,3 +35,60 @@ func TestMoveResourceBCS(t *testing.T) {
 	assert.Equal(t, "0x1::coin::CoinStore<0x1::aptos_coin::AptosCoin>", resources[0].Tag.String())
 	assert.Equal(t, "0x1::account::Account", resources[1].Tag.String())
 }
+
+func TestAccountResourcesIterator(t *testing.T) {
+	// 5 resources, served 2 at a time with the cursor being the index of the next resource
+	records := make([]AccountResourceRecord, 5)
+	for i := range records {
+		records[i] = AccountResourceRecord{
+			Tag:  StructTag{Address: AccountOne, Module: "resources", Name: fmt.Sprintf("R%d", i), TypeParams: []TypeTag{}},
+			Data: []byte{byte(i)},
+		}
+	}
+	pinnedVersions := make([]string, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/accounts/"+AccountOne.String()+"/resources", r.URL.Path)
+		pinnedVersions = append(pinnedVersions, r.URL.Query().Get("ledger_version"))
+		start, _ := strconv.Atoi(r.URL.Query().Get("start"))
+		end := min(start+2, len(records))
+		if end < len(records) {
+			w.Header().Set(HeaderAptosCursor, strconv.Itoa(end))
+		}
+		w.Header().Set(HeaderAptosLedgerVersion, "1000")
+		page := records[start:end]
+		if r.Header.Get("Accept") == "application/x-bcs" {
+			ser := bcs.Serializer{}
+			bcs.SerializeSequence(page, &ser)
+			_, _ = w.Write(ser.ToBytes())
+			return
+		}
+		resources := make([]string, len(page))
+		for i, record := range page {
+			resources[i] = fmt.Sprintf(`{"type":"%s","data":{"value":"%d"}}`, record.Tag.String(), record.Data[0])
+		}
+		_, _ = w.Write([]byte("[" + strings.Join(resources, ",") + "]"))
+	}))
+	defer server.Close()
+
+	client, err := NewNodeClient(server.URL+"/v1", 4)
+	assert.NoError(t, err)
+
+	page, cursor, err := client.AccountResourcesPage(AccountOne, "", nil)
+	assert.NoError(t, err)
+	assert.Len(t, page, 2)
+	assert.Equal(t, "2", cursor)
+
+	resources, err := client.AccountResourcesIterator(AccountOne, 2).Collect()
+	assert.NoError(t, err)
+	assert.Len(t, resources, 5)
+	assert.Equal(t, "0x1::resources::R4", resources[4].Type)
+	assert.Equal(t, "4", resources[4].Data["value"])
+	// Later pages are pinned to the ledger version of the first
+	assert.Equal(t, []string{"", "", "1000", "1000"}, pinnedVersions)
+
+	pinnedVersions = pinnedVersions[:0]
+	bcsResources, err := client.AccountResourcesBCSIterator(AccountOne, 2, 7).Collect()
+	assert.NoError(t, err)
+	assert.Equal(t, records, bcsResources)
+	assert.Equal(t, []string{"7", "7", "7"}, pinnedVersions)
+}
@@ -0,0 +1,230 @@
+package aptos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy configures how the [NodeClient] retries transient failures, such as rate limiting (429), gateway errors
+// (502, 503, 504), timeouts, and refused or reset connections.  Failures that repeating can't fix, such as certificate
+// errors, unknown hosts, and unsupported URL schemes, are not retried.
+//
+// Only requests that are safe to repeat are retried: GETs, view functions, simulations, and transaction submission,
+// where a retried submission that is rejected because the transaction already reached the node is treated as success.
+//
+//	client.SetRetryPolicy(&RetryPolicy{
+//		MaxRetries:     5,
+//		InitialBackoff: 500 * time.Millisecond,
+//		MaxBackoff:     30 * time.Second,
+//		Multiplier:     2,
+//		Jitter:         0.2,
+//	})
+type RetryPolicy struct {
+	MaxRetries           int           // Number of retries after the first attempt, 0 disables retries
+	InitialBackoff       time.Duration // Backoff before the first retry
+	MaxBackoff           time.Duration // Upper bound on a computed backoff, Retry-After may exceed it up to MaxRetryAfter
+	Multiplier           float64       // Growth of the backoff per retry, values below 1 are treated as 1
+	Jitter               float64       // Fraction of the backoff randomized in either direction, between 0 and 1
+	RetryableStatusCodes []int         // HTTP status codes to retry, defaults to 429, 502, 503, and 504 if empty
+	MaxRetryAfter        time.Duration // Longest Retry-After to wait for, a longer one ends the retries, 1 minute if 0
+}
+
+// DefaultMaxRetryAfter is the longest Retry-After header honored when [RetryPolicy.MaxRetryAfter] is 0
+const DefaultMaxRetryAfter = time.Minute
+
+// DefaultRetryableStatusCodes are the HTTP status codes retried when [RetryPolicy.RetryableStatusCodes] is empty
+var DefaultRetryableStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// DefaultRetryPolicy is the retry policy used by a new [NodeClient]
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxRetries:     3,
+		InitialBackoff: 200 * time.Millisecond,
+		MaxBackoff:     10 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+	}
+}
+
+// shouldRetry determines if the outcome of an attempt is a transient failure
+func (policy *RetryPolicy) shouldRetry(response *http.Response, err error) bool {
+	if err != nil {
+		// Cancellation and deadlines are the caller's choice, not a transient failure
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return false
+		}
+		return isTransientNetworkError(err)
+	}
+	codes := policy.RetryableStatusCodes
+	if len(codes) == 0 {
+		codes = DefaultRetryableStatusCodes
+	}
+	return slices.Contains(codes, response.StatusCode)
+}
+
+// isTransientNetworkError tells whether a request failed in a way that may pass on retry: a timeout, a refused or reset
+// connection, or a connection closed partway through the response
+func isTransientNetworkError(err error) bool {
+	if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// retryAfterTooLong tells whether the response's Retry-After header asks to wait longer than the policy allows
+func (policy *RetryPolicy) retryAfterTooLong(response *http.Response) bool {
+	if response == nil {
+		return false
+	}
+	delay, ok := parseRetryAfter(response.Header.Get("Retry-After"), time.Now())
+	if !ok {
+		return false
+	}
+	maxRetryAfter := policy.MaxRetryAfter
+	if maxRetryAfter <= 0 {
+		maxRetryAfter = DefaultMaxRetryAfter
+	}
+	return delay > maxRetryAfter
+}
+
+// backoff returns the delay before the given retry (starting at 0), honoring the response's Retry-After header if present
+func (policy *RetryPolicy) backoff(retry int, response *http.Response) time.Duration {
+	if response != nil {
+		if delay, ok := parseRetryAfter(response.Header.Get("Retry-After"), time.Now()); ok {
+			return delay
+		}
+	}
+
+	multiplier := math.Max(policy.Multiplier, 1)
+	delay := float64(policy.InitialBackoff) * math.Pow(multiplier, float64(retry))
+	if policy.MaxBackoff > 0 {
+		delay = math.Min(delay, float64(policy.MaxBackoff))
+	}
+	if policy.Jitter > 0 {
+		jitter := math.Min(policy.Jitter, 1)
+		delay += delay * jitter * (2*rand.Float64() - 1)
+	}
+	return time.Duration(delay)
+}
+
+// parseRetryAfter parses a Retry-After header, which is either a number of seconds or an HTTP date
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.ParseUint(value, 10, 32); err == nil {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+// SetRetryPolicy replaces the retry policy, nil disables retries
+//
+//	client.SetRetryPolicy(nil)
+func (rc *NodeClient) SetRetryPolicy(policy *RetryPolicy) {
+	rc.retryPolicy = policy
+}
+
+// do sends the request built by newRequest, retrying transient failures according to the retry policy if retry is set.
+//
+// With a [NodePool], each attempt goes to a node picked by the pool, and retries fail over to other nodes before backing
+// off.  Reads pinned with [WithMinLedgerVersion] are retried while the node is behind.  newRequest is called once per
+// attempt so that the request body can be replayed.  It returns the final response and the number of attempts made.
+func (rc *NodeClient) do(ctx context.Context, newRequest func() (*http.Request, error), retry bool) (response *http.Response, attempts int, err error) {
+	policy := rc.retryPolicy
+	maxAttempts := 1
+	if retry && policy != nil {
+		maxAttempts += policy.MaxRetries
+	}
+	if retry && rc.pool != nil {
+		maxAttempts = max(maxAttempts, len(rc.pool.nodes))
+	}
+	minVersion, pinned := minLedgerVersion(ctx)
+	pinned = pinned && retry
+	// Without a retry policy, failover still needs to classify failures and back off once every node is tried
+	failoverPolicy := policy
+	if failoverPolicy == nil {
+		failoverPolicy = DefaultRetryPolicy()
+	}
+
+	var tried []*poolNode
+	for {
+		var req *http.Request
+		req, err = newRequest()
+		if err != nil {
+			return nil, attempts, err
+		}
+		var node *poolNode
+		if rc.pool != nil {
+			node = rc.pool.pick(tried, minVersion)
+			if rc.pool.route(req, rc.baseUrl, node) {
+				tried = append(tried, node)
+			} else {
+				node = nil
+			}
+		}
+
+		start := time.Now()
+		response, err = rc.send(req)
+		attempts++
+
+		transient := failoverPolicy.shouldRetry(response, err)
+		stale := false
+		if err == nil {
+			captureLedgerInfo(ctx, response)
+			ledgerVersion, ok := responseLedgerVersion(response)
+			stale = pinned && ok && ledgerVersion < minVersion
+			if node != nil {
+				rc.pool.recordResponse(node, time.Since(start), ledgerVersion)
+			}
+		}
+		if node != nil && transient {
+			rc.pool.recordFailure(node)
+		}
+
+		// A node asking to wait too long is given up on, unless there is another node to fail over to
+		failover := rc.pool != nil && len(tried) < len(rc.pool.nodes)
+		waitTooLong := !failover && failoverPolicy.retryAfterTooLong(response)
+		if !retry || attempts >= maxAttempts || !(transient || stale) || waitTooLong {
+			if stale {
+				ledgerVersion, _ := responseLedgerVersion(response)
+				_, _ = io.Copy(io.Discard, response.Body)
+				_ = response.Body.Close()
+				return nil, attempts, fmt.Errorf("%w: node at ledger version %d, need %d", ErrLedgerVersionNotReached, ledgerVersion, minVersion)
+			}
+			return response, attempts, err
+		}
+		if response != nil {
+			// Drain the body so the connection can be reused
+			_, _ = io.Copy(io.Discard, response.Body)
+			_ = response.Body.Close()
+		}
+
+		// Fail over to an untried node immediately, otherwise back off
+		if failover {
+			continue
+		}
+		if sleepErr := sleepCtx(ctx, failoverPolicy.backoff(attempts-1, response)); sleepErr != nil {
+			return nil, attempts, sleepErr
+		}
+	}
+}
@@ -0,0 +1,242 @@
+package aptos
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func testRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxRetries:     3,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     5 * time.Millisecond,
+		Multiplier:     2,
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	delay, ok := parseRetryAfter("3", now)
+	assert.True(t, ok)
+	assert.Equal(t, 3*time.Second, delay)
+
+	delay, ok = parseRetryAfter(now.Add(10*time.Second).Format(http.TimeFormat), now)
+	assert.True(t, ok)
+	assert.Equal(t, 10*time.Second, delay)
+
+	delay, ok = parseRetryAfter(now.Add(-10*time.Second).Format(http.TimeFormat), now)
+	assert.True(t, ok)
+	assert.Equal(t, time.Duration(0), delay)
+
+	_, ok = parseRetryAfter("", now)
+	assert.False(t, ok)
+	_, ok = parseRetryAfter("soon", now)
+	assert.False(t, ok)
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := &RetryPolicy{
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     time.Second,
+		Multiplier:     2,
+	}
+	assert.Equal(t, 100*time.Millisecond, policy.backoff(0, nil))
+	assert.Equal(t, 400*time.Millisecond, policy.backoff(2, nil))
+	assert.Equal(t, time.Second, policy.backoff(10, nil))
+
+	// Retry-After takes precedence over the computed backoff
+	response := &http.Response{Header: http.Header{}}
+	response.Header.Set("Retry-After", "2")
+	assert.Equal(t, 2*time.Second, policy.backoff(0, response))
+
+	policy.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		delay := policy.backoff(0, nil)
+		assert.GreaterOrEqual(t, delay, 50*time.Millisecond)
+		assert.LessOrEqual(t, delay, 150*time.Millisecond)
+	}
+}
+
+func TestRetryPolicyShouldRetry(t *testing.T) {
+	policy := testRetryPolicy()
+	urlError := func(err error) error {
+		return &url.Error{Op: "Get", URL: "https://fullnode.testnet.aptoslabs.com/v1", Err: err}
+	}
+
+	// Transient failures
+	assert.True(t, policy.shouldRetry(nil, urlError(&net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)})))
+	assert.True(t, policy.shouldRetry(nil, urlError(&net.OpError{Op: "read", Net: "tcp", Err: os.NewSyscallError("read", syscall.ECONNRESET)})))
+	assert.True(t, policy.shouldRetry(nil, urlError(&net.OpError{Op: "read", Net: "tcp", Err: os.ErrDeadlineExceeded})))
+	assert.True(t, policy.shouldRetry(nil, urlError(io.ErrUnexpectedEOF)))
+	assert.True(t, policy.shouldRetry(&http.Response{StatusCode: http.StatusServiceUnavailable}, nil))
+
+	// Failures that a retry can't fix
+	assert.False(t, policy.shouldRetry(nil, urlError(x509.UnknownAuthorityError{})))
+	assert.False(t, policy.shouldRetry(nil, urlError(x509.HostnameError{Host: "fullnode.testnet.aptoslabs.com"})))
+	assert.False(t, policy.shouldRetry(nil, urlError(&net.OpError{Op: "dial", Net: "tcp", Err: &net.DNSError{Err: "no such host", Name: "fullnode.testnet.aptoslabs.com", IsNotFound: true}})))
+	assert.False(t, policy.shouldRetry(nil, urlError(errors.New(`unsupported protocol scheme "ftp"`))))
+	assert.False(t, policy.shouldRetry(nil, urlError(context.Canceled)))
+	assert.False(t, policy.shouldRetry(nil, fmt.Errorf("request failed: %w", context.DeadlineExceeded)))
+	assert.False(t, policy.shouldRetry(&http.Response{StatusCode: http.StatusBadRequest}, nil))
+}
+
+func TestNodeClientRetryCertificateError(t *testing.T) {
+	var connections atomic.Int32
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			connections.Add(1)
+		}
+	}
+	server.StartTLS()
+	defer server.Close()
+
+	// The client doesn't trust the test server's certificate
+	client, err := NewNodeClient(server.URL+"/v1", 4)
+	assert.NoError(t, err)
+	client.SetRetryPolicy(testRetryPolicy())
+	_, err = client.Info()
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), connections.Load())
+}
+
+func TestNodeClientRetry(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) <= 2 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte(`{"chain_id":4,"epoch":"1","ledger_version":"10","oldest_ledger_version":"0","ledger_timestamp":"1","node_role":"full_node","oldest_block_height":"0","block_height":"5","git_hash":"abc"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewNodeClient(server.URL+"/v1", 4)
+	assert.NoError(t, err)
+	client.SetRetryPolicy(testRetryPolicy())
+
+	info, err := client.Info()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(10), info.LedgerVersion())
+	assert.Equal(t, int32(3), requests.Load())
+
+	// Give up after the maximum number of retries
+	requests.Store(-10)
+	_, err = client.Info()
+	assert.Error(t, err)
+	assert.Equal(t, int32(-6), requests.Load())
+
+	// No retries when disabled
+	client.SetRetryPolicy(nil)
+	requests.Store(0)
+	_, err = client.Info()
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), requests.Load())
+}
+
+func TestNodeClientRetryAfterTooLong(t *testing.T) {
+	var requests atomic.Int32
+	var retryAfter atomic.Value
+	retryAfter.Store("86400")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Retry-After", retryAfter.Load().(string))
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client, err := NewNodeClient(server.URL+"/v1", 4)
+	assert.NoError(t, err)
+	client.SetRetryPolicy(testRetryPolicy())
+
+	// A day is longer than the default limit, so the rate limit is returned rather than waited out
+	start := time.Now()
+	_, err = client.Info()
+	var httpErr *HttpError
+	assert.True(t, errors.As(err, &httpErr))
+	assert.Equal(t, http.StatusTooManyRequests, httpErr.StatusCode)
+	assert.Equal(t, int32(1), requests.Load())
+	assert.Less(t, time.Since(start), 5*time.Second)
+
+	// The limit is configurable
+	policy := testRetryPolicy()
+	policy.MaxRetryAfter = 500 * time.Millisecond
+	client.SetRetryPolicy(policy)
+	retryAfter.Store("1")
+	requests.Store(0)
+	_, err = client.Info()
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), requests.Load())
+	retryAfter.Store("0")
+	requests.Store(0)
+	_, err = client.Info()
+	assert.Error(t, err)
+	assert.Equal(t, int32(4), requests.Load())
+}
+
+func TestSubmitTransactionRetryAlreadySubmitted(t *testing.T) {
+	sender, err := NewEd25519Account()
+	assert.NoError(t, err)
+	rawTxn := &RawTransaction{
+		Sender:                     sender.Address,
+		SequenceNumber:             1,
+		Payload:                    TransactionPayload{Payload: &EntryFunction{Module: ModuleId{Address: AccountOne, Name: "aptos_account"}, Function: "transfer"}},
+		MaxGasAmount:               1000,
+		GasUnitPrice:               100,
+		ExpirationTimestampSeconds: 1714158778,
+		ChainId:                    4,
+	}
+	signedTxn, err := rawTxn.SignedTransaction(sender)
+	assert.NoError(t, err)
+	hash, err := signedTxn.Hash()
+	assert.NoError(t, err)
+
+	var submissions atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/transactions":
+			if submissions.Add(1) == 1 {
+				// The first attempt reached mempool, but the response was lost
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"message":"Transaction already in mempool","error_code":"invalid_transaction_update","vm_error_code":null}`))
+		case "/v1/transactions/by_hash/" + hash:
+			_, _ = w.Write([]byte(`{"type":"pending_transaction","hash":"` + hash + `","sender":"` + sender.Address.String() + `","sequence_number":"1","max_gas_amount":"1000","gas_unit_price":"100","expiration_timestamp_secs":"1714158778"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewNodeClient(server.URL+"/v1", 4)
+	assert.NoError(t, err)
+	client.SetRetryPolicy(testRetryPolicy())
+
+	response, err := client.SubmitTransaction(signedTxn)
+	assert.NoError(t, err)
+	assert.Equal(t, hash, response.Hash)
+	assert.Equal(t, uint64(1), response.SequenceNumber)
+	assert.Equal(t, int32(2), submissions.Load())
+
+	// A rejection on the first attempt is a real failure
+	submissions.Store(1)
+	_, err = client.SubmitTransaction(signedTxn)
+	assert.Error(t, err)
+}
@@ -0,0 +1,291 @@
+package aptos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// AccountClient is anything that can fetch account info, e.g. [Client] or [NodeClient]
+type AccountClient interface {
+	AccountCtx(ctx context.Context, address AccountAddress, ledgerVersion ...uint64) (info AccountInfo, err error)
+}
+
+// MaxInFlight is an option to NewAccountSequenceNumber, the most transactions that may be outstanding at once
+type MaxInFlight uint64
+
+const (
+	defaultMaxInFlight          = 100
+	defaultSequenceNumberPeriod = 100 * time.Millisecond
+	defaultSequenceNumberWait   = 30 * time.Second
+)
+
+// Move VM statuses for rejected sequence numbers, as the vm_error_code of an [AptosApiError]
+const (
+	vmStatusSequenceNumberTooOld = 3
+	vmStatusSequenceNumberTooNew = 4
+)
+
+// AccountSequenceNumber hands out sequence numbers for one sender locally, rather than fetching the sequence number
+// from chain for every transaction.  It is safe for concurrent use.
+//
+// At most [MaxInFlight] sequence numbers, 100 by default, are outstanding, i.e. handed out but not yet committed.  Once
+// that many are outstanding, [AccountSequenceNumber.Next] polls the chain every PollPeriod until some commit.  If none
+// commit within PollTimeout, 30 seconds by default, the outstanding transactions are assumed to have expired or been
+// dropped, and it starts over from the on-chain sequence number.
+//
+// Pass it to [NodeClient.BuildTransaction] in place of a [SequenceNumber].  When a submission is rejected for its
+// sequence number, call [AccountSequenceNumber.HandleError] to resync from chain.  When a transaction fails before
+// reaching chain, e.g. its simulation or submission fails for another reason, call [AccountSequenceNumber.Release] to
+// give its sequence number back, otherwise the transactions after it are stuck waiting on the gap.
+// [NodeClient.BuildSignAndSubmitTransaction] does both automatically.
+//
+//	seqNum, err := NewAccountSequenceNumber(client, sender.Address, MaxInFlight(50))
+//	for _, payload := range payloads {
+//		go func(payload TransactionPayload) {
+//			response, err := client.BuildSignAndSubmitTransaction(sender, payload, seqNum)
+//			// ...
+//		}(payload)
+//	}
+type AccountSequenceNumber struct {
+	client      AccountClient
+	address     AccountAddress
+	maxInFlight uint64
+	period      time.Duration
+	timeout     time.Duration
+
+	lock        chan struct{} // Held while reading or updating the fields below, can be abandoned on cancellation
+	initialized bool
+	next        uint64   // Next sequence number to hand out
+	committed   uint64   // Most recent on-chain sequence number, every number before it is committed
+	released    []uint64 // Sequence numbers below next given back with Release, handed out again first
+}
+
+// NewAccountSequenceNumber creates a sequence number manager for the sender, which fetches the on-chain sequence number
+// on first use.
+//
+// Accepts options MaxInFlight, which defaults to 100, PollPeriod, which defaults to 100 milliseconds, and PollTimeout,
+// which defaults to 30 seconds.
+func NewAccountSequenceNumber(client AccountClient, address AccountAddress, options ...any) (*AccountSequenceNumber, error) {
+	manager := &AccountSequenceNumber{
+		client:      client,
+		address:     address,
+		maxInFlight: defaultMaxInFlight,
+		period:      defaultSequenceNumberPeriod,
+		timeout:     defaultSequenceNumberWait,
+		lock:        make(chan struct{}, 1),
+	}
+	for i, arg := range options {
+		switch value := arg.(type) {
+		case MaxInFlight:
+			manager.maxInFlight = uint64(value)
+		case PollPeriod:
+			manager.period = time.Duration(value)
+		case PollTimeout:
+			manager.timeout = time.Duration(value)
+		default:
+			return nil, fmt.Errorf("NewAccountSequenceNumber arg %d bad type %T", i+1, arg)
+		}
+	}
+	if manager.maxInFlight == 0 {
+		return nil, errors.New("NewAccountSequenceNumber MaxInFlight must be at least 1")
+	}
+	return manager, nil
+}
+
+// Address returns the sender the sequence numbers are for
+func (manager *AccountSequenceNumber) Address() AccountAddress {
+	return manager.address
+}
+
+// Next returns the next sequence number to use, waiting while [MaxInFlight] are outstanding
+func (manager *AccountSequenceNumber) Next() (uint64, error) {
+	return manager.NextCtx(context.Background())
+}
+
+// NextCtx is [AccountSequenceNumber.Next] with a [context.Context], cancelling the context stops waiting
+func (manager *AccountSequenceNumber) NextCtx(ctx context.Context) (uint64, error) {
+	err := manager.acquire(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer manager.release()
+
+	if !manager.initialized {
+		err = manager.resync(ctx)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	// Fill in gaps left by released sequence numbers first, dropping any that were used since
+	for len(manager.released) > 0 {
+		sequenceNumber := manager.released[0]
+		manager.released = manager.released[1:]
+		if sequenceNumber >= manager.committed {
+			return sequenceNumber, nil
+		}
+	}
+
+	if manager.next-manager.committed >= manager.maxInFlight {
+		start := time.Now()
+		for {
+			onChain, err := manager.fetch(ctx)
+			if err != nil {
+				return 0, err
+			}
+			manager.committed = max(manager.committed, onChain)
+			if manager.next < manager.committed {
+				// Something else used the account's sequence numbers
+				manager.next = manager.committed
+			}
+			if manager.next-manager.committed < manager.maxInFlight {
+				break
+			}
+			if time.Since(start) >= manager.timeout {
+				// Nothing committed, so the outstanding transactions expired or were dropped
+				manager.next = manager.committed
+				manager.released = nil
+				break
+			}
+			err = sleepCtx(ctx, manager.period)
+			if err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	sequenceNumber := manager.next
+	manager.next++
+	return sequenceNumber, nil
+}
+
+// Release gives back a sequence number from [AccountSequenceNumber.Next] that won't reach chain, e.g. because
+// simulating or signing its transaction failed, or the node rejected it.  A transaction whose submission failed without
+// an answer from the node, e.g. a timeout, may still reach chain, so its sequence number must not be released.  It is handed out again before any new sequence number, so
+// that the transactions after it aren't stuck waiting on the gap.
+func (manager *AccountSequenceNumber) Release(sequenceNumber uint64) {
+	// Not cancellable, as it is called on failure paths, which include a cancelled context
+	_ = manager.acquire(context.Background())
+	defer manager.release()
+
+	if !manager.initialized || sequenceNumber >= manager.next || sequenceNumber < manager.committed {
+		return
+	}
+	if sequenceNumber == manager.next-1 {
+		manager.next--
+		// Released numbers just below are now simply the next ones
+		for len(manager.released) > 0 && manager.released[len(manager.released)-1] == manager.next-1 {
+			manager.released = manager.released[:len(manager.released)-1]
+			manager.next--
+		}
+		return
+	}
+	i, found := slices.BinarySearch(manager.released, sequenceNumber)
+	if !found {
+		manager.released = slices.Insert(manager.released, i, sequenceNumber)
+	}
+}
+
+// Resync starts over from the on-chain sequence number, e.g. after transactions were dropped
+func (manager *AccountSequenceNumber) Resync() error {
+	return manager.ResyncCtx(context.Background())
+}
+
+// ResyncCtx is [AccountSequenceNumber.Resync] with a [context.Context] for cancellation and deadlines
+func (manager *AccountSequenceNumber) ResyncCtx(ctx context.Context) error {
+	err := manager.acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer manager.release()
+	return manager.resync(ctx)
+}
+
+// HandleError resyncs from chain if err is a submission rejected for its sequence number, i.e.
+// SEQUENCE_NUMBER_TOO_OLD or SEQUENCE_NUMBER_TOO_NEW.  It returns whether it resynced, in which case the transaction can
+// be rebuilt with a new sequence number.  Other errors are ignored.
+//
+//	response, err := client.SubmitTransaction(signedTxn)
+//	if resynced, _ := seqNum.HandleError(err); resynced {
+//		// Build, sign, and submit again
+//	}
+func (manager *AccountSequenceNumber) HandleError(err error) (bool, error) {
+	return manager.HandleErrorCtx(context.Background(), err)
+}
+
+// HandleErrorCtx is [AccountSequenceNumber.HandleError] with a [context.Context] for cancellation and deadlines
+func (manager *AccountSequenceNumber) HandleErrorCtx(ctx context.Context, err error) (bool, error) {
+	if !IsSequenceNumberError(err) {
+		return false, nil
+	}
+	resyncErr := manager.ResyncCtx(ctx)
+	if resyncErr != nil {
+		return false, resyncErr
+	}
+	return true, nil
+}
+
+// IsSequenceNumberError tells whether err is a transaction rejected for its sequence number, either already used
+// (SEQUENCE_NUMBER_TOO_OLD) or too far ahead of the account's (SEQUENCE_NUMBER_TOO_NEW)
+func IsSequenceNumberError(err error) bool {
+	var apiErr *AptosApiError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	if apiErr.ErrorCode == AptosErrorCodeSequenceNumberTooOld {
+		return true
+	}
+	if apiErr.VmErrorCode != nil && (*apiErr.VmErrorCode == vmStatusSequenceNumberTooOld || *apiErr.VmErrorCode == vmStatusSequenceNumberTooNew) {
+		return true
+	}
+	return strings.Contains(apiErr.Message, "SEQUENCE_NUMBER_TOO_OLD") || strings.Contains(apiErr.Message, "SEQUENCE_NUMBER_TOO_NEW")
+}
+
+// isSubmissionRejected tells whether a failed submission was turned away by the node, a 4xx response with an API error,
+// rather than failing in a way that may have left the transaction in mempool, e.g. a timeout or a 5xx response
+func isSubmissionRejected(err error) bool {
+	var httpErr *HttpError
+	return errors.As(err, &httpErr) && httpErr.StatusCode >= 400 && httpErr.StatusCode < 500 && httpErr.ApiError != nil
+}
+
+// resync resets to the on-chain sequence number, the lock must be held
+func (manager *AccountSequenceNumber) resync(ctx context.Context) error {
+	onChain, err := manager.fetch(ctx)
+	if err != nil {
+		return err
+	}
+	manager.committed = onChain
+	manager.next = onChain
+	manager.released = nil
+	manager.initialized = true
+	return nil
+}
+
+// fetch gets the on-chain sequence number, which is 0 for an account that doesn't exist yet
+func (manager *AccountSequenceNumber) fetch(ctx context.Context) (uint64, error) {
+	info, err := manager.client.AccountCtx(ctx, manager.address)
+	if errors.Is(err, ErrAccountNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return info.SequenceNumber()
+}
+
+func (manager *AccountSequenceNumber) acquire(ctx context.Context) error {
+	select {
+	case manager.lock <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (manager *AccountSequenceNumber) release() {
+	<-manager.lock
+}
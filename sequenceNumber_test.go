@@ -0,0 +1,247 @@
+package aptos
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestAccountSequenceNumber(t *testing.T) {
+	var onChain atomic.Uint64
+	onChain.Store(5)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/accounts/" + AccountOne.String():
+			_, _ = fmt.Fprintf(w, `{"sequence_number":"%d","authentication_key":"%s"}`, onChain.Load(), AccountOne.String())
+		case "/v1/accounts/" + AccountTwo.String():
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Account not found","error_code":"account_not_found","vm_error_code":null}`))
+		case "/v1/transactions":
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"message":"Invalid transaction: Type: Validation Code: SEQUENCE_NUMBER_TOO_NEW","error_code":"vm_error","vm_error_code":4}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	client, err := NewNodeClient(server.URL+"/v1", 4)
+	assert.NoError(t, err)
+
+	// Concurrent callers get unique, consecutive sequence numbers
+	seqNum, err := NewAccountSequenceNumber(client, AccountOne)
+	assert.NoError(t, err)
+	var mutex sync.Mutex
+	var handedOut []uint64
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sequenceNumber, err := seqNum.Next()
+			assert.NoError(t, err)
+			mutex.Lock()
+			handedOut = append(handedOut, sequenceNumber)
+			mutex.Unlock()
+		}()
+	}
+	wg.Wait()
+	sort.Slice(handedOut, func(i, j int) bool { return handedOut[i] < handedOut[j] })
+	for i, sequenceNumber := range handedOut {
+		assert.Equal(t, uint64(5+i), sequenceNumber)
+	}
+
+	// At the in-flight cap, Next waits for a commit
+	seqNum, err = NewAccountSequenceNumber(client, AccountOne, MaxInFlight(2), PollPeriod(10*time.Millisecond), PollTimeout(5*time.Second))
+	assert.NoError(t, err)
+	for _, expected := range []uint64{5, 6} {
+		sequenceNumber, err := seqNum.Next()
+		assert.NoError(t, err)
+		assert.Equal(t, expected, sequenceNumber)
+	}
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		onChain.Store(6)
+	}()
+	start := time.Now()
+	sequenceNumber, err := seqNum.Next()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(7), sequenceNumber)
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+
+	// Cancelling stops waiting
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+	_, err = seqNum.NextCtx(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// Nothing committing within the timeout means the outstanding transactions expired
+	seqNum, err = NewAccountSequenceNumber(client, AccountOne, MaxInFlight(1), PollPeriod(10*time.Millisecond), PollTimeout(50*time.Millisecond))
+	assert.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		sequenceNumber, err = seqNum.Next()
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(6), sequenceNumber)
+	}
+
+	// A rejected sequence number resyncs from chain
+	payload, err := CoinTransferPayload(nil, AccountTwo, 1)
+	assert.NoError(t, err)
+	sender, err := NewEd25519Account()
+	assert.NoError(t, err)
+	sender.Address = AccountOne
+	seqNum, err = NewAccountSequenceNumber(client, AccountOne)
+	assert.NoError(t, err)
+	_, err = client.BuildSignAndSubmitTransaction(sender, TransactionPayload{Payload: payload}, seqNum, GasUnitPrice(100), MaxGasAmount(1000))
+	assert.True(t, IsSequenceNumberError(err))
+	onChain.Store(10)
+	resynced, err := seqNum.HandleError(err)
+	assert.NoError(t, err)
+	assert.True(t, resynced)
+	sequenceNumber, err = seqNum.Next()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(10), sequenceNumber)
+	resynced, err = seqNum.HandleError(assert.AnError)
+	assert.NoError(t, err)
+	assert.False(t, resynced)
+
+	// The manager hands out sequence numbers to BuildTransaction
+	rawTxn, err := client.BuildTransaction(AccountOne, TransactionPayload{Payload: payload}, seqNum, GasUnitPrice(100), MaxGasAmount(1000))
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(11), rawTxn.SequenceNumber)
+	_, err = client.BuildTransaction(AccountTwo, TransactionPayload{Payload: payload}, seqNum, GasUnitPrice(100), MaxGasAmount(1000))
+	assert.Error(t, err)
+	_, err = client.BuildTransaction(AccountOne, TransactionPayload{Payload: payload}, seqNum, SequenceNumber(1), GasUnitPrice(100), MaxGasAmount(1000))
+	assert.Error(t, err)
+
+	// An account that doesn't exist yet starts at 0
+	seqNum, err = NewAccountSequenceNumber(client, AccountTwo)
+	assert.NoError(t, err)
+	sequenceNumber, err = seqNum.Next()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), sequenceNumber)
+
+	_, err = NewAccountSequenceNumber(client, AccountOne, MaxInFlight(0))
+	assert.Error(t, err)
+	_, err = NewAccountSequenceNumber(client, AccountOne, SequenceNumber(1))
+	assert.Error(t, err)
+}
+
+func TestAccountSequenceNumberRelease(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/accounts/" + AccountOne.String():
+			_, _ = fmt.Fprintf(w, `{"sequence_number":"5","authentication_key":"%s"}`, AccountOne.String())
+		case "/v1/transactions/simulate":
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"message":"Invalid transaction: Type: Validation Code: INVALID_AUTH_KEY","error_code":"vm_error","vm_error_code":2}`))
+		case "/v1/transactions":
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"message":"Invalid transaction: Type: Validation Code: INSUFFICIENT_BALANCE_FOR_TRANSACTION_FEE","error_code":"vm_error","vm_error_code":5}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	client, err := NewNodeClient(server.URL+"/v1", 4)
+	assert.NoError(t, err)
+	seqNum, err := NewAccountSequenceNumber(client, AccountOne)
+	assert.NoError(t, err)
+	next := func(expected uint64) {
+		sequenceNumber, err := seqNum.Next()
+		assert.NoError(t, err)
+		assert.Equal(t, expected, sequenceNumber)
+	}
+
+	// A released gap is filled before handing out new sequence numbers
+	next(5)
+	next(6)
+	next(7)
+	seqNum.Release(6)
+	next(6)
+	next(8)
+
+	// Releasing the most recent ones rewinds
+	seqNum.Release(7)
+	seqNum.Release(8)
+	next(7)
+	next(8)
+	seqNum.Release(100)
+	next(9)
+
+	// Failures after the sequence number is handed out give it back
+	payload, err := CoinTransferPayload(nil, AccountTwo, 1)
+	assert.NoError(t, err)
+	sender, err := NewEd25519Account()
+	assert.NoError(t, err)
+	sender.Address = AccountOne
+	_, err = client.BuildTransactionWithOptions(AccountOne, TransactionPayload{Payload: payload},
+		WithAccountSequenceNumber(seqNum), WithSimulatedMaxGasAmount(1.5, sender.PubKey()))
+	assert.Error(t, err)
+	_, err = client.BuildTransactionMultiAgentWithOptions(AccountOne, TransactionPayload{Payload: payload},
+		WithAccountSequenceNumber(seqNum), WithSimulatedMaxGasAmount(1.5, sender.PubKey()), WithSecondarySigners(AccountTwo))
+	assert.Error(t, err)
+	_, err = client.BuildSignAndSubmitTransactionWithOptions(sender, TransactionPayload{Payload: payload}, WithAccountSequenceNumber(seqNum))
+	assert.Error(t, err)
+	assert.False(t, IsSequenceNumberError(err))
+	next(10)
+}
+
+func TestAccountSequenceNumberAmbiguousSubmission(t *testing.T) {
+	var accepted atomic.Int32
+	var unavailable atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/accounts/" + AccountOne.String():
+			_, _ = fmt.Fprintf(w, `{"sequence_number":"5","authentication_key":"%s"}`, AccountOne.String())
+		case "/v1/transactions":
+			// The node takes the transaction into mempool, but the answer never makes it back
+			_, _ = io.ReadAll(r.Body)
+			accepted.Add(1)
+			if unavailable.Load() {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			<-r.Context().Done()
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	client, err := NewNodeClient(server.URL+"/v1", 4)
+	assert.NoError(t, err)
+	client.SetRetryPolicy(nil)
+	seqNum, err := NewAccountSequenceNumber(client, AccountOne)
+	assert.NoError(t, err)
+	payload, err := CoinTransferPayload(nil, AccountTwo, 1)
+	assert.NoError(t, err)
+	sender, err := NewEd25519Account()
+	assert.NoError(t, err)
+	sender.Address = AccountOne
+
+	// The transaction may be in mempool, so its sequence number isn't handed out again
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err = client.BuildSignAndSubmitTransactionWithOptionsCtx(ctx, sender, TransactionPayload{Payload: payload}, WithAccountSequenceNumber(seqNum))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, int32(1), accepted.Load())
+	sequenceNumber, err := seqNum.Next()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(6), sequenceNumber)
+
+	// Nor after a 5xx
+	unavailable.Store(true)
+	_, err = client.BuildSignAndSubmitTransactionWithOptions(sender, TransactionPayload{Payload: payload}, WithAccountSequenceNumber(seqNum))
+	assert.Error(t, err)
+	assert.Equal(t, int32(2), accepted.Load())
+	sequenceNumber, err = seqNum.Next()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(8), sequenceNumber)
+}
@@ -31,6 +31,18 @@ type SignedTransaction struct {
 
 // Verify checks a signed transaction's signature
 func (txn *SignedTransaction) Verify() error {
+	// Multi-agent and fee payer signers sign the raw transaction with its extra data, which is in the authenticator
+	if rawTxn, ok := txn.Transaction.(*RawTransaction); ok && txn.Authenticator != nil {
+		switch auth := txn.Authenticator.Auth.(type) {
+		case *MultiAgentTransactionAuthenticator:
+			return verifyWithData(auth, &RawTransactionWithData{
+				Variant: MultiAgentRawTransactionWithDataVariant,
+				Inner:   &MultiAgentRawTransactionWithData{RawTxn: rawTxn, SecondarySigners: auth.SecondarySignerAddresses},
+			})
+		case *FeePayerTransactionAuthenticator:
+			return verifyFeePayer(rawTxn, auth)
+		}
+	}
 	bytes, err := txn.Transaction.SigningMessage()
 	if err != nil {
 		return err
@@ -41,18 +53,72 @@ func (txn *SignedTransaction) Verify() error {
 	return errors.New("signature is invalid")
 }
 
-// TransactionPrefix is a cached hash prefix for taking transaction hashes
-var TransactionPrefix *[]byte
+// verifyWithData checks the authenticator's signatures over the raw transaction with data
+func verifyWithData(auth TransactionAuthenticatorImpl, rawTxn *RawTransactionWithData) error {
+	message, err := rawTxn.SigningMessage()
+	if err != nil {
+		return err
+	}
+	if auth.Verify(message) {
+		return nil
+	}
+	return errors.New("signature is invalid")
+}
 
-// Hash takes the hash of the SignedTransaction
-//
-// Note: At the moment, this assumes that the transaction is a UserTransaction
-func (txn *SignedTransaction) Hash() (string, error) {
+// verifyFeePayer checks a fee payer transaction's signatures like the chain does.  The fee payer signs over its own
+// address, while the sender and secondary signers may instead sign over the [AccountZero] placeholder, for when they
+// sign before the fee payer is known.
+func verifyFeePayer(rawTxn *RawTransaction, auth *FeePayerTransactionAuthenticator) error {
+	if auth.FeePayer == nil {
+		return errors.New("fee payer transaction has no fee payer")
+	}
+	messages := make([][]byte, 0, 2)
+	for _, feePayer := range []AccountAddress{AccountZero, *auth.FeePayer} {
+		message, err := (&RawTransactionWithData{
+			Variant: MultiAgentWithFeePayerRawTransactionWithDataVariant,
+			Inner: &MultiAgentWithFeePayerRawTransactionWithData{
+				RawTxn:           rawTxn,
+				SecondarySigners: auth.SecondarySignerAddresses,
+				FeePayer:         &feePayer,
+			},
+		}).SigningMessage()
+		if err != nil {
+			return err
+		}
+		messages = append(messages, message)
+	}
+	signers := append([]crypto.AccountAuthenticator{*auth.Sender}, auth.SecondarySigners...)
+	for _, signer := range signers {
+		if !signer.Verify(messages[0]) && !signer.Verify(messages[1]) {
+			return errors.New("signature is invalid")
+		}
+	}
+	if !auth.FeePayerAuthenticator.Verify(messages[1]) {
+		return errors.New("fee payer signature is invalid")
+	}
+	return nil
+}
+
+// TransactionPrefix is a cached hash prefix for taking transaction hashes.  It is computed up front, so that
+// concurrent hashing doesn't race to cache it.
+var TransactionPrefix = func() *[]byte {
+	hash := Sha3256Hash([][]byte{[]byte("APTOS::Transaction")})
+	return &hash
+}()
+
+// transactionPrefix returns the hash prefix for transaction hashes, computing it again if it was cleared
+func transactionPrefix() []byte {
 	if TransactionPrefix == nil {
 		hash := Sha3256Hash([][]byte{[]byte("APTOS::Transaction")})
 		TransactionPrefix = &hash
 	}
+	return *TransactionPrefix
+}
 
+// Hash takes the hash of the SignedTransaction
+//
+// Note: At the moment, this assumes that the transaction is a UserTransaction
+func (txn *SignedTransaction) Hash() (string, error) {
 	txnBytes, err := bcs.Serialize(txn)
 	if err != nil {
 		return "", err
@@ -61,10 +127,19 @@ func (txn *SignedTransaction) Hash() (string, error) {
 	// Transaction signature is defined as, the domain separated prefix based on struct (Transaction)
 	// Then followed by the type of the transaction for the enum, UserTransaction is 0
 	// Then followed by BCS encoded bytes of the signed transaction
-	hashBytes := Sha3256Hash([][]byte{*TransactionPrefix, {byte(UserTransactionVariant)}, txnBytes})
+	hashBytes := Sha3256Hash([][]byte{transactionPrefix(), {byte(UserTransactionVariant)}, txnBytes})
 	return BytesToHex(hashBytes), nil
 }
 
+//region SignedTransaction TransactionImpl
+
+// TransactionType returns TransactionVariantUser, a SignedTransaction is the user variant of an on-chain Transaction
+func (txn *SignedTransaction) TransactionType() TransactionVariant {
+	return TransactionVariantUser
+}
+
+//endregion
+
 //region SignedTransaction bcs.Struct
 
 func (txn *SignedTransaction) MarshalBCS(ser *bcs.Serializer) {
@@ -72,6 +147,12 @@ func (txn *SignedTransaction) MarshalBCS(ser *bcs.Serializer) {
 	txn.Authenticator.MarshalBCS(ser)
 }
 func (txn *SignedTransaction) UnmarshalBCS(des *bcs.Deserializer) {
+	if txn.Transaction == nil {
+		txn.Transaction = &RawTransaction{}
+	}
+	if txn.Authenticator == nil {
+		txn.Authenticator = &TransactionAuthenticator{}
+	}
 	txn.Transaction.UnmarshalBCS(des)
 	txn.Authenticator.UnmarshalBCS(des)
 }
@@ -51,17 +51,19 @@ func (s *MultiEd25519TestSigner) Sign(msg []byte) (authenticator *crypto.Account
 
 func (s *MultiEd25519TestSigner) SignMessage(msg []byte) (crypto.Signature, error) {
 	signatures := make([]*crypto.Ed25519Signature, s.SignaturesRequired)
+	bitmap := [crypto.MultiEd25519BitmapLen]byte{}
 	for i := 0; i < int(s.SignaturesRequired); i++ {
 		sig, err := s.Keys[i].SignMessage(msg)
 		if err != nil {
 			return nil, err
 		}
 		signatures[i] = sig.(*crypto.Ed25519Signature)
+		bitmap[i/8] |= 128 >> (i % 8)
 	}
 
 	return &crypto.MultiEd25519Signature{
 		Signatures: signatures,
-		Bitmap:     [4]byte{},
+		Bitmap:     bitmap,
 	}, nil
 }
 
@@ -0,0 +1,773 @@
+package aptos
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+	"github.com/aptos-labs/aptos-go-sdk/crypto"
+	"slices"
+	"strings"
+)
+
+// signingSessionVersion is the version of the JSON envelope written by [SigningSession.MarshalJSON]
+const signingSessionVersion = 1
+
+const (
+	signingSessionRawTransaction         = 0
+	signingSessionRawTransactionWithData = 1
+)
+
+const (
+	partialSignaturesMultiEd25519 = 0
+	partialSignaturesMultiKey     = 1
+)
+
+// SigningSession collects the signatures of a transaction from parties on different machines, e.g. for multi-agent
+// and fee payer transactions.  It is passed around as BCS, or as JSON holding the BCS in base64, and each party adds
+// its signature until the session is complete:
+//
+//	session, err := NewSigningSession(rawTxn)
+//	err = session.Sign(sender)
+//	bytes, err := json.Marshal(session)
+//	// Send bytes to the secondary signer, which signs and sends it back
+//	session = &SigningSession{}
+//	err = json.Unmarshal(bytes, session)
+//	err = session.Sign(secondarySigner)
+//	signedTxn, err := session.SignedTransaction()
+//
+// A signer with a [crypto.MultiKey] or [crypto.MultiEd25519PublicKey] account may have its keys held by different
+// parties.  Each adds its key's signature with [SigningSession.SignPartial] or [SigningSession.AddPartial], and once
+// there are enough they are combined into the signer's authenticator.
+//
+// Signatures are verified against the transaction's SigningMessage as they are added, so that a bad one is caught by
+// whoever adds it.  Only the signature is checked, not that the key is the signer account's authentication key.
+type SigningSession struct {
+	transaction    RawTransactionImpl
+	signers        []AccountAddress
+	authenticators []*crypto.AccountAuthenticator
+	partials       []*partialSignatures // Signatures of multi-key signers without enough of them yet
+}
+
+// NewSigningSession starts a session for a [RawTransaction] or [RawTransactionWithData], with no signatures yet
+func NewSigningSession(txn RawTransactionImpl) (*SigningSession, error) {
+	signers, err := signingSessionSigners(txn)
+	if err != nil {
+		return nil, err
+	}
+	return &SigningSession{
+		transaction:    txn,
+		signers:        signers,
+		authenticators: make([]*crypto.AccountAuthenticator, len(signers)),
+		partials:       make([]*partialSignatures, len(signers)),
+	}, nil
+}
+
+// signingSessionSigners returns the transaction's signers, the sender, then any secondary signers, then any fee payer
+func signingSessionSigners(txn RawTransactionImpl) ([]AccountAddress, error) {
+	switch txn := txn.(type) {
+	case *RawTransaction:
+		return []AccountAddress{txn.Sender}, nil
+	case *RawTransactionWithData:
+		switch inner := txn.Inner.(type) {
+		case *MultiAgentRawTransactionWithData:
+			return append([]AccountAddress{inner.RawTxn.Sender}, inner.SecondarySigners...), nil
+		case *MultiAgentWithFeePayerRawTransactionWithData:
+			if inner.FeePayer == nil {
+				return nil, errors.New("fee payer transaction has no fee payer")
+			}
+			signers := append([]AccountAddress{inner.RawTxn.Sender}, inner.SecondarySigners...)
+			return append(signers, *inner.FeePayer), nil
+		default:
+			return nil, fmt.Errorf("unknown RawTransactionWithData type %T", txn.Inner)
+		}
+	default:
+		return nil, fmt.Errorf("unknown transaction type %T", txn)
+	}
+}
+
+// Transaction returns the transaction being signed
+func (session *SigningSession) Transaction() RawTransactionImpl {
+	return session.transaction
+}
+
+// Signers returns the addresses that must sign, the sender, then any secondary signers, then any fee payer.  A fee
+// payer not yet known is the [AccountZero] placeholder.
+func (session *SigningSession) Signers() []AccountAddress {
+	return append([]AccountAddress{}, session.signers...)
+}
+
+// SigningMessage returns the message each party signs, for signing outside the SDK
+func (session *SigningSession) SigningMessage() ([]byte, error) {
+	return session.transaction.SigningMessage()
+}
+
+// feePayer returns the fee payer transaction, or nil if the transaction has no fee payer
+func (session *SigningSession) feePayer() *MultiAgentWithFeePayerRawTransactionWithData {
+	if txn, ok := session.transaction.(*RawTransactionWithData); ok {
+		if inner, ok := txn.Inner.(*MultiAgentWithFeePayerRawTransactionWithData); ok {
+			return inner
+		}
+	}
+	return nil
+}
+
+// verify checks the authenticator's signature for the signer at index i
+func (session *SigningSession) verify(i int, auth *crypto.AccountAuthenticator) error {
+	if auth == nil || auth.Auth == nil {
+		return errors.New("authenticator cannot be nil")
+	}
+	message, err := session.SigningMessage()
+	if err != nil {
+		return err
+	}
+	if auth.Verify(message) {
+		return nil
+	}
+	feePayer := session.feePayer()
+	if feePayer == nil {
+		return fmt.Errorf("signature of %s is invalid", session.signers[i].String())
+	}
+	if i == len(session.signers)-1 {
+		return fmt.Errorf("fee payer signature of %s is invalid", session.signers[i].String())
+	}
+
+	// Besides the fee payer, signers may have signed over the fee payer placeholder
+	placeholder, err := session.placeholderMessage()
+	if err != nil {
+		return err
+	}
+	if auth.Verify(placeholder) {
+		return nil
+	}
+	return fmt.Errorf("signature of %s is invalid", session.signers[i].String())
+}
+
+// placeholderMessage returns the signing message of a fee payer transaction with the [AccountZero] fee payer
+// placeholder, which signers other than the fee payer may sign instead
+func (session *SigningSession) placeholderMessage() ([]byte, error) {
+	feePayer := session.feePayer()
+	if feePayer == nil {
+		return nil, errors.New("transaction has no fee payer")
+	}
+	return (&RawTransactionWithData{
+		Variant: MultiAgentWithFeePayerRawTransactionWithDataVariant,
+		Inner: &MultiAgentWithFeePayerRawTransactionWithData{
+			RawTxn:           feePayer.RawTxn,
+			SecondarySigners: feePayer.SecondarySigners,
+			FeePayer:         &AccountZero,
+		},
+	}).SigningMessage()
+}
+
+// Add adds the signer's authenticator, replacing any it already added, and any partial signatures of its keys.  The
+// signature must verify against the transaction, see [SigningSession.AddFeePayer] for a fee payer not yet known.
+func (session *SigningSession) Add(signer AccountAddress, auth *crypto.AccountAuthenticator) error {
+	feePayer := session.feePayer()
+	found := false
+	for i, address := range session.signers {
+		if address != signer {
+			continue
+		}
+		if feePayer != nil && i == len(session.signers)-1 && address == AccountZero {
+			return errors.New("fee payer is not set, add it with AddFeePayer")
+		}
+		err := session.verify(i, auth)
+		if err != nil {
+			return err
+		}
+		session.authenticators[i] = auth
+		session.partials[i] = nil
+		found = true
+	}
+	if !found {
+		return fmt.Errorf("%s is not a signer of the transaction", signer.String())
+	}
+	return nil
+}
+
+// AddFeePayer fills in the fee payer's address, replacing the [AccountZero] placeholder, and adds its authenticator.
+// The fee payer signs over its own address, e.g. with [RawTransactionWithData.SignAsFeePayer].  Signatures already
+// added over the placeholder remain valid.
+func (session *SigningSession) AddFeePayer(feePayer AccountAddress, auth *crypto.AccountAuthenticator) error {
+	inner := session.feePayer()
+	if inner == nil {
+		return errors.New("transaction has no fee payer")
+	}
+
+	// Check the signature over the fee payer's address, after filling it in
+	i := len(session.signers) - 1
+	previous := inner.FeePayer
+	err := session.SetFeePayer(feePayer)
+	if err != nil {
+		return err
+	}
+	err = session.verify(i, auth)
+	if err != nil {
+		inner.FeePayer = previous
+		session.signers[i] = *previous
+		return err
+	}
+	session.authenticators[i] = auth
+	session.partials[i] = nil
+	return nil
+}
+
+// SetFeePayer fills in the fee payer's address, replacing the [AccountZero] placeholder, without adding its
+// authenticator.  This is for a multi-key fee payer, whose keys then add their signatures with
+// [SigningSession.SignPartial] or [SigningSession.AddPartial].
+func (session *SigningSession) SetFeePayer(feePayer AccountAddress) error {
+	inner := session.feePayer()
+	if inner == nil {
+		return errors.New("transaction has no fee payer")
+	}
+	if feePayer == AccountZero {
+		return errors.New("fee payer cannot be the placeholder address")
+	}
+	i := len(session.signers) - 1
+	if session.signers[i] != AccountZero && session.signers[i] != feePayer {
+		return fmt.Errorf("transaction fee payer is %s, not %s", session.signers[i].String(), feePayer.String())
+	}
+	inner.FeePayer = &feePayer
+	session.signers[i] = feePayer
+	return nil
+}
+
+// Sign signs the transaction as the signer and adds its authenticator
+func (session *SigningSession) Sign(signer TransactionSigner) error {
+	auth, err := session.transaction.Sign(signer)
+	if err != nil {
+		return err
+	}
+	return session.Add(signer.AccountAddress(), auth)
+}
+
+// SignAsFeePayer signs the transaction as the fee payer and adds its authenticator, filling in the fee payer's address
+// if it isn't known yet, see [RawTransactionWithData.SignAsFeePayer]
+func (session *SigningSession) SignAsFeePayer(feePayer TransactionSigner) error {
+	inner := session.feePayer()
+	if inner == nil {
+		return errors.New("transaction has no fee payer")
+	}
+
+	// Sign a copy, so that a wrong fee payer leaves the session as it was
+	address := feePayer.AccountAddress()
+	auth, err := (&RawTransactionWithData{
+		Variant: MultiAgentWithFeePayerRawTransactionWithDataVariant,
+		Inner: &MultiAgentWithFeePayerRawTransactionWithData{
+			RawTxn:           inner.RawTxn,
+			SecondarySigners: inner.SecondarySigners,
+			FeePayer:         &address,
+		},
+	}).Sign(feePayer)
+	if err != nil {
+		return err
+	}
+	return session.AddFeePayer(address, auth)
+}
+
+// AddPartial adds the signature of one key of a multi-key signer, the key at index in publicKey, a [crypto.MultiKey]
+// or [crypto.MultiEd25519PublicKey].  Once the signer has as many signatures as the key requires, they are combined
+// into its authenticator.  Every key of a signer must sign the same message, see [SigningSession.SignPartial].  A fee
+// payer not yet known must first be filled in with [SigningSession.SetFeePayer].
+func (session *SigningSession) AddPartial(signer AccountAddress, publicKey crypto.PublicKey, index uint8, signature crypto.Signature) error {
+	found := false
+	for i, address := range session.signers {
+		if address != signer {
+			continue
+		}
+		err := session.addPartial(i, publicKey, index, signature)
+		if err != nil {
+			return err
+		}
+		found = true
+	}
+	if !found {
+		return fmt.Errorf("%s is not a signer of the transaction", signer.String())
+	}
+	return nil
+}
+
+// addPartial adds the partial signature for the signer at index i
+func (session *SigningSession) addPartial(i int, publicKey crypto.PublicKey, index uint8, signature crypto.Signature) error {
+	if session.feePayer() != nil && i == len(session.signers)-1 && session.signers[i] == AccountZero {
+		return errors.New("fee payer is not set, set it with SetFeePayer")
+	}
+	if session.authenticators[i] != nil {
+		return fmt.Errorf("%s has already signed", session.signers[i].String())
+	}
+	partials := session.partials[i]
+	if partials != nil && !bytes.Equal(partials.publicKey.Bytes(), publicKey.Bytes()) {
+		return fmt.Errorf("public key of %s does not match that of its other partial signatures", session.signers[i].String())
+	}
+	signature, placeholder, err := session.verifyPartial(i, publicKey, index, signature)
+	if err != nil {
+		return err
+	}
+	if partials == nil {
+		partials = &partialSignatures{
+			publicKey:   publicKey,
+			placeholder: placeholder,
+			signatures:  make(map[uint8]crypto.Signature),
+		}
+	} else if partials.placeholder != placeholder {
+		return fmt.Errorf("partial signature of %s is over a different fee payer than its other partial signatures", session.signers[i].String())
+	}
+	partials.signatures[index] = signature
+	session.partials[i] = partials
+
+	_, required, err := multiKeyPublicKey(publicKey, index)
+	if err != nil {
+		return err
+	}
+	if len(partials.signatures) < int(required) {
+		return nil
+	}
+	auth, err := partials.authenticator()
+	if err != nil {
+		return err
+	}
+	err = session.verify(i, auth)
+	if err != nil {
+		return err
+	}
+	session.authenticators[i] = auth
+	session.partials[i] = nil
+	return nil
+}
+
+// verifyPartial checks the partial signature for the signer at index i, and returns it in the form the multi-key
+// signature holds, and whether it is over the fee payer placeholder
+func (session *SigningSession) verifyPartial(i int, publicKey crypto.PublicKey, index uint8, signature crypto.Signature) (crypto.Signature, bool, error) {
+	key, _, err := multiKeyPublicKey(publicKey, index)
+	if err != nil {
+		return nil, false, err
+	}
+	switch key.(type) {
+	case *crypto.AnyPublicKey:
+		switch signature.(type) {
+		case *crypto.AnySignature:
+		case *crypto.Ed25519Signature, *crypto.Secp256k1Signature:
+			signature = &crypto.AnySignature{
+				Variant:   crypto.AnySignatureVariant(key.(*crypto.AnyPublicKey).Variant),
+				Signature: signature,
+			}
+		default:
+			return nil, false, fmt.Errorf("signature type %T does not match public key type %T", signature, publicKey)
+		}
+	case *crypto.Ed25519PublicKey:
+		if _, ok := signature.(*crypto.Ed25519Signature); !ok {
+			return nil, false, fmt.Errorf("signature type %T does not match public key type %T", signature, publicKey)
+		}
+	}
+
+	// Besides the fee payer, signers may have signed over the fee payer placeholder
+	if session.feePayer() != nil && i != len(session.signers)-1 {
+		placeholder, err := session.placeholderMessage()
+		if err != nil {
+			return nil, false, err
+		}
+		if key.Verify(placeholder, signature) {
+			return signature, true, nil
+		}
+	}
+	message, err := session.SigningMessage()
+	if err != nil {
+		return nil, false, err
+	}
+	if key.Verify(message, signature) {
+		return signature, false, nil
+	}
+	return nil, false, fmt.Errorf("partial signature of %s key %d is invalid", session.signers[i].String(), index)
+}
+
+// SignPartial signs the transaction with one key of a multi-key signer, and adds its signature as with
+// [SigningSession.AddPartial].  The key's index is that of its public key in publicKey.
+func (session *SigningSession) SignPartial(signer AccountAddress, publicKey crypto.PublicKey, key crypto.MessageSigner) error {
+	index, err := multiKeyIndex(publicKey, key.VerifyingKey())
+	if err != nil {
+		return err
+	}
+
+	// Sign the same message as the signer's other keys
+	message, err := session.SigningMessage()
+	if err != nil {
+		return err
+	}
+	i := slices.Index(session.signers, signer)
+	if i >= 0 && session.partials[i] != nil && session.partials[i].placeholder {
+		message, err = session.placeholderMessage()
+		if err != nil {
+			return err
+		}
+	}
+	signature, err := key.SignMessage(message)
+	if err != nil {
+		return err
+	}
+	return session.AddPartial(signer, publicKey, index, signature)
+}
+
+// Partials returns the key indices of the partial signatures added for the signer, that are not yet combined
+func (session *SigningSession) Partials(signer AccountAddress) []uint8 {
+	i := slices.Index(session.signers, signer)
+	if i < 0 || session.partials[i] == nil {
+		return []uint8{}
+	}
+	return session.partials[i].indices()
+}
+
+// Authenticators returns the authenticators in the order of [SigningSession.Signers], nil for those not yet added
+func (session *SigningSession) Authenticators() []*crypto.AccountAuthenticator {
+	return append([]*crypto.AccountAuthenticator{}, session.authenticators...)
+}
+
+// Missing returns the signers that haven't added their authenticator yet
+func (session *SigningSession) Missing() []AccountAddress {
+	missing := make([]AccountAddress, 0)
+	for i, auth := range session.authenticators {
+		if auth == nil {
+			missing = append(missing, session.signers[i])
+		}
+	}
+	return missing
+}
+
+// IsComplete tells whether every signer has added its authenticator
+func (session *SigningSession) IsComplete() bool {
+	return len(session.Missing()) == 0
+}
+
+// SignedTransaction assembles the signed transaction for submission, once every signer has added its authenticator
+func (session *SigningSession) SignedTransaction() (*SignedTransaction, error) {
+	missing := session.Missing()
+	if len(missing) > 0 {
+		addresses := make([]string, len(missing))
+		for i, address := range missing {
+			addresses[i] = address.String()
+		}
+		return nil, fmt.Errorf("missing signatures from %s", strings.Join(addresses, ", "))
+	}
+
+	sender := session.authenticators[0]
+	switch txn := session.transaction.(type) {
+	case *RawTransaction:
+		return txn.SignedTransactionWithAuthenticator(sender)
+	case *RawTransactionWithData:
+		secondarySigners := make([]crypto.AccountAuthenticator, 0, len(session.authenticators))
+		for _, auth := range session.authenticators[1:] {
+			secondarySigners = append(secondarySigners, *auth)
+		}
+		if txn.Variant == MultiAgentWithFeePayerRawTransactionWithDataVariant {
+			feePayer := secondarySigners[len(secondarySigners)-1]
+			return SponsoredSignedTransaction(txn, sender, &feePayer, secondarySigners[:len(secondarySigners)-1]...)
+		}
+		signedTxn, ok := txn.ToMultiAgentSignedTransaction(sender, secondarySigners)
+		if !ok {
+			return nil, fmt.Errorf("unknown RawTransactionWithData variant %d", txn.Variant)
+		}
+		return signedTxn, nil
+	default:
+		return nil, fmt.Errorf("unknown transaction type %T", txn)
+	}
+}
+
+//region SigningSession bcs.Struct
+
+func (session *SigningSession) MarshalBCS(ser *bcs.Serializer) {
+	switch session.transaction.(type) {
+	case *RawTransaction:
+		ser.Uleb128(signingSessionRawTransaction)
+	case *RawTransactionWithData:
+		ser.Uleb128(signingSessionRawTransactionWithData)
+	default:
+		ser.SetError(fmt.Errorf("unknown transaction type %T", session.transaction))
+		return
+	}
+	ser.Struct(session.transaction)
+	bcs.SerializeSequence(session.signers, ser)
+	bcs.SerializeSequenceWithFunction(session.authenticators, ser, func(ser *bcs.Serializer, auth *crypto.AccountAuthenticator) {
+		ser.Bool(auth != nil)
+		if auth != nil {
+			ser.Struct(auth)
+		}
+	})
+	bcs.SerializeSequenceWithFunction(session.partials, ser, func(ser *bcs.Serializer, partials *partialSignatures) {
+		ser.Bool(partials != nil)
+		if partials != nil {
+			ser.Struct(partials)
+		}
+	})
+}
+
+// UnmarshalBCS reads a session, and verifies its signatures as if they were added again
+func (session *SigningSession) UnmarshalBCS(des *bcs.Deserializer) {
+	variant := des.Uleb128()
+	switch variant {
+	case signingSessionRawTransaction:
+		session.transaction = &RawTransaction{}
+	case signingSessionRawTransactionWithData:
+		session.transaction = &RawTransactionWithData{}
+	default:
+		des.SetError(fmt.Errorf("unknown SigningSession variant %d", variant))
+		return
+	}
+	des.Struct(session.transaction)
+	session.signers = bcs.DeserializeSequence[AccountAddress](des)
+	session.authenticators = bcs.DeserializeSequenceWithFunction(des, func(des *bcs.Deserializer, out **crypto.AccountAuthenticator) {
+		if des.Bool() {
+			*out = &crypto.AccountAuthenticator{}
+			des.Struct(*out)
+		}
+	})
+	session.partials = bcs.DeserializeSequenceWithFunction(des, func(des *bcs.Deserializer, out **partialSignatures) {
+		if des.Bool() {
+			*out = &partialSignatures{}
+			des.Struct(*out)
+		}
+	})
+	if des.Error() != nil {
+		return
+	}
+
+	signers, err := signingSessionSigners(session.transaction)
+	if err != nil {
+		des.SetError(err)
+		return
+	}
+	if len(signers) != len(session.signers) || len(session.authenticators) != len(session.signers) || len(session.partials) != len(session.signers) {
+		des.SetError(fmt.Errorf("SigningSession has %d signers, %d authenticators and %d partial signatures, transaction has %d signers", len(session.signers), len(session.authenticators), len(session.partials), len(signers)))
+		return
+	}
+	for i, signer := range signers {
+		if signer != session.signers[i] {
+			des.SetError(fmt.Errorf("SigningSession signer %s does not match transaction signer %s", session.signers[i].String(), signer.String()))
+			return
+		}
+		if session.authenticators[i] != nil {
+			err = session.verify(i, session.authenticators[i])
+			if err != nil {
+				des.SetError(err)
+				return
+			}
+		}
+		if session.partials[i] != nil {
+			err = session.verifyPartials(i)
+			if err != nil {
+				des.SetError(err)
+				return
+			}
+		}
+	}
+}
+
+// verifyPartials checks the partial signatures read for the signer at index i, as if they were added again
+func (session *SigningSession) verifyPartials(i int) error {
+	partials := session.partials[i]
+	if session.authenticators[i] != nil {
+		return fmt.Errorf("%s has both an authenticator and partial signatures", session.signers[i].String())
+	}
+	if session.feePayer() != nil && i == len(session.signers)-1 && session.signers[i] == AccountZero {
+		return errors.New("fee payer is not set, but has partial signatures")
+	}
+	for index, signature := range partials.signatures {
+		_, placeholder, err := session.verifyPartial(i, partials.publicKey, index, signature)
+		if err != nil {
+			return err
+		}
+		if placeholder != partials.placeholder {
+			return fmt.Errorf("partial signature of %s is over a different fee payer than its other partial signatures", session.signers[i].String())
+		}
+	}
+	_, required, err := multiKeyPublicKey(partials.publicKey, 0)
+	if err != nil {
+		return err
+	}
+	if len(partials.signatures) >= int(required) {
+		return fmt.Errorf("partial signatures of %s are enough to be combined", session.signers[i].String())
+	}
+	return nil
+}
+
+//endregion
+
+//region SigningSession JSON
+
+// signingSessionJson is the JSON envelope of a session, the session's BCS in base64
+type signingSessionJson struct {
+	Version int    `json:"version"`
+	Bcs     string `json:"bcs"`
+}
+
+// MarshalJSON writes the session as {"version":1,"bcs":"<base64 BCS>"}
+func (session *SigningSession) MarshalJSON() ([]byte, error) {
+	bytes, err := bcs.Serialize(session)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(&signingSessionJson{
+		Version: signingSessionVersion,
+		Bcs:     base64.StdEncoding.EncodeToString(bytes),
+	})
+}
+
+// UnmarshalJSON reads a session written by [SigningSession.MarshalJSON]
+func (session *SigningSession) UnmarshalJSON(b []byte) error {
+	envelope := &signingSessionJson{}
+	err := json.Unmarshal(b, envelope)
+	if err != nil {
+		return err
+	}
+	if envelope.Version != signingSessionVersion {
+		return fmt.Errorf("unsupported SigningSession version %d", envelope.Version)
+	}
+	bytes, err := base64.StdEncoding.DecodeString(envelope.Bcs)
+	if err != nil {
+		return err
+	}
+	return bcs.Deserialize(session, bytes)
+}
+
+//endregion
+
+//region partialSignatures
+
+// partialSignatures are the signatures of some of the keys of a multi-key signer, not yet enough to combine
+type partialSignatures struct {
+	publicKey   crypto.PublicKey           // *crypto.MultiKey or *crypto.MultiEd25519PublicKey
+	placeholder bool                       // Signed over the fee payer placeholder, rather than the fee payer's address
+	signatures  map[uint8]crypto.Signature // *crypto.AnySignature or *crypto.Ed25519Signature, by key index
+}
+
+// multiKeyPublicKey returns the key at index of a multi-key public key, and the number of signatures it requires
+func multiKeyPublicKey(publicKey crypto.PublicKey, index uint8) (crypto.VerifyingKey, uint8, error) {
+	switch publicKey := publicKey.(type) {
+	case *crypto.MultiKey:
+		if publicKey != nil && int(index) < len(publicKey.PubKeys) {
+			return publicKey.PubKeys[index], publicKey.SignaturesRequired, nil
+		}
+	case *crypto.MultiEd25519PublicKey:
+		if publicKey != nil && int(index) < len(publicKey.PubKeys) {
+			return publicKey.PubKeys[index], publicKey.SignaturesRequired, nil
+		}
+	default:
+		return nil, 0, fmt.Errorf("public key type %T is not a multi-key", publicKey)
+	}
+	return nil, 0, fmt.Errorf("key index %d out of range", index)
+}
+
+// multiKeyIndex returns the index of key in a multi-key public key
+func multiKeyIndex(publicKey crypto.PublicKey, key crypto.VerifyingKey) (uint8, error) {
+	switch publicKey := publicKey.(type) {
+	case *crypto.MultiKey:
+		if _, ok := key.(*crypto.AnyPublicKey); !ok {
+			key = crypto.ToAnyPublicKey(key)
+		}
+		for i, pubKey := range publicKey.PubKeys {
+			if bytes.Equal(pubKey.Bytes(), key.Bytes()) {
+				return uint8(i), nil
+			}
+		}
+	case *crypto.MultiEd25519PublicKey:
+		for i, pubKey := range publicKey.PubKeys {
+			if bytes.Equal(pubKey.Bytes(), key.Bytes()) {
+				return uint8(i), nil
+			}
+		}
+	default:
+		return 0, fmt.Errorf("public key type %T is not a multi-key", publicKey)
+	}
+	return 0, errors.New("key is not one of the multi-key's keys")
+}
+
+// indices returns the key indices of the signatures, in ascending order
+func (partials *partialSignatures) indices() []uint8 {
+	indices := make([]uint8, 0, len(partials.signatures))
+	for index := range partials.signatures {
+		indices = append(indices, index)
+	}
+	slices.Sort(indices)
+	return indices
+}
+
+// authenticator combines the signatures into the signer's authenticator, holding them in order of key index
+func (partials *partialSignatures) authenticator() (*crypto.AccountAuthenticator, error) {
+	switch publicKey := partials.publicKey.(type) {
+	case *crypto.MultiKey:
+		sig := &crypto.MultiKeySignature{}
+		for _, index := range partials.indices() {
+			err := sig.Bitmap.AddKey(index)
+			if err != nil {
+				return nil, err
+			}
+			sig.Signatures = append(sig.Signatures, partials.signatures[index].(*crypto.AnySignature))
+		}
+		return &crypto.AccountAuthenticator{
+			Variant: crypto.AccountAuthenticatorMultiKey,
+			Auth:    &crypto.MultiKeyAuthenticator{PubKey: publicKey, Sig: sig},
+		}, nil
+	case *crypto.MultiEd25519PublicKey:
+		sig := &crypto.MultiEd25519Signature{}
+		for _, index := range partials.indices() {
+			sig.Bitmap[index/8] |= 128 >> (index % 8)
+			sig.Signatures = append(sig.Signatures, partials.signatures[index].(*crypto.Ed25519Signature))
+		}
+		return &crypto.AccountAuthenticator{
+			Variant: crypto.AccountAuthenticatorMultiEd25519,
+			Auth:    &crypto.MultiEd25519Authenticator{PubKey: publicKey, Sig: sig},
+		}, nil
+	default:
+		return nil, fmt.Errorf("public key type %T is not a multi-key", partials.publicKey)
+	}
+}
+
+func (partials *partialSignatures) MarshalBCS(ser *bcs.Serializer) {
+	switch partials.publicKey.(type) {
+	case *crypto.MultiEd25519PublicKey:
+		ser.Uleb128(partialSignaturesMultiEd25519)
+	case *crypto.MultiKey:
+		ser.Uleb128(partialSignaturesMultiKey)
+	default:
+		ser.SetError(fmt.Errorf("public key type %T is not a multi-key", partials.publicKey))
+		return
+	}
+	ser.Struct(partials.publicKey)
+	ser.Bool(partials.placeholder)
+	bcs.SerializeSequenceWithFunction(partials.indices(), ser, func(ser *bcs.Serializer, index uint8) {
+		ser.U8(index)
+		ser.Struct(partials.signatures[index])
+	})
+}
+
+func (partials *partialSignatures) UnmarshalBCS(des *bcs.Deserializer) {
+	var newSignature func() crypto.Signature
+	variant := des.Uleb128()
+	switch variant {
+	case partialSignaturesMultiEd25519:
+		partials.publicKey = &crypto.MultiEd25519PublicKey{}
+		newSignature = func() crypto.Signature { return &crypto.Ed25519Signature{} }
+	case partialSignaturesMultiKey:
+		partials.publicKey = &crypto.MultiKey{}
+		newSignature = func() crypto.Signature { return &crypto.AnySignature{} }
+	default:
+		des.SetError(fmt.Errorf("unknown partial signatures variant %d", variant))
+		return
+	}
+	des.Struct(partials.publicKey)
+	partials.placeholder = des.Bool()
+	length := des.Uleb128()
+	partials.signatures = make(map[uint8]crypto.Signature)
+	for range length {
+		index := des.U8()
+		signature := newSignature()
+		des.Struct(signature)
+		if des.Error() != nil {
+			return
+		}
+		partials.signatures[index] = signature
+	}
+}
+
+//endregion
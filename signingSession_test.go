@@ -0,0 +1,223 @@
+package aptos
+
+import (
+	"encoding/json"
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+	"github.com/aptos-labs/aptos-go-sdk/crypto"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSigningSession(t *testing.T) {
+	sender, err := NewEd25519Account()
+	assert.NoError(t, err)
+	secondary, err := NewEd25519Account()
+	assert.NoError(t, err)
+	feePayer, err := NewEd25519Account()
+	assert.NoError(t, err)
+	payload, err := CoinTransferPayload(nil, AccountOne, 1)
+	assert.NoError(t, err)
+	rawTxn := &RawTransaction{
+		Sender:                     sender.Address,
+		SequenceNumber:             5,
+		Payload:                    TransactionPayload{Payload: payload},
+		MaxGasAmount:               1000,
+		GasUnitPrice:               100,
+		ExpirationTimestampSeconds: 1_900_000_000,
+		ChainId:                    4,
+	}
+
+	// Multi-agent, signed by each party after passing the session around as JSON
+	session, err := NewSigningSession(&RawTransactionWithData{
+		Variant: MultiAgentRawTransactionWithDataVariant,
+		Inner:   &MultiAgentRawTransactionWithData{RawTxn: rawTxn, SecondarySigners: []AccountAddress{secondary.Address}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []AccountAddress{sender.Address, secondary.Address}, session.Signers())
+	assert.NoError(t, session.Sign(secondary))
+	assert.Equal(t, []AccountAddress{sender.Address}, session.Missing())
+	_, err = session.SignedTransaction()
+	assert.ErrorContains(t, err, sender.Address.String())
+
+	bytes, err := json.Marshal(session)
+	assert.NoError(t, err)
+	received := &SigningSession{}
+	assert.NoError(t, json.Unmarshal(bytes, received))
+	assert.Equal(t, session.Signers(), received.Signers())
+	assert.Equal(t, []AccountAddress{sender.Address}, received.Missing())
+	assert.NoError(t, received.Sign(sender))
+	assert.True(t, received.IsComplete())
+	signedTxn, err := received.SignedTransaction()
+	assert.NoError(t, err)
+	assert.Equal(t, TransactionAuthenticatorMultiAgent, signedTxn.Authenticator.Variant)
+	assert.IsType(t, &RawTransaction{}, signedTxn.Transaction)
+	assert.NoError(t, signedTxn.Verify())
+
+	// Signatures are checked as they are added
+	other, err := NewEd25519Account()
+	assert.NoError(t, err)
+	assert.Error(t, received.Sign(other))
+	otherAuth, err := rawTxn.Sign(sender)
+	assert.NoError(t, err)
+	assert.Error(t, received.Add(sender.Address, otherAuth))
+	assert.Error(t, received.Add(sender.Address, nil))
+	assert.Error(t, received.SignAsFeePayer(feePayer))
+
+	// Fee payer, with the sender signing before the fee payer is known
+	session, err = NewSigningSession(&RawTransactionWithData{
+		Variant: MultiAgentWithFeePayerRawTransactionWithDataVariant,
+		Inner: &MultiAgentWithFeePayerRawTransactionWithData{
+			RawTxn:           rawTxn,
+			SecondarySigners: []AccountAddress{secondary.Address},
+			FeePayer:         &AccountZero,
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []AccountAddress{sender.Address, secondary.Address, AccountZero}, session.Signers())
+	assert.NoError(t, session.Sign(sender))
+	assert.Error(t, session.Add(AccountZero, otherAuth))
+
+	bytes, err = bcs.Serialize(session)
+	assert.NoError(t, err)
+	received = &SigningSession{}
+	assert.NoError(t, bcs.Deserialize(received, bytes))
+	assert.NoError(t, received.SignAsFeePayer(feePayer))
+	assert.Equal(t, []AccountAddress{sender.Address, secondary.Address, feePayer.Address}, received.Signers())
+	assert.Error(t, received.SignAsFeePayer(other))
+	assert.Equal(t, feePayer.Address, received.Signers()[2])
+
+	// The secondary signer signs after the fee payer is known
+	bytes, err = json.Marshal(received)
+	assert.NoError(t, err)
+	session = &SigningSession{}
+	assert.NoError(t, json.Unmarshal(bytes, session))
+	assert.Equal(t, []AccountAddress{secondary.Address}, session.Missing())
+	assert.NoError(t, session.Sign(secondary))
+	signedTxn, err = session.SignedTransaction()
+	assert.NoError(t, err)
+	assert.Equal(t, TransactionAuthenticatorFeePayer, signedTxn.Authenticator.Variant)
+	assert.Equal(t, feePayer.Address, *signedTxn.Authenticator.Auth.(*FeePayerTransactionAuthenticator).FeePayer)
+	assert.NoError(t, signedTxn.Verify())
+
+	// A tampered session is rejected when read
+	tampered := &SigningSession{}
+	session.authenticators[0] = otherAuth
+	bytes, err = bcs.Serialize(session)
+	assert.NoError(t, err)
+	assert.Error(t, bcs.Deserialize(tampered, bytes))
+	assert.Error(t, json.Unmarshal([]byte(`{"version":2,"bcs":""}`), tampered))
+
+	// A single signer transaction
+	session, err = NewSigningSession(rawTxn)
+	assert.NoError(t, err)
+	assert.Error(t, session.Sign(secondary))
+	assert.NoError(t, session.Add(sender.Address, otherAuth))
+	signedTxn, err = session.SignedTransaction()
+	assert.NoError(t, err)
+	assert.Equal(t, TransactionAuthenticatorEd25519, signedTxn.Authenticator.Variant)
+	assert.NoError(t, signedTxn.Verify())
+}
+
+func TestSigningSessionPartial(t *testing.T) {
+	sender, err := NewEd25519Account()
+	assert.NoError(t, err)
+	feePayer, err := NewEd25519Account()
+	assert.NoError(t, err)
+	multiKey, err := NewMultiKeyTestSigner(3, 2)
+	assert.NoError(t, err)
+	publicKey := multiKey.PubKey()
+	keys := make([]crypto.MessageSigner, len(multiKey.Signers))
+	for i, signer := range multiKey.Signers {
+		keys[i] = signer.(*crypto.SingleSigner).Signer
+	}
+	payload, err := CoinTransferPayload(nil, AccountOne, 1)
+	assert.NoError(t, err)
+	rawTxn := &RawTransaction{
+		Sender:                     sender.Address,
+		SequenceNumber:             5,
+		Payload:                    TransactionPayload{Payload: payload},
+		MaxGasAmount:               1000,
+		GasUnitPrice:               100,
+		ExpirationTimestampSeconds: 1_900_000_000,
+		ChainId:                    4,
+	}
+
+	// A 2 of 3 multi-key secondary signer, whose keys sign before the fee payer is known
+	session, err := NewSigningSession(&RawTransactionWithData{
+		Variant: MultiAgentWithFeePayerRawTransactionWithDataVariant,
+		Inner: &MultiAgentWithFeePayerRawTransactionWithData{
+			RawTxn:           rawTxn,
+			SecondarySigners: []AccountAddress{multiKey.AccountAddress()},
+			FeePayer:         &AccountZero,
+		},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, session.SignPartial(multiKey.AccountAddress(), publicKey, keys[2]))
+	assert.Equal(t, []uint8{2}, session.Partials(multiKey.AccountAddress()))
+	assert.Contains(t, session.Missing(), multiKey.AccountAddress())
+
+	// Partial signatures are checked as they are added
+	message, err := session.SigningMessage()
+	assert.NoError(t, err)
+	signature, err := keys[0].SignMessage(message)
+	assert.NoError(t, err)
+	assert.Error(t, session.AddPartial(multiKey.AccountAddress(), publicKey, 1, signature))
+	assert.Error(t, session.AddPartial(multiKey.AccountAddress(), publicKey, 3, signature))
+	assert.Error(t, session.AddPartial(sender.Address, sender.PubKey(), 0, signature))
+	other, err := NewMultiKeyTestSigner(3, 2)
+	assert.NoError(t, err)
+	assert.Error(t, session.SignPartial(multiKey.AccountAddress(), other.PubKey(), other.Signers[0].(*crypto.SingleSigner).Signer))
+	assert.Error(t, session.SignPartial(multiKey.AccountAddress(), publicKey, other.Signers[0].(*crypto.SingleSigner).Signer))
+	assert.Error(t, session.AddPartial(AccountZero, publicKey, 0, signature))
+	assert.Equal(t, []uint8{2}, session.Partials(multiKey.AccountAddress()))
+
+	// The next key signs after the session is passed around, and after the fee payer is known
+	bytes, err := json.Marshal(session)
+	assert.NoError(t, err)
+	received := &SigningSession{}
+	assert.NoError(t, json.Unmarshal(bytes, received))
+	assert.Equal(t, []uint8{2}, received.Partials(multiKey.AccountAddress()))
+	assert.NoError(t, received.SetFeePayer(feePayer.Address))
+	assert.NoError(t, received.SignPartial(multiKey.AccountAddress(), publicKey, keys[0]))
+	assert.Equal(t, []uint8{}, received.Partials(multiKey.AccountAddress()))
+	assert.Equal(t, []AccountAddress{sender.Address, feePayer.Address}, received.Missing())
+	assert.Error(t, received.SignPartial(multiKey.AccountAddress(), publicKey, keys[1]))
+	assert.NoError(t, received.Sign(sender))
+	assert.NoError(t, received.SignAsFeePayer(feePayer))
+	signedTxn, err := received.SignedTransaction()
+	assert.NoError(t, err)
+	assert.NoError(t, signedTxn.Verify())
+	secondary := signedTxn.Authenticator.Auth.(*FeePayerTransactionAuthenticator).SecondarySigners[0]
+	assert.Equal(t, crypto.AccountAuthenticatorMultiKey, secondary.Variant)
+	assert.Len(t, secondary.Auth.(*crypto.MultiKeyAuthenticator).Sig.Signatures, 2)
+
+	// A 2 of 3 MultiEd25519 sender of a single signer transaction
+	multiEd25519, err := NewMultiEd25519Signer(3, 2)
+	assert.NoError(t, err)
+	publicKey = multiEd25519.PubKey()
+	senderTxn := *rawTxn
+	senderTxn.Sender = multiEd25519.AccountAddress()
+	session, err = NewSigningSession(&senderTxn)
+	assert.NoError(t, err)
+	assert.NoError(t, session.SignPartial(senderTxn.Sender, publicKey, multiEd25519.Keys[0]))
+
+	bytes, err = bcs.Serialize(session)
+	assert.NoError(t, err)
+	received = &SigningSession{}
+	assert.NoError(t, bcs.Deserialize(received, bytes))
+	message, err = received.SigningMessage()
+	assert.NoError(t, err)
+	signature, err = multiEd25519.Keys[2].SignMessage(message)
+	assert.NoError(t, err)
+	assert.NoError(t, received.AddPartial(senderTxn.Sender, publicKey, 2, signature))
+	signedTxn, err = received.SignedTransaction()
+	assert.NoError(t, err)
+	assert.Equal(t, TransactionAuthenticatorMultiEd25519, signedTxn.Authenticator.Variant)
+	assert.NoError(t, signedTxn.Verify())
+
+	// A tampered partial signature is rejected when read
+	session.partials[0].signatures[0] = signature
+	bytes, err = bcs.Serialize(session)
+	assert.NoError(t, err)
+	assert.Error(t, bcs.Deserialize(&SigningSession{}, bytes))
+}
@@ -0,0 +1,92 @@
+package aptos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/aptos-labs/aptos-go-sdk/api"
+	"github.com/aptos-labs/aptos-go-sdk/crypto"
+)
+
+// BuildSponsoredTransaction builds a transaction for a fee payer, e.g. a gas station, to pay for.  The fee payer is
+// left as the [AccountZero] placeholder, so that the sender can sign before the fee payer is known.
+//
+// The flow is split across the sender and the fee payer:
+//
+//	rawTxn, err := client.BuildSponsoredTransaction(sender.Address, payload)
+//	senderAuth, err := rawTxn.Sign(sender)
+//	// Send rawTxn and senderAuth to the fee payer, which fills in its address and signs
+//	feePayerAuth, err := rawTxn.SignAsFeePayer(feePayer)
+//	response, err := client.SubmitSponsored(rawTxn, senderAuth, feePayerAuth)
+//
+// Accepts any [BuildOption] except [WithFeePayer].  With [WithSecondarySigners], their authenticators are passed to
+// SubmitSponsored in the same order.
+func (rc *NodeClient) BuildSponsoredTransaction(sender AccountAddress, payload TransactionPayload, options ...BuildOption) (rawTxn *RawTransactionWithData, err error) {
+	return rc.BuildSponsoredTransactionCtx(context.Background(), sender, payload, options...)
+}
+
+// BuildSponsoredTransactionCtx is [NodeClient.BuildSponsoredTransaction] with a [context.Context] for cancellation and
+// deadlines
+func (rc *NodeClient) BuildSponsoredTransactionCtx(ctx context.Context, sender AccountAddress, payload TransactionPayload, options ...BuildOption) (rawTxn *RawTransactionWithData, err error) {
+	config, err := newBuildConfig(options)
+	if err != nil {
+		return nil, err
+	}
+	if config.feePayer != nil {
+		return nil, errors.New("BuildSponsoredTransaction sets the fee payer, FeePayer cannot be set")
+	}
+	options = append(options[:len(options):len(options)], WithFeePayer(AccountZero))
+	return rc.BuildTransactionMultiAgentWithOptionsCtx(ctx, sender, payload, options...)
+}
+
+// SignAsFeePayer fills in the fee payer's address, replacing the [AccountZero] placeholder left by
+// [NodeClient.BuildSponsoredTransaction], and signs the transaction as the fee payer.  Signatures the sender already
+// made over the placeholder remain valid.
+func (txn *RawTransactionWithData) SignAsFeePayer(feePayer TransactionSigner) (*crypto.AccountAuthenticator, error) {
+	inner, ok := txn.Inner.(*MultiAgentWithFeePayerRawTransactionWithData)
+	if !ok {
+		return nil, errors.New("transaction has no fee payer, build it with BuildSponsoredTransaction")
+	}
+	address := feePayer.AccountAddress()
+	if inner.FeePayer != nil && *inner.FeePayer != AccountZero && *inner.FeePayer != address {
+		return nil, fmt.Errorf("transaction fee payer is %s, not %s", inner.FeePayer.String(), address.String())
+	}
+	inner.FeePayer = &address
+	return txn.Sign(feePayer)
+}
+
+// SubmitSponsored assembles the fee payer transaction from the sender's, fee payer's, and any secondary signers'
+// authenticators, and submits it.  The fee payer must have signed with [RawTransactionWithData.SignAsFeePayer].
+func (rc *NodeClient) SubmitSponsored(rawTxn *RawTransactionWithData, sender *crypto.AccountAuthenticator, feePayer *crypto.AccountAuthenticator, secondarySigners ...crypto.AccountAuthenticator) (data *api.SubmitTransactionResponse, err error) {
+	return rc.SubmitSponsoredCtx(context.Background(), rawTxn, sender, feePayer, secondarySigners...)
+}
+
+// SubmitSponsoredCtx is [NodeClient.SubmitSponsored] with a [context.Context] for cancellation and deadlines
+func (rc *NodeClient) SubmitSponsoredCtx(ctx context.Context, rawTxn *RawTransactionWithData, sender *crypto.AccountAuthenticator, feePayer *crypto.AccountAuthenticator, secondarySigners ...crypto.AccountAuthenticator) (data *api.SubmitTransactionResponse, err error) {
+	signedTxn, err := SponsoredSignedTransaction(rawTxn, sender, feePayer, secondarySigners...)
+	if err != nil {
+		return nil, err
+	}
+	return rc.SubmitTransactionCtx(ctx, signedTxn)
+}
+
+// SponsoredSignedTransaction assembles the fee payer transaction like [NodeClient.SubmitSponsored] without submitting
+// it, e.g. to simulate or store it.  The signatures are verified, so that a bad one is caught before submission.
+func SponsoredSignedTransaction(rawTxn *RawTransactionWithData, sender *crypto.AccountAuthenticator, feePayer *crypto.AccountAuthenticator, secondarySigners ...crypto.AccountAuthenticator) (*SignedTransaction, error) {
+	inner, ok := rawTxn.Inner.(*MultiAgentWithFeePayerRawTransactionWithData)
+	if !ok {
+		return nil, errors.New("transaction has no fee payer, build it with BuildSponsoredTransaction")
+	}
+	if inner.FeePayer == nil || *inner.FeePayer == AccountZero {
+		return nil, errors.New("transaction fee payer is not set, sign it with SignAsFeePayer")
+	}
+	if len(secondarySigners) != len(inner.SecondarySigners) {
+		return nil, fmt.Errorf("transaction has %d secondary signers, got %d authenticators", len(inner.SecondarySigners), len(secondarySigners))
+	}
+	signedTxn, _ := rawTxn.ToFeePayerSignedTransaction(sender, inner.FeePayer, feePayer, secondarySigners, inner.SecondarySigners)
+	err := signedTxn.Verify()
+	if err != nil {
+		return nil, err
+	}
+	return signedTxn, nil
+}
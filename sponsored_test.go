@@ -0,0 +1,111 @@
+package aptos
+
+import (
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSponsoredTransaction(t *testing.T) {
+	sender, err := NewEd25519Account()
+	assert.NoError(t, err)
+	feePayer, err := NewEd25519Account()
+	assert.NoError(t, err)
+
+	var submitted *SignedTransaction
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/accounts/" + sender.Address.String():
+			_, _ = w.Write([]byte(`{"sequence_number":"2","authentication_key":"0x1"}`))
+		case "/v1/transactions":
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			submitted = &SignedTransaction{}
+			assert.NoError(t, bcs.Deserialize(submitted, body))
+			hash, err := submitted.Hash()
+			assert.NoError(t, err)
+			w.WriteHeader(http.StatusAccepted)
+			_, _ = w.Write([]byte(`{"hash":"` + hash + `"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	client, err := NewNodeClient(server.URL+"/v1", 4)
+	assert.NoError(t, err)
+	payload, err := CoinTransferPayload(nil, AccountOne, 1)
+	assert.NoError(t, err)
+
+	rawTxn, err := client.BuildSponsoredTransaction(sender.Address, TransactionPayload{Payload: payload})
+	assert.NoError(t, err)
+	inner := rawTxn.Inner.(*MultiAgentWithFeePayerRawTransactionWithData)
+	assert.Equal(t, AccountZero, *inner.FeePayer)
+	assert.Equal(t, uint64(2), inner.RawTxn.SequenceNumber)
+
+	// The sender signs over the placeholder, before the fee payer is known
+	senderAuth, err := rawTxn.Sign(sender)
+	assert.NoError(t, err)
+	_, err = client.SubmitSponsored(rawTxn, senderAuth, senderAuth)
+	assert.Error(t, err)
+
+	feePayerAuth, err := rawTxn.SignAsFeePayer(feePayer)
+	assert.NoError(t, err)
+	assert.Equal(t, feePayer.Address, *inner.FeePayer)
+
+	response, err := client.SubmitSponsored(rawTxn, senderAuth, feePayerAuth)
+	assert.NoError(t, err)
+
+	// On-chain, the signed transaction holds the plain raw transaction, and the fee payer is in the authenticator
+	assert.IsType(t, &RawTransaction{}, submitted.Transaction)
+	assert.Equal(t, TransactionAuthenticatorFeePayer, submitted.Authenticator.Variant)
+	assert.Equal(t, feePayer.Address, *submitted.Authenticator.Auth.(*FeePayerTransactionAuthenticator).FeePayer)
+	assert.NoError(t, submitted.Verify())
+	hash, err := submitted.Hash()
+	assert.NoError(t, err)
+	assert.Equal(t, hash, response.Hash)
+
+	// A signature over a different transaction is caught before submission
+	expiration := WithExpirationTime(time.Unix(1_900_000_000, 0))
+	otherTxn, err := client.BuildSponsoredTransaction(sender.Address, TransactionPayload{Payload: payload}, WithSequenceNumber(9), expiration)
+	assert.NoError(t, err)
+	otherSenderAuth, err := otherTxn.Sign(sender)
+	assert.NoError(t, err)
+	otherFeePayerAuth, err := otherTxn.SignAsFeePayer(feePayer)
+	assert.NoError(t, err)
+	_, err = client.SubmitSponsored(rawTxn, senderAuth, otherFeePayerAuth)
+	assert.Error(t, err)
+	_, err = client.SubmitSponsored(rawTxn, otherSenderAuth, feePayerAuth)
+	assert.Error(t, err)
+	_, err = client.SubmitSponsored(rawTxn, senderAuth, feePayerAuth, *senderAuth)
+	assert.Error(t, err)
+
+	// The fee payer signs over its own address, not the placeholder
+	placeholderTxn, err := client.BuildSponsoredTransaction(sender.Address, TransactionPayload{Payload: payload}, WithSequenceNumber(9), expiration)
+	assert.NoError(t, err)
+	placeholderAuth, err := placeholderTxn.Sign(feePayer)
+	assert.NoError(t, err)
+	_, err = client.SubmitSponsored(otherTxn, otherSenderAuth, placeholderAuth)
+	assert.Error(t, err)
+
+	// Another fee payer can't take over a transaction already signed by one
+	other, err := NewEd25519Account()
+	assert.NoError(t, err)
+	_, err = rawTxn.SignAsFeePayer(other)
+	assert.Error(t, err)
+
+	_, err = client.BuildSponsoredTransaction(sender.Address, TransactionPayload{Payload: payload}, WithFeePayer(feePayer.Address))
+	assert.Error(t, err)
+	multiAgent, err := client.BuildTransactionMultiAgentWithOptions(sender.Address, TransactionPayload{Payload: payload}, WithSecondarySigners(AccountTwo))
+	assert.NoError(t, err)
+	_, err = multiAgent.SignAsFeePayer(feePayer)
+	assert.Error(t, err)
+}
+
+func TestRawTransactionWithDataPrehash(t *testing.T) {
+	assert.Equal(t, Sha3256Hash([][]byte{[]byte("APTOS::RawTransactionWithData")}), RawTransactionWithDataPrehash())
+	assert.NotEqual(t, RawTransactionPrehash(), RawTransactionWithDataPrehash())
+}
@@ -0,0 +1,348 @@
+package aptos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/aptos-labs/aptos-go-sdk/api"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// MaxPollPeriod is an option to SubscribeBlocks and SubscribeTransactions, the longest wait between polls once caught up
+// with the chain.  The wait starts at PollPeriod and doubles each time there is nothing new.
+type MaxPollPeriod time.Duration
+
+// IncludeTransactions is an option to SubscribeBlocks, to fetch each block with all of its transactions
+type IncludeTransactions bool
+
+const (
+	defaultSubscriptionPollPeriod    = 250 * time.Millisecond
+	defaultSubscriptionMaxPollPeriod = 5 * time.Second
+)
+
+// Subscription delivers items from the chain in order, with no gaps or duplicates, until its context is cancelled or a
+// request fails.
+//
+// Items are sent on an unbuffered channel, so the subscription only fetches more once the previous item was received.  A
+// slow consumer slows down polling rather than buffering without bound.
+//
+// [Subscription.Checkpoint] is where to resume a new subscription from, e.g. after a restart, without missing or
+// repeating items.
+//
+//	sub, err := client.SubscribeBlocks(ctx, checkpoint)
+//	for block := range sub.Items() {
+//		// handle block
+//	}
+//	checkpoint = sub.Checkpoint()
+//	if err := sub.Err(); err != nil {
+//		// Stopped early
+//	}
+type Subscription[T any] struct {
+	items      chan T
+	checkpoint atomic.Uint64
+	err        error
+}
+
+// Items returns the channel of items, it is closed when the subscription stops
+func (sub *Subscription[T]) Items() <-chan T {
+	return sub.items
+}
+
+// Err returns the error that stopped the subscription, only valid once [Subscription.Items] is closed.  It is the
+// context's error if the context was cancelled.
+func (sub *Subscription[T]) Err() error {
+	return sub.err
+}
+
+// Checkpoint returns the block height or transaction version after the last item received from [Subscription.Items].
+// Subscribing again from the checkpoint continues where this subscription left off.
+func (sub *Subscription[T]) Checkpoint() uint64 {
+	return sub.checkpoint.Load()
+}
+
+// send waits for the consumer to receive the item, then moves the checkpoint past it
+func (sub *Subscription[T]) send(ctx context.Context, item T, next uint64) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case sub.items <- item:
+		sub.checkpoint.Store(next)
+		return nil
+	}
+}
+
+// run calls poll until it fails, then closes the channel.  poll reports whether it found anything new, and the wait
+// between polls backs off from period to maxPeriod while there is nothing new.
+func (sub *Subscription[T]) run(ctx context.Context, period, maxPeriod time.Duration, poll func() (bool, error)) {
+	defer close(sub.items)
+	wait := period
+	for {
+		found, err := poll()
+		if err != nil {
+			sub.err = err
+			return
+		}
+		if found {
+			wait = period
+			continue
+		}
+		err = sleepCtx(ctx, wait)
+		if err != nil {
+			sub.err = err
+			return
+		}
+		wait = min(wait*2, maxPeriod)
+	}
+}
+
+func newSubscription[T any](start uint64) *Subscription[T] {
+	sub := &Subscription[T]{items: make(chan T)}
+	sub.checkpoint.Store(start)
+	return sub
+}
+
+// TransactionFilter selects transactions for [NodeClient.SubscribeTransactions].  Every field that is set must match,
+// and a nil or empty filter matches all transactions.
+type TransactionFilter struct {
+	// Sender matches user transactions sent by the account
+	Sender *AccountAddress
+	// EntryFunction matches user transactions calling the entry function, e.g. "0x1::aptos_account::transfer"
+	EntryFunction string
+	// EventType matches transactions emitting an event of the type, e.g. "0x1::coin::CoinDeposit".  Without type
+	// arguments, it matches events of the type with any type arguments.
+	EventType string
+}
+
+// Matches tells whether the transaction passes the filter
+func (f *TransactionFilter) Matches(txn *api.Transaction) bool {
+	if f == nil {
+		return true
+	}
+	if f.Sender != nil || f.EntryFunction != "" {
+		userTxn, err := txn.UserTransaction()
+		if err != nil {
+			return false
+		}
+		if f.Sender != nil && (userTxn.Sender == nil || *userTxn.Sender != *f.Sender) {
+			return false
+		}
+		if f.EntryFunction != "" && !entryFunctionMatches(userTxn.Payload, f.EntryFunction) {
+			return false
+		}
+	}
+	if f.EventType != "" {
+		return slices.ContainsFunc(transactionEvents(txn), func(event *api.Event) bool {
+			return eventTypeMatches(event.Type, f.EventType)
+		})
+	}
+	return true
+}
+
+func entryFunctionMatches(payload *api.TransactionPayload, functionId string) bool {
+	if payload == nil {
+		return false
+	}
+	entryFunction, ok := payload.Inner.(*api.TransactionPayloadEntryFunction)
+	if !ok {
+		return false
+	}
+	if entryFunction.Function == functionId {
+		return true
+	}
+	// Compare parsed, so that e.g. 0x1 and 0x0000…0001 match
+	module, function, err := ParseFunctionId(entryFunction.Function)
+	if err != nil {
+		return false
+	}
+	filterModule, filterFunction, err := ParseFunctionId(functionId)
+	return err == nil && module == filterModule && function == filterFunction
+}
+
+func eventTypeMatches(eventType string, filterType string) bool {
+	if !strings.Contains(filterType, "<") {
+		eventType, _, _ = strings.Cut(eventType, "<")
+	}
+	if eventType == filterType {
+		return true
+	}
+	// Compare parsed, so that address formats and spacing don't matter
+	eventTag, err := ParseTypeTag(eventType)
+	if err != nil {
+		return false
+	}
+	filterTag, err := ParseTypeTag(filterType)
+	return err == nil && eventTag.String() == filterTag.String()
+}
+
+// transactionEvents returns the events emitted by a transaction, if it's of a type that has events
+func transactionEvents(txn *api.Transaction) []*api.Event {
+	switch inner := txn.Inner.(type) {
+	case *api.UserTransaction:
+		return inner.Events
+	case *api.GenesisTransaction:
+		return inner.Events
+	case *api.BlockMetadataTransaction:
+		return inner.Events
+	case *api.ValidatorTransaction:
+		return inner.Events
+	default:
+		return nil
+	}
+}
+
+func getSubscriptionOptions(name string, options ...any) (period time.Duration, maxPeriod time.Duration, includeTransactions bool, err error) {
+	period = defaultSubscriptionPollPeriod
+	maxPeriod = defaultSubscriptionMaxPollPeriod
+	for i, arg := range options {
+		switch value := arg.(type) {
+		case PollPeriod:
+			period = time.Duration(value)
+		case MaxPollPeriod:
+			maxPeriod = time.Duration(value)
+		case IncludeTransactions:
+			if name != "SubscribeBlocks" {
+				err = fmt.Errorf("%s arg %d bad type %T", name, i+1, arg)
+				return
+			}
+			includeTransactions = bool(value)
+		default:
+			err = fmt.Errorf("%s arg %d bad type %T", name, i+1, arg)
+			return
+		}
+	}
+	if period <= 0 {
+		err = fmt.Errorf("%s poll period must be positive, got %s", name, period)
+		return
+	}
+	maxPeriod = max(period, maxPeriod)
+	return
+}
+
+// SubscribeBlocks delivers blocks in order of height, starting at fromHeight, and polls for new blocks once caught up.
+// See [Subscription] for how delivery works and how to resume.
+//
+// It stops when the context is cancelled or a request fails.  Accepts options PollPeriod and MaxPollPeriod, which
+// default to 250 milliseconds and 5 seconds, and IncludeTransactions to fetch every block's transactions.
+//
+//	sub, err := client.SubscribeBlocks(ctx, 100, IncludeTransactions(true))
+//	for block := range sub.Items() {
+//		fmt.Printf("block %d has %d transactions\n", block.BlockHeight, len(block.Transactions))
+//	}
+func (rc *NodeClient) SubscribeBlocks(ctx context.Context, fromHeight uint64, options ...any) (*Subscription[*api.Block], error) {
+	period, maxPeriod, includeTransactions, err := getSubscriptionOptions("SubscribeBlocks", options...)
+	if err != nil {
+		return nil, err
+	}
+	sub := newSubscription[*api.Block](fromHeight)
+	go sub.run(ctx, period, maxPeriod, func() (bool, error) {
+		next := sub.Checkpoint()
+		block, err := rc.BlockByHeightCtx(ctx, next, includeTransactions)
+		if errors.Is(err, ErrBlockNotFound) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		if block.BlockHeight != next {
+			return false, fmt.Errorf("SubscribeBlocks requested block %d, got block %d", next, block.BlockHeight)
+		}
+		return true, sub.send(ctx, block, next+1)
+	})
+	return sub, nil
+}
+
+// SubscribeTransactions delivers committed transactions matching the filter in order of version, starting at
+// fromVersion, and polls for new transactions once caught up.  A nil filter delivers all transactions.  See
+// [Subscription] for how delivery works and how to resume, the checkpoint moves past filtered out transactions too.
+//
+// It stops when the context is cancelled or a request fails.  Accepts options PollPeriod and MaxPollPeriod, which
+// default to 250 milliseconds and 5 seconds.
+//
+//	sub, err := client.SubscribeTransactions(ctx, 0, &TransactionFilter{EntryFunction: "0x1::aptos_account::transfer"})
+//	for txn := range sub.Items() {
+//		fmt.Printf("transfer at version %d\n", *txn.Version())
+//	}
+func (rc *NodeClient) SubscribeTransactions(ctx context.Context, fromVersion uint64, filter *TransactionFilter, options ...any) (*Subscription[*api.Transaction], error) {
+	period, maxPeriod, _, err := getSubscriptionOptions("SubscribeTransactions", options...)
+	if err != nil {
+		return nil, err
+	}
+	sub := newSubscription[*api.Transaction](fromVersion)
+	go sub.run(ctx, period, maxPeriod, func() (bool, error) {
+		next := sub.Checkpoint()
+		transactions, err := rc.TransactionsCtx(ctx, &next, nil)
+		if transactionsCaughtUp(err, next) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+
+		// Deliver strictly in order of version.  Anything already delivered is dropped, and a gap ends the page, so
+		// the rest is fetched again from the checkpoint.
+		slices.SortFunc(transactions, func(a, b *api.Transaction) int {
+			return compareVersions(a.Version(), b.Version())
+		})
+		found := false
+		for _, txn := range transactions {
+			version := txn.Version()
+			if version == nil || *version > next {
+				break
+			}
+			if *version < next {
+				continue
+			}
+			next++
+			found = true
+			if filter.Matches(txn) {
+				err = sub.send(ctx, txn, next)
+				if err != nil {
+					return false, err
+				}
+			} else {
+				sub.checkpoint.Store(next)
+			}
+		}
+		return found, nil
+	})
+	return sub, nil
+}
+
+// transactionsCaughtUp tells whether a failed request for transactions starting at start only went past the end of the
+// ledger.  The node rejects a start past its ledger version as invalid input, rather than as not found.
+func transactionsCaughtUp(err error, start uint64) bool {
+	if errors.Is(err, ErrTransactionNotFound) || errors.Is(err, ErrVersionNotFound) {
+		return true
+	}
+	var httpErr *HttpError
+	if !errors.As(err, &httpErr) || httpErr.ApiError == nil || httpErr.ApiError.ErrorCode != AptosErrorCodeInvalidInput {
+		return false
+	}
+	if header := httpErr.Header.Get(HeaderAptosLedgerVersion); header != "" {
+		ledgerVersion, err := strconv.ParseUint(header, 10, 64)
+		return err == nil && start > ledgerVersion
+	}
+	return strings.Contains(httpErr.ApiError.Message, "higher than the current ledger version")
+}
+
+// compareVersions orders transactions by version, with pending transactions last
+func compareVersions(a, b *uint64) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return 1
+	case b == nil:
+		return -1
+	case *a < *b:
+		return -1
+	case *a > *b:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,165 @@
+package aptos
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/aptos-labs/aptos-go-sdk/api"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func testSubscriptionTransaction(version uint64) string {
+	sender := "0x1"
+	if version%2 == 1 {
+		sender = "0xcafe"
+	}
+	return fmt.Sprintf(`{"type":"user_transaction","version":"%d","hash":"0x%d","success":true,"sender":"%s",`+
+		`"payload":{"type":"entry_function_payload","function":"0x1::aptos_account::transfer","type_arguments":[],"arguments":[]},`+
+		`"events":[{"type":"0x1::coin::CoinDeposit<0x1::aptos_coin::AptosCoin>","sequence_number":"0","data":{}}]}`, version, version, sender)
+}
+
+func TestTransactionFilter(t *testing.T) {
+	txn := &api.Transaction{}
+	assert.NoError(t, json.Unmarshal([]byte(testSubscriptionTransaction(1)), txn))
+	checkpoint := &api.Transaction{}
+	assert.NoError(t, json.Unmarshal([]byte(`{"type":"state_checkpoint_transaction","version":"2","hash":"0x2","success":true}`), checkpoint))
+
+	cafe := AccountAddress{30: 0xca, 31: 0xfe}
+	var nilFilter *TransactionFilter
+	assert.True(t, nilFilter.Matches(txn))
+	assert.True(t, (&TransactionFilter{}).Matches(checkpoint))
+	assert.True(t, (&TransactionFilter{Sender: &cafe}).Matches(txn))
+	assert.False(t, (&TransactionFilter{Sender: &AccountOne}).Matches(txn))
+	assert.False(t, (&TransactionFilter{Sender: &cafe}).Matches(checkpoint))
+	assert.True(t, (&TransactionFilter{EntryFunction: "0x1::aptos_account::transfer"}).Matches(txn))
+	assert.True(t, (&TransactionFilter{EntryFunction: "0x0000000000000000000000000000000000000000000000000000000000000001::aptos_account::transfer"}).Matches(txn))
+	assert.False(t, (&TransactionFilter{EntryFunction: "0x1::coin::transfer"}).Matches(txn))
+	assert.True(t, (&TransactionFilter{EventType: "0x1::coin::CoinDeposit"}).Matches(txn))
+	assert.True(t, (&TransactionFilter{EventType: "0x1::coin::CoinDeposit<0x1::aptos_coin::AptosCoin>"}).Matches(txn))
+	assert.False(t, (&TransactionFilter{EventType: "0x1::coin::CoinDeposit<u64>"}).Matches(txn))
+	assert.False(t, (&TransactionFilter{EventType: "0x1::coin::CoinWithdraw"}).Matches(txn))
+	assert.False(t, (&TransactionFilter{Sender: &cafe, EntryFunction: "0x1::coin::transfer"}).Matches(txn))
+}
+
+func TestSubscribeBlocks(t *testing.T) {
+	var latest atomic.Uint64
+	latest.Store(6)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		height, err := strconv.ParseUint(strings.TrimPrefix(r.URL.Path, "/v1/blocks/by_height/"), 10, 64)
+		assert.NoError(t, err)
+		assert.Equal(t, "false", r.URL.Query().Get("with_transactions"))
+		if height > latest.Load() {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Block not found","error_code":"block_not_found"}`))
+			return
+		}
+		_, _ = fmt.Fprintf(w, `{"block_height":"%d","block_hash":"0x%d","block_timestamp":"1","first_version":"%d","last_version":"%d","transactions":null}`, height, height, height*10, height*10+9)
+	}))
+	defer server.Close()
+
+	client, err := NewNodeClient(server.URL+"/v1", 4)
+	assert.NoError(t, err)
+
+	_, err = client.SubscribeBlocks(context.Background(), 0, PollTimeout(time.Second))
+	assert.Error(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sub, err := client.SubscribeBlocks(ctx, 5, PollPeriod(time.Millisecond), MaxPollPeriod(10*time.Millisecond))
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(5), sub.Checkpoint())
+
+	for expected := uint64(5); expected <= 8; expected++ {
+		if expected == 7 {
+			// New blocks are picked up once they exist
+			latest.Store(8)
+		}
+		select {
+		case block := <-sub.Items():
+			assert.Equal(t, expected, block.BlockHeight)
+			assert.Equal(t, expected*10, block.FirstVersion)
+			assert.Equal(t, expected+1, sub.Checkpoint())
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for block %d", expected)
+		}
+	}
+
+	cancel()
+	for range sub.Items() {
+	}
+	assert.ErrorIs(t, sub.Err(), context.Canceled)
+	assert.Equal(t, uint64(9), sub.Checkpoint())
+}
+
+func TestSubscribeTransactions(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/transactions", r.URL.Path)
+		start, err := strconv.ParseUint(r.URL.Query().Get("start"), 10, 64)
+		assert.NoError(t, err)
+		var versions []uint64
+		switch requests.Add(1) {
+		case 1:
+			// Out of order, an already delivered version, and a gap at 13
+			assert.Equal(t, uint64(10), start)
+			versions = []uint64{11, 9, 10, 12, 14}
+		default:
+			for version := start; version < 16 && version < start+3; version++ {
+				versions = append(versions, version)
+			}
+		}
+		// Like a node, a start past the ledger version is invalid input
+		w.Header().Set(HeaderAptosLedgerVersion, "15")
+		if len(versions) == 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = fmt.Fprintf(w, `{"message":"Given start value (%d) is higher than the current ledger version, it must be < 16","error_code":"invalid_input"}`, start)
+			return
+		}
+		txns := make([]string, len(versions))
+		for i, version := range versions {
+			txns[i] = testSubscriptionTransaction(version)
+		}
+		_, _ = w.Write([]byte("[" + strings.Join(txns, ",") + "]"))
+	}))
+	defer server.Close()
+
+	client, err := NewNodeClient(server.URL+"/v1", 4)
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cafe := AccountAddress{30: 0xca, 31: 0xfe}
+	sub, err := client.SubscribeTransactions(ctx, 10, &TransactionFilter{Sender: &cafe}, PollPeriod(time.Millisecond))
+	assert.NoError(t, err)
+
+	for _, expected := range []uint64{11, 13, 15} {
+		select {
+		case txn := <-sub.Items():
+			assert.Equal(t, expected, *txn.Version())
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for version %d", expected)
+		}
+	}
+
+	// The checkpoint moves past filtered out versions once the subscription is caught up
+	assert.Eventually(t, func() bool { return sub.Checkpoint() == 16 }, 5*time.Second, time.Millisecond)
+	cancel()
+	for range sub.Items() {
+	}
+	assert.ErrorIs(t, sub.Err(), context.Canceled)
+
+	// Other invalid input, e.g. a start within the ledger, is still an error
+	header := http.Header{}
+	header.Set(HeaderAptosLedgerVersion, "15")
+	invalid := &HttpError{Header: header, ApiError: &AptosApiError{Message: "bad start", ErrorCode: AptosErrorCodeInvalidInput}}
+	assert.True(t, transactionsCaughtUp(invalid, 16))
+	assert.False(t, transactionsCaughtUp(invalid, 15))
+	assert.False(t, transactionsCaughtUp(&HttpError{Header: http.Header{}, ApiError: invalid.ApiError}, 16))
+}
@@ -260,3 +260,191 @@ func (ea *SingleSenderTransactionAuthenticator) UnmarshalBCS(des *bcs.Deserializ
 
 //endregion
 //endregion
+
+//region Simulation authenticators
+
+// SimulationTransactionAuthenticator builds a [TransactionAuthenticator] with zeroed signatures for simulating rawTxn.
+// The node rejects simulations with valid signatures, so only the public keys are needed.
+//
+// For a [RawTransaction], pass the sender's public key.  For a [RawTransactionWithData], pass the sender's public key,
+// followed by one per secondary signer in order, followed by the fee payer's public key if there is a fee payer.
+func SimulationTransactionAuthenticator(rawTxn RawTransactionImpl, signers ...crypto.PublicKey) (*TransactionAuthenticator, error) {
+	if len(signers) == 0 {
+		return nil, fmt.Errorf("simulation requires at least the sender's public key")
+	}
+	sender, err := SimulationAccountAuthenticator(signers[0])
+	if err != nil {
+		return nil, err
+	}
+
+	switch txn := rawTxn.(type) {
+	case *RawTransaction:
+		if len(signers) != 1 {
+			return nil, fmt.Errorf("single sender simulation expects 1 public key, got %d", len(signers))
+		}
+		switch sender.Variant {
+		case crypto.AccountAuthenticatorEd25519:
+			return &TransactionAuthenticator{
+				Variant: TransactionAuthenticatorEd25519,
+				Auth:    &Ed25519TransactionAuthenticator{Sender: sender},
+			}, nil
+		case crypto.AccountAuthenticatorMultiEd25519:
+			return &TransactionAuthenticator{
+				Variant: TransactionAuthenticatorMultiEd25519,
+				Auth:    &MultiEd25519TransactionAuthenticator{Sender: sender},
+			}, nil
+		default:
+			return &TransactionAuthenticator{
+				Variant: TransactionAuthenticatorSingleSender,
+				Auth:    &SingleSenderTransactionAuthenticator{Sender: sender},
+			}, nil
+		}
+	case *RawTransactionWithData:
+		switch inner := txn.Inner.(type) {
+		case *MultiAgentRawTransactionWithData:
+			if len(signers) != len(inner.SecondarySigners)+1 {
+				return nil, fmt.Errorf("multi-agent simulation expects %d public keys, got %d", len(inner.SecondarySigners)+1, len(signers))
+			}
+			secondarySigners, err := simulationAccountAuthenticators(signers[1:])
+			if err != nil {
+				return nil, err
+			}
+			return &TransactionAuthenticator{
+				Variant: TransactionAuthenticatorMultiAgent,
+				Auth: &MultiAgentTransactionAuthenticator{
+					Sender:                   sender,
+					SecondarySignerAddresses: inner.SecondarySigners,
+					SecondarySigners:         secondarySigners,
+				},
+			}, nil
+		case *MultiAgentWithFeePayerRawTransactionWithData:
+			if len(signers) != len(inner.SecondarySigners)+2 {
+				return nil, fmt.Errorf("fee payer simulation expects %d public keys, got %d", len(inner.SecondarySigners)+2, len(signers))
+			}
+			secondarySigners, err := simulationAccountAuthenticators(signers[1 : len(signers)-1])
+			if err != nil {
+				return nil, err
+			}
+			feePayer, err := SimulationAccountAuthenticator(signers[len(signers)-1])
+			if err != nil {
+				return nil, err
+			}
+			return &TransactionAuthenticator{
+				Variant: TransactionAuthenticatorFeePayer,
+				Auth: &FeePayerTransactionAuthenticator{
+					Sender:                   sender,
+					SecondarySignerAddresses: inner.SecondarySigners,
+					SecondarySigners:         secondarySigners,
+					FeePayer:                 inner.FeePayer,
+					FeePayerAuthenticator:    feePayer,
+				},
+			}, nil
+		default:
+			return nil, fmt.Errorf("unknown RawTransactionWithData type %T", txn.Inner)
+		}
+	default:
+		return nil, fmt.Errorf("unknown raw transaction type %T", rawTxn)
+	}
+}
+
+// SimulationAccountAuthenticator builds a [crypto.AccountAuthenticator] for the public key with a zeroed signature
+//
+// Supports [crypto.Ed25519PublicKey], [crypto.MultiEd25519PublicKey], [crypto.AnyPublicKey], and [crypto.MultiKey]
+func SimulationAccountAuthenticator(pubKey crypto.PublicKey) (*crypto.AccountAuthenticator, error) {
+	switch key := pubKey.(type) {
+	case *crypto.Ed25519PublicKey:
+		return &crypto.AccountAuthenticator{
+			Variant: crypto.AccountAuthenticatorEd25519,
+			Auth: &crypto.Ed25519Authenticator{
+				PubKey: key,
+				Sig:    &crypto.Ed25519Signature{},
+			},
+		}, nil
+	case *crypto.MultiEd25519PublicKey:
+		signatures := make([]*crypto.Ed25519Signature, key.SignaturesRequired)
+		bitmap := [crypto.MultiEd25519BitmapLen]byte{}
+		for i := range signatures {
+			signatures[i] = &crypto.Ed25519Signature{}
+			bitmap[i/8] |= 128 >> (i % 8)
+		}
+		return &crypto.AccountAuthenticator{
+			Variant: crypto.AccountAuthenticatorMultiEd25519,
+			Auth: &crypto.MultiEd25519Authenticator{
+				PubKey: key,
+				Sig: &crypto.MultiEd25519Signature{
+					Signatures: signatures,
+					Bitmap:     bitmap,
+				},
+			},
+		}, nil
+	case *crypto.AnyPublicKey:
+		signature, err := simulationAnySignature(key)
+		if err != nil {
+			return nil, err
+		}
+		return &crypto.AccountAuthenticator{
+			Variant: crypto.AccountAuthenticatorSingleSender,
+			Auth: &crypto.SingleKeyAuthenticator{
+				PubKey: key,
+				Sig:    signature,
+			},
+		}, nil
+	case *crypto.MultiKey:
+		signatures := make([]*crypto.AnySignature, key.SignaturesRequired)
+		bitmap := crypto.MultiKeyBitmap{}
+		for i := range signatures {
+			signature, err := simulationAnySignature(key.PubKeys[i])
+			if err != nil {
+				return nil, err
+			}
+			signatures[i] = signature
+			err = bitmap.AddKey(uint8(i))
+			if err != nil {
+				return nil, err
+			}
+		}
+		return &crypto.AccountAuthenticator{
+			Variant: crypto.AccountAuthenticatorMultiKey,
+			Auth: &crypto.MultiKeyAuthenticator{
+				PubKey: key,
+				Sig: &crypto.MultiKeySignature{
+					Signatures: signatures,
+					Bitmap:     bitmap,
+				},
+			},
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported public key type for simulation %T", pubKey)
+	}
+}
+
+func simulationAccountAuthenticators(pubKeys []crypto.PublicKey) ([]crypto.AccountAuthenticator, error) {
+	out := make([]crypto.AccountAuthenticator, len(pubKeys))
+	for i, pubKey := range pubKeys {
+		auth, err := SimulationAccountAuthenticator(pubKey)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = *auth
+	}
+	return out, nil
+}
+
+func simulationAnySignature(key *crypto.AnyPublicKey) (*crypto.AnySignature, error) {
+	switch key.Variant {
+	case crypto.AnyPublicKeyVariantEd25519:
+		return &crypto.AnySignature{
+			Variant:   crypto.AnySignatureVariantEd25519,
+			Signature: &crypto.Ed25519Signature{},
+		}, nil
+	case crypto.AnyPublicKeyVariantSecp256k1:
+		return &crypto.AnySignature{
+			Variant:   crypto.AnySignatureVariantSecp256k1,
+			Signature: &crypto.Secp256k1Signature{Inner: make([]byte, crypto.Secp256k1SignatureLength)},
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported any public key variant for simulation %d", key.Variant)
+	}
+}
+
+//endregion
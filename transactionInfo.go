@@ -0,0 +1,234 @@
+package aptos
+
+import (
+	"fmt"
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+)
+
+//region HashValue
+
+// HashValue is a 32 byte SHA3-256 hash, e.g. a transaction hash or a state root
+// Implements bcs.Struct
+type HashValue []byte
+
+// String returns the hash as a 0x prefixed hex string, matching hashes in the JSON API
+func (h HashValue) String() string {
+	return BytesToHex(h)
+}
+
+//region HashValue bcs.Struct
+
+func (h *HashValue) MarshalBCS(ser *bcs.Serializer) {
+	ser.WriteBytes(*h)
+}
+
+func (h *HashValue) UnmarshalBCS(des *bcs.Deserializer) {
+	*h = des.ReadBytes()
+}
+
+//endregion
+//endregion
+
+//region TransactionInfo
+
+// TransactionInfo is the outcome of an on-chain transaction that is committed to the ledger, without the write set and
+// events themselves
+// Implements bcs.Struct
+type TransactionInfo struct {
+	GasUsed             uint64
+	Status              ExecutionStatus
+	TransactionHash     HashValue
+	EventRootHash       HashValue
+	StateChangeHash     HashValue
+	StateCheckpointHash HashValue // nil if the transaction didn't end a block
+	AuxiliaryInfoHash   HashValue // nil if there is no auxiliary info
+}
+
+// TransactionInfoVariantV0 is the only version of TransactionInfo
+const TransactionInfoVariantV0 uint32 = 0
+
+//region TransactionInfo bcs.Struct
+
+func (info *TransactionInfo) MarshalBCS(ser *bcs.Serializer) {
+	ser.Uleb128(TransactionInfoVariantV0)
+	ser.U64(info.GasUsed)
+	ser.Struct(&info.Status)
+	ser.Struct(&info.TransactionHash)
+	ser.Struct(&info.EventRootHash)
+	ser.Struct(&info.StateChangeHash)
+	marshalOptionalHash(ser, info.StateCheckpointHash)
+	marshalOptionalHash(ser, info.AuxiliaryInfoHash)
+}
+
+func (info *TransactionInfo) UnmarshalBCS(des *bcs.Deserializer) {
+	variant := des.Uleb128()
+	if des.Error() != nil {
+		return
+	}
+	if variant != TransactionInfoVariantV0 {
+		des.SetError(fmt.Errorf("unknown TransactionInfo variant %d", variant))
+		return
+	}
+	info.GasUsed = des.U64()
+	des.Struct(&info.Status)
+	des.Struct(&info.TransactionHash)
+	des.Struct(&info.EventRootHash)
+	des.Struct(&info.StateChangeHash)
+	info.StateCheckpointHash = unmarshalOptionalHash(des)
+	info.AuxiliaryInfoHash = unmarshalOptionalHash(des)
+}
+
+//endregion
+
+func marshalOptionalHash(ser *bcs.Serializer, hash HashValue) {
+	if hash == nil {
+		ser.Bool(false)
+	} else {
+		ser.Bool(true)
+		ser.Struct(&hash)
+	}
+}
+
+func unmarshalOptionalHash(des *bcs.Deserializer) (hash HashValue) {
+	if des.Bool() {
+		des.Struct(&hash)
+	}
+	return
+}
+
+//endregion
+
+//region ExecutionStatus
+
+type ExecutionStatusVariant uint32
+
+const (
+	ExecutionStatusSuccess            ExecutionStatusVariant = 0
+	ExecutionStatusOutOfGas           ExecutionStatusVariant = 1
+	ExecutionStatusMoveAbort          ExecutionStatusVariant = 2
+	ExecutionStatusExecutionFailure   ExecutionStatusVariant = 3
+	ExecutionStatusMiscellaneousError ExecutionStatusVariant = 4
+)
+
+// ExecutionStatus is the VM status of a committed transaction.  Which fields are set depends on the Variant:
+//
+//   - ExecutionStatusMoveAbort sets Location, Code, and AbortInfo if the module has error descriptions
+//   - ExecutionStatusExecutionFailure sets Location, Function, and CodeOffset
+//   - ExecutionStatusMiscellaneousError sets StatusCode if known
+//
+// Implements bcs.Struct
+type ExecutionStatus struct {
+	Variant    ExecutionStatusVariant
+	Location   *ModuleId  // Module aborting or failing, nil for a script
+	Code       uint64     // Abort code
+	AbortInfo  *AbortInfo // Human-readable description of the abort code
+	Function   uint16     // Index of the failing function in the module
+	CodeOffset uint16     // Offset of the failing instruction in the function
+	StatusCode *uint64    // VM status code of a miscellaneous error
+}
+
+// AbortInfo describes a Move abort code from the error map of its module
+type AbortInfo struct {
+	ReasonName  string
+	Description string
+}
+
+// Success returns true if the transaction executed successfully
+func (status *ExecutionStatus) Success() bool {
+	return status.Variant == ExecutionStatusSuccess
+}
+
+//region ExecutionStatus bcs.Struct
+
+func (status *ExecutionStatus) MarshalBCS(ser *bcs.Serializer) {
+	ser.Uleb128(uint32(status.Variant))
+	switch status.Variant {
+	case ExecutionStatusSuccess, ExecutionStatusOutOfGas:
+	case ExecutionStatusMoveAbort:
+		marshalAbortLocation(ser, status.Location)
+		ser.U64(status.Code)
+		if status.AbortInfo == nil {
+			ser.Bool(false)
+		} else {
+			ser.Bool(true)
+			ser.WriteString(status.AbortInfo.ReasonName)
+			ser.WriteString(status.AbortInfo.Description)
+		}
+	case ExecutionStatusExecutionFailure:
+		marshalAbortLocation(ser, status.Location)
+		ser.U16(status.Function)
+		ser.U16(status.CodeOffset)
+	case ExecutionStatusMiscellaneousError:
+		if status.StatusCode == nil {
+			ser.Bool(false)
+		} else {
+			ser.Bool(true)
+			ser.U64(*status.StatusCode)
+		}
+	default:
+		ser.SetError(fmt.Errorf("unknown ExecutionStatus variant %d", status.Variant))
+	}
+}
+
+func (status *ExecutionStatus) UnmarshalBCS(des *bcs.Deserializer) {
+	status.Variant = ExecutionStatusVariant(des.Uleb128())
+	if des.Error() != nil {
+		return
+	}
+	switch status.Variant {
+	case ExecutionStatusSuccess, ExecutionStatusOutOfGas:
+	case ExecutionStatusMoveAbort:
+		status.Location = unmarshalAbortLocation(des)
+		status.Code = des.U64()
+		if des.Bool() {
+			status.AbortInfo = &AbortInfo{
+				ReasonName:  des.ReadString(),
+				Description: des.ReadString(),
+			}
+		}
+	case ExecutionStatusExecutionFailure:
+		status.Location = unmarshalAbortLocation(des)
+		status.Function = des.U16()
+		status.CodeOffset = des.U16()
+	case ExecutionStatusMiscellaneousError:
+		if des.Bool() {
+			code := des.U64()
+			status.StatusCode = &code
+		}
+	default:
+		des.SetError(fmt.Errorf("unknown ExecutionStatus variant %d", status.Variant))
+	}
+}
+
+//endregion
+
+// The abort location is an enum of Module(ModuleId) and Script
+const (
+	abortLocationModule uint32 = 0
+	abortLocationScript uint32 = 1
+)
+
+func marshalAbortLocation(ser *bcs.Serializer, location *ModuleId) {
+	if location == nil {
+		ser.Uleb128(abortLocationScript)
+	} else {
+		ser.Uleb128(abortLocationModule)
+		ser.Struct(location)
+	}
+}
+
+func unmarshalAbortLocation(des *bcs.Deserializer) *ModuleId {
+	switch variant := des.Uleb128(); variant {
+	case abortLocationModule:
+		location := &ModuleId{}
+		des.Struct(location)
+		return location
+	case abortLocationScript:
+		return nil
+	default:
+		des.SetError(fmt.Errorf("unknown AbortLocation variant %d", variant))
+		return nil
+	}
+}
+
+//endregion
@@ -0,0 +1,373 @@
+package aptos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/aptos-labs/aptos-go-sdk/api"
+	"sync"
+)
+
+// TransactionWorkerClient is anything that can build, submit, and wait for transactions, e.g. [Client] or [NodeClient]
+type TransactionWorkerClient interface {
+	AccountClient
+	BuildTransactionCtx(ctx context.Context, sender AccountAddress, payload TransactionPayload, options ...any) (*RawTransaction, error)
+	SubmitTransactionCtx(ctx context.Context, signedTxn *SignedTransaction) (*api.SubmitTransactionResponse, error)
+	WaitForTransactionCtx(ctx context.Context, txnHash string, options ...any) (*api.UserTransaction, error)
+}
+
+// ErrWorkerStopped is returned by [TransactionWorker.Push] once the worker has been stopped
+var ErrWorkerStopped = errors.New("transaction worker stopped")
+
+// Parallelism is an option to NewTransactionWorker, the number of transactions built and submitted at once
+type Parallelism uint
+
+// QueueSize is an option to NewTransactionWorker, the number of payloads that can be pushed before Push waits
+type QueueSize uint
+
+// TransactionOutcomeCallback is an option to NewTransactionWorker, called with every outcome instead of sending it to
+// [TransactionWorker.Outcomes].  It is called concurrently from multiple goroutines.
+type TransactionOutcomeCallback func(outcome TransactionOutcome)
+
+const (
+	defaultWorkerParallelism = 8
+	defaultWorkerQueueSize   = 100
+	// Submissions rejected for their sequence number are rebuilt with a resynced one this many times
+	workerSubmitAttempts = 3
+)
+
+// TransactionOutcomeStatus is how a transaction pushed to a [TransactionWorker] ended up
+type TransactionOutcomeStatus uint8
+
+const (
+	// TransactionOutcomeCommitted is a transaction that was committed and succeeded
+	TransactionOutcomeCommitted TransactionOutcomeStatus = iota
+	// TransactionOutcomeFailed is a transaction that was committed but failed, see [TransactionOutcome.VmStatus]
+	TransactionOutcomeFailed
+	// TransactionOutcomeExpired is a transaction that expired before it was committed
+	TransactionOutcomeExpired
+	// TransactionOutcomeError is a transaction that could not be built, signed, or submitted, or whose commit could not
+	// be determined, see [TransactionOutcome.Err]
+	TransactionOutcomeError
+)
+
+func (status TransactionOutcomeStatus) String() string {
+	switch status {
+	case TransactionOutcomeCommitted:
+		return "committed"
+	case TransactionOutcomeFailed:
+		return "failed"
+	case TransactionOutcomeExpired:
+		return "expired"
+	case TransactionOutcomeError:
+		return "error"
+	default:
+		return fmt.Sprintf("TransactionOutcomeStatus(%d)", uint8(status))
+	}
+}
+
+// TransactionOutcome is the result of a payload pushed to a [TransactionWorker]
+type TransactionOutcome struct {
+	Id      string                   // Id the payload was pushed with, to correlate the outcome with the payload
+	Payload TransactionPayload       // Payload as pushed
+	Status  TransactionOutcomeStatus // Status is whether it committed, failed, expired, or hit an error
+
+	Hash           string               // Hash of the submitted transaction, empty if it was never submitted
+	SequenceNumber uint64               // SequenceNumber of the submitted transaction
+	Transaction    *api.UserTransaction // Transaction as committed, for TransactionOutcomeCommitted and TransactionOutcomeFailed
+	VmStatus       string               // VmStatus of the committed transaction, which explains a TransactionOutcomeFailed
+	Err            error                // Err is nil for TransactionOutcomeCommitted, otherwise what went wrong
+}
+
+// workerItem is a payload waiting in the queue
+type workerItem struct {
+	id      string
+	payload TransactionPayload
+}
+
+// TransactionWorker builds, signs, submits, and waits for transactions from one sender, so that many payloads can be
+// sent without writing the loop around [NodeClient.BuildTransaction], [RawTransaction.SignedTransaction],
+// [NodeClient.SubmitTransaction], and [NodeClient.WaitForTransaction] each time.
+//
+// Payloads are pushed onto a queue with a correlation id, and built and submitted by Parallelism goroutines, 8 by
+// default, with sequence numbers from an [AccountSequenceNumber].  Each submitted transaction is waited for in
+// [WaitModeCommitted], and its [TransactionOutcome] is sent to [TransactionWorker.Outcomes], or to a
+// TransactionOutcomeCallback if there is one.
+//
+//	worker, err := NewTransactionWorker(client, sender, MaxInFlight(50))
+//	worker.Start(ctx)
+//	go func() {
+//		for _, transfer := range transfers {
+//			_ = worker.Push(transfer.Id, transfer.Payload)
+//		}
+//		worker.Stop()
+//	}()
+//	for outcome := range worker.Outcomes() {
+//		fmt.Printf("%s %s %s\n", outcome.Id, outcome.Status, outcome.Hash)
+//	}
+type TransactionWorker struct {
+	client          TransactionWorkerClient
+	sender          TransactionSigner
+	sequenceNumbers *AccountSequenceNumber
+	parallelism     uint
+	buildOptions    []any
+	waitOptions     []any
+	callback        TransactionOutcomeCallback
+
+	queue    chan workerItem
+	outcomes chan TransactionOutcome
+
+	lock     sync.RWMutex // Read locked while pushing onto the queue, write locked to start the worker or close the queue
+	stopped  bool
+	done     chan struct{}
+	stopOnce sync.Once
+	started  bool
+	workers  sync.WaitGroup
+	waiters  sync.WaitGroup
+}
+
+// NewTransactionWorker creates a worker for transactions from the sender, which processes nothing until
+// [TransactionWorker.Start].
+//
+// Accepts options Parallelism, QueueSize, TransactionOutcomeCallback, MaxInFlight for the sequence numbers, PollPeriod
+// and PollTimeout for waiting, and the options of [NodeClient.BuildTransaction] except SequenceNumber, including any
+// [BuildOption] other than WithSequenceNumber, WithAccountSequenceNumber, WithFeePayer, and WithSecondarySigners.  An *AccountSequenceNumber for the sender can be given to share it with other code sending from the
+// same account, otherwise the worker creates its own.
+func NewTransactionWorker(client TransactionWorkerClient, sender TransactionSigner, options ...any) (*TransactionWorker, error) {
+	worker := &TransactionWorker{
+		client:      client,
+		sender:      sender,
+		parallelism: defaultWorkerParallelism,
+		waitOptions: []any{WaitModeCommitted},
+		done:        make(chan struct{}),
+	}
+	queueSize := uint(defaultWorkerQueueSize)
+	var sequenceNumberOptions []any
+	for i, arg := range options {
+		switch value := arg.(type) {
+		case Parallelism:
+			worker.parallelism = uint(value)
+		case QueueSize:
+			queueSize = uint(value)
+		case TransactionOutcomeCallback:
+			worker.callback = value
+		case MaxInFlight:
+			sequenceNumberOptions = append(sequenceNumberOptions, value)
+		case PollPeriod, PollTimeout:
+			worker.waitOptions = append(worker.waitOptions, value)
+		case *AccountSequenceNumber:
+			worker.sequenceNumbers = value
+		case MaxGasAmount, GasUnitPrice, ExpirationSeconds, ChainIdOption, EstimateGasUnitPrice, EstimateMaxGasAmount:
+			worker.buildOptions = append(worker.buildOptions, value)
+		case BuildOption:
+			// Options the worker can't build every transaction with would fail every push, so reject them up front
+			config := &buildConfig{}
+			err := value(config)
+			if err != nil {
+				return nil, err
+			}
+			if config.haveSequenceNumber || config.sequenceNumbers != nil {
+				return nil, fmt.Errorf("NewTransactionWorker arg %d sets the sequence number, which the worker manages", i+1)
+			}
+			if config.feePayer != nil || len(config.secondarySigners) != 0 {
+				return nil, fmt.Errorf("NewTransactionWorker arg %d sets a fee payer or secondary signers, the worker only sends single signer transactions", i+1)
+			}
+			worker.buildOptions = append(worker.buildOptions, value)
+		default:
+			return nil, fmt.Errorf("NewTransactionWorker arg %d bad type %T", i+1, arg)
+		}
+	}
+	if worker.parallelism == 0 {
+		return nil, errors.New("NewTransactionWorker Parallelism must be at least 1")
+	}
+	// Conflicting build options would fail every push, so check them up front
+	buildOptions, err := buildOptionsFromAny("NewTransactionWorker", worker.buildOptions)
+	if err != nil {
+		return nil, err
+	}
+	_, err = newBuildConfig(buildOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	if worker.sequenceNumbers == nil {
+		worker.sequenceNumbers, err = NewAccountSequenceNumber(client, sender.AccountAddress(), sequenceNumberOptions...)
+		if err != nil {
+			return nil, err
+		}
+	} else if len(sequenceNumberOptions) != 0 {
+		return nil, errors.New("NewTransactionWorker MaxInFlight cannot be used with an AccountSequenceNumber")
+	} else if worker.sequenceNumbers.Address() != sender.AccountAddress() {
+		return nil, fmt.Errorf("AccountSequenceNumber is for %s, not the sender %s", worker.sequenceNumbers.Address(), sender.AccountAddress())
+	}
+
+	worker.queue = make(chan workerItem, queueSize)
+	if worker.callback == nil {
+		worker.outcomes = make(chan TransactionOutcome)
+	}
+	return worker, nil
+}
+
+// Start starts processing the queue until [TransactionWorker.Stop].  Cancelling the context abandons the transactions
+// in progress, which get a TransactionOutcomeError outcome.
+func (worker *TransactionWorker) Start(ctx context.Context) {
+	worker.lock.Lock()
+	defer worker.lock.Unlock()
+	if worker.started || worker.stopped {
+		return
+	}
+	worker.started = true
+	for i := uint(0); i < worker.parallelism; i++ {
+		worker.workers.Add(1)
+		go worker.run(ctx)
+	}
+}
+
+// Push adds a payload to the queue, waiting while the queue is full.  The id is returned in the payload's
+// [TransactionOutcome] to correlate the two, it doesn't need to be unique.
+func (worker *TransactionWorker) Push(id string, payload TransactionPayload) error {
+	return worker.PushCtx(context.Background(), id, payload)
+}
+
+// PushCtx is [TransactionWorker.Push] with a [context.Context], cancelling the context stops waiting for the queue
+func (worker *TransactionWorker) PushCtx(ctx context.Context, id string, payload TransactionPayload) error {
+	worker.lock.RLock()
+	defer worker.lock.RUnlock()
+	if worker.stopped {
+		return ErrWorkerStopped
+	}
+	select {
+	case worker.queue <- workerItem{id: id, payload: payload}:
+		return nil
+	case <-worker.done:
+		return ErrWorkerStopped
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Outcomes delivers the outcome of every pushed payload, in the order they finish, and is closed after
+// [TransactionWorker.Stop] once all are delivered.  It must be drained, or the worker stalls.  It is nil if there is a
+// TransactionOutcomeCallback.
+func (worker *TransactionWorker) Outcomes() <-chan TransactionOutcome {
+	return worker.outcomes
+}
+
+// SequenceNumbers returns the sender's sequence number manager
+func (worker *TransactionWorker) SequenceNumbers() *AccountSequenceNumber {
+	return worker.sequenceNumbers
+}
+
+// Stop stops accepting payloads, and waits for the ones already pushed to be submitted and their outcomes delivered.
+// Payloads still queued on a worker that was never started are dropped.  It is safe to call more than once.
+func (worker *TransactionWorker) Stop() {
+	worker.stopOnce.Do(func() {
+		// Wake any Push waiting on a full queue, so that the lock can be taken
+		close(worker.done)
+		worker.lock.Lock()
+		worker.stopped = true
+		close(worker.queue)
+		worker.lock.Unlock()
+
+		worker.workers.Wait()
+		worker.waiters.Wait()
+		if worker.outcomes != nil {
+			close(worker.outcomes)
+		}
+	})
+}
+
+// run builds and submits payloads from the queue until it is closed
+func (worker *TransactionWorker) run(ctx context.Context) {
+	defer worker.workers.Done()
+	for item := range worker.queue {
+		outcome, rawTxn := worker.submit(ctx, item)
+		if rawTxn == nil {
+			worker.deliver(outcome)
+			continue
+		}
+		// Wait separately, so that the next payload can be submitted while this one commits
+		worker.waiters.Add(1)
+		go func() {
+			defer worker.waiters.Done()
+			worker.deliver(worker.wait(ctx, outcome, rawTxn))
+		}()
+	}
+}
+
+// submit builds, signs, and submits the payload, returning the transaction if it was submitted.  A sequence number
+// that can't reach chain, because its transaction wasn't sent or the node rejected it, is released, so that the
+// transactions after it aren't stuck waiting on the gap.
+func (worker *TransactionWorker) submit(ctx context.Context, item workerItem) (TransactionOutcome, *RawTransaction) {
+	outcome := TransactionOutcome{Id: item.id, Payload: item.payload, Status: TransactionOutcomeError}
+	options := append([]any{worker.sequenceNumbers}, worker.buildOptions...)
+	for attempt := 1; ; attempt++ {
+		rawTxn, err := worker.client.BuildTransactionCtx(ctx, worker.sender.AccountAddress(), item.payload, options...)
+		if err != nil {
+			outcome.Err = err
+			return outcome, nil
+		}
+		outcome.SequenceNumber = rawTxn.SequenceNumber
+		signedTxn, err := rawTxn.SignedTransaction(worker.sender)
+		if err != nil {
+			worker.sequenceNumbers.Release(rawTxn.SequenceNumber)
+			outcome.Err = err
+			return outcome, nil
+		}
+		response, err := worker.client.SubmitTransactionCtx(ctx, signedTxn)
+		if err == nil {
+			outcome.Hash = response.Hash
+			return outcome, rawTxn
+		}
+		outcome.Err = err
+		resynced, resyncErr := worker.sequenceNumbers.HandleErrorCtx(ctx, err)
+		if resyncErr != nil {
+			worker.sequenceNumbers.Release(rawTxn.SequenceNumber)
+			outcome.Err = errors.Join(err, resyncErr)
+			return outcome, nil
+		}
+		if !resynced {
+			// A transaction that may have reached mempool keeps its sequence number, until it expires and a resync
+			// takes it back
+			if isSubmissionRejected(err) {
+				worker.sequenceNumbers.Release(rawTxn.SequenceNumber)
+			}
+			return outcome, nil
+		}
+		if attempt >= workerSubmitAttempts {
+			return outcome, nil
+		}
+	}
+}
+
+// wait waits for the submitted transaction to be committed or expire
+func (worker *TransactionWorker) wait(ctx context.Context, outcome TransactionOutcome, rawTxn *RawTransaction) TransactionOutcome {
+	options := append([]any{TransactionExpiration(rawTxn.ExpirationTimestampSeconds)}, worker.waitOptions...)
+	txn, err := worker.client.WaitForTransactionCtx(ctx, outcome.Hash, options...)
+	outcome.Err = err
+	var failed *TransactionFailedError
+	switch {
+	case err == nil:
+		outcome.Status = TransactionOutcomeCommitted
+		outcome.Transaction = txn
+		outcome.VmStatus = txn.VmStatus
+	case errors.As(err, &failed):
+		outcome.Status = TransactionOutcomeFailed
+		outcome.Transaction = failed.Transaction
+		outcome.VmStatus = failed.VmStatus
+	case errors.Is(err, ErrTransactionExpired):
+		outcome.Status = TransactionOutcomeExpired
+		// The expired sequence number was never used, so the ones after it can't commit until it's reused
+		_ = worker.sequenceNumbers.ResyncCtx(ctx)
+	}
+	return outcome
+}
+
+// deliver passes the outcome to the callback or the outcomes channel
+func (worker *TransactionWorker) deliver(outcome TransactionOutcome) {
+	if worker.callback != nil {
+		worker.callback(outcome)
+		return
+	}
+	worker.outcomes <- outcome
+}
@@ -0,0 +1,214 @@
+package aptos
+
+import (
+	"context"
+	"fmt"
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTransactionWorker(t *testing.T) {
+	sender, err := NewEd25519Account()
+	assert.NoError(t, err)
+
+	// The amount transferred decides what happens to each transaction
+	var mutex sync.Mutex
+	amounts := map[string]uint64{}
+	accepted := map[uint64]bool{}
+	var rejected atomic.Bool
+	// Accepted transactions commit right away once the ones before them have, like mempool parking a transaction
+	// until the gap before it is filled
+	var onChain atomic.Uint64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		now := uint64(time.Now().UnixMicro())
+		w.Header().Set(HeaderAptosLedgerVersion, "100")
+		w.Header().Set(HeaderAptosLedgerTimestampUsec, strconv.FormatUint(now, 10))
+		switch {
+		case r.URL.Path == "/v1":
+			_, _ = fmt.Fprintf(w, `{"chain_id":4,"epoch":"1","ledger_version":"100","oldest_ledger_version":"0","ledger_timestamp":"%d","node_role":"full_node","oldest_block_height":"0","block_height":"5","git_hash":"abc"}`, now)
+		case r.URL.Path == "/v1/accounts/"+sender.Address.String():
+			_, _ = fmt.Fprintf(w, `{"sequence_number":"%d","authentication_key":"%s"}`, onChain.Load(), sender.Address.String())
+		case r.URL.Path == "/v1/transactions" && r.Method == http.MethodPost:
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			signedTxn := &SignedTransaction{}
+			assert.NoError(t, bcs.Deserialize(signedTxn, body))
+			assert.NoError(t, signedTxn.Verify())
+			rawTxn := signedTxn.Transaction.(*RawTransaction)
+			amount := bcs.NewDeserializer(rawTxn.Payload.Payload.(*EntryFunction).Args[1]).U64()
+			switch {
+			case amount == 4 && !rejected.Swap(true):
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"message":"Invalid transaction: Type: Validation Code: SEQUENCE_NUMBER_TOO_OLD","error_code":"vm_error","vm_error_code":3}`))
+				return
+			case amount == 5:
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"message":"Invalid transaction: Type: Validation Code: INSUFFICIENT_BALANCE_FOR_TRANSACTION_FEE","error_code":"vm_error","vm_error_code":5}`))
+				return
+			}
+			hash, err := signedTxn.Hash()
+			assert.NoError(t, err)
+			mutex.Lock()
+			amounts[hash] = amount
+			accepted[rawTxn.SequenceNumber] = true
+			for accepted[onChain.Load()] {
+				onChain.Add(1)
+			}
+			mutex.Unlock()
+			w.WriteHeader(http.StatusAccepted)
+			_, _ = fmt.Fprintf(w, `{"hash":"%s","sequence_number":"%d"}`, hash, rawTxn.SequenceNumber)
+		case strings.HasPrefix(r.URL.Path, "/v1/transactions/wait_by_hash/"):
+			hash := strings.TrimPrefix(r.URL.Path, "/v1/transactions/wait_by_hash/")
+			mutex.Lock()
+			amount := amounts[hash]
+			mutex.Unlock()
+			switch amount {
+			case 2:
+				_, _ = fmt.Fprintf(w, `{"type":"user_transaction","version":"8","hash":"%s","success":false,"vm_status":"Move abort in 0x1::coin: EINSUFFICIENT_BALANCE(0x10006)"}`, hash)
+			case 3:
+				// The ledger is past the expiration without the transaction
+				w.Header().Set(HeaderAptosLedgerTimestampUsec, strconv.FormatUint(now+3600_000_000, 10))
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"message":"Transaction not found","error_code":"transaction_not_found"}`))
+			default:
+				_, _ = fmt.Fprintf(w, `{"type":"user_transaction","version":"7","hash":"%s","success":true,"vm_status":"Executed successfully"}`, hash)
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	client, err := NewNodeClient(server.URL+"/v1", 4)
+	assert.NoError(t, err)
+
+	worker, err := NewTransactionWorker(client, sender, Parallelism(4), MaxInFlight(10), GasUnitPrice(100), MaxGasAmount(1000))
+	assert.NoError(t, err)
+	worker.Start(context.Background())
+	go func() {
+		for i := 0; i < 20; i++ {
+			amount := uint64(1)
+			if i < 5 {
+				amount = uint64(i + 1)
+			}
+			payload, err := CoinTransferPayload(nil, AccountOne, amount)
+			assert.NoError(t, err)
+			assert.NoError(t, worker.Push(fmt.Sprintf("transfer-%d", i), TransactionPayload{Payload: payload}))
+		}
+		worker.Stop()
+	}()
+
+	outcomes := map[string]TransactionOutcome{}
+	for outcome := range worker.Outcomes() {
+		outcomes[outcome.Id] = outcome
+	}
+	assert.Len(t, outcomes, 20)
+	assert.Equal(t, TransactionOutcomeCommitted, outcomes["transfer-0"].Status)
+	assert.NoError(t, outcomes["transfer-0"].Err)
+	assert.Equal(t, uint64(7), outcomes["transfer-0"].Transaction.Version)
+	assert.NotEmpty(t, outcomes["transfer-0"].Hash)
+
+	assert.Equal(t, TransactionOutcomeFailed, outcomes["transfer-1"].Status)
+	assert.Contains(t, outcomes["transfer-1"].VmStatus, "EINSUFFICIENT_BALANCE")
+
+	assert.Equal(t, TransactionOutcomeExpired, outcomes["transfer-2"].Status)
+	assert.ErrorIs(t, outcomes["transfer-2"].Err, ErrTransactionExpired)
+
+	// Rebuilt after its sequence number was rejected
+	assert.Equal(t, TransactionOutcomeCommitted, outcomes["transfer-3"].Status)
+	assert.True(t, rejected.Load())
+
+	assert.Equal(t, TransactionOutcomeError, outcomes["transfer-4"].Status)
+	assert.Error(t, outcomes["transfer-4"].Err)
+	assert.Empty(t, outcomes["transfer-4"].Hash)
+
+	for i := 5; i < 20; i++ {
+		assert.Equal(t, TransactionOutcomeCommitted, outcomes[fmt.Sprintf("transfer-%d", i)].Status)
+	}
+
+	payload, err := CoinTransferPayload(nil, AccountOne, 1)
+	assert.NoError(t, err)
+	assert.ErrorIs(t, worker.Push("late", TransactionPayload{Payload: payload}), ErrWorkerStopped)
+
+	// Outcomes can go to a callback instead
+	var committed atomic.Int32
+	worker, err = NewTransactionWorker(client, sender, GasUnitPrice(100), MaxGasAmount(1000),
+		TransactionOutcomeCallback(func(outcome TransactionOutcome) {
+			if outcome.Status == TransactionOutcomeCommitted {
+				committed.Add(1)
+			}
+		}))
+	assert.NoError(t, err)
+	assert.Nil(t, worker.Outcomes())
+	worker.Start(context.Background())
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, worker.Push(strconv.Itoa(i), TransactionPayload{Payload: payload}))
+	}
+	worker.Stop()
+	assert.Equal(t, int32(3), committed.Load())
+
+	// A rejected transaction's sequence number is released and reused, rather than leaving a gap that the
+	// transactions after it would be stuck behind
+	rejectedPayload, err := CoinTransferPayload(nil, AccountOne, 5)
+	assert.NoError(t, err)
+	worker, err = NewTransactionWorker(client, sender, Parallelism(1), GasUnitPrice(100), MaxGasAmount(1000),
+		TransactionOutcomeCallback(func(outcome TransactionOutcome) {}))
+	assert.NoError(t, err)
+	worker.Start(context.Background())
+	assert.NoError(t, worker.Push("rejected", TransactionPayload{Payload: rejectedPayload}))
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, worker.Push(strconv.Itoa(i), TransactionPayload{Payload: payload}))
+	}
+	worker.Stop()
+	mutex.Lock()
+	for sequenceNumber := range accepted {
+		assert.Less(t, sequenceNumber, onChain.Load(), "sequence number %d is stuck behind a gap", sequenceNumber)
+	}
+	mutex.Unlock()
+
+	// A client without a chain id looks it up once, while building transactions in parallel
+	unknownChain, err := NewNodeClient(server.URL+"/v1", 0)
+	assert.NoError(t, err)
+	committed.Store(0)
+	worker, err = NewTransactionWorker(unknownChain, sender, Parallelism(4), GasUnitPrice(100), MaxGasAmount(1000),
+		TransactionOutcomeCallback(func(outcome TransactionOutcome) {
+			if outcome.Status == TransactionOutcomeCommitted {
+				committed.Add(1)
+			}
+		}))
+	assert.NoError(t, err)
+	worker.Start(context.Background())
+	for i := 0; i < 8; i++ {
+		assert.NoError(t, worker.Push(strconv.Itoa(i), TransactionPayload{Payload: payload}))
+	}
+	worker.Stop()
+	assert.Equal(t, int32(8), committed.Load())
+	chainId, err := unknownChain.GetChainId()
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(4), chainId)
+
+	_, err = NewTransactionWorker(client, sender, SequenceNumber(1))
+	assert.Error(t, err)
+	_, err = NewTransactionWorker(client, sender, Parallelism(0))
+	assert.Error(t, err)
+	_, err = NewTransactionWorker(client, sender, WithSequenceNumber(1))
+	assert.Error(t, err)
+	_, err = NewTransactionWorker(client, sender, WithAccountSequenceNumber(worker.SequenceNumbers()))
+	assert.Error(t, err)
+	_, err = NewTransactionWorker(client, sender, WithFeePayer(AccountTwo))
+	assert.Error(t, err)
+	_, err = NewTransactionWorker(client, sender, MaxGasAmount(1000), EstimateMaxGasAmount{Multiplier: 1.5})
+	assert.Error(t, err)
+	other, err := NewAccountSequenceNumber(client, AccountOne)
+	assert.NoError(t, err)
+	_, err = NewTransactionWorker(client, sender, other)
+	assert.Error(t, err)
+}
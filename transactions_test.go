@@ -3,6 +3,7 @@ package aptos
 import (
 	"encoding/binary"
 	"github.com/aptos-labs/aptos-go-sdk/bcs"
+	"github.com/aptos-labs/aptos-go-sdk/crypto"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
@@ -69,3 +70,83 @@ func TestTPMarshal(t *testing.T) {
 	// without a payload, it should fail
 	assert.Error(t, ser.Error())
 }
+
+func TestSimulationTransactionAuthenticator(t *testing.T) {
+	for name, createSigner := range TestSigners {
+		signer, err := createSigner()
+		assert.NoError(t, err, name)
+
+		rawTxn := &RawTransaction{
+			Sender:         signer.AccountAddress(),
+			SequenceNumber: 1,
+			Payload: TransactionPayload{Payload: &EntryFunction{
+				Module:   ModuleId{Address: AccountOne, Name: "aptos_account"},
+				Function: "transfer",
+				ArgTypes: []TypeTag{},
+				Args:     [][]byte{},
+			}},
+			MaxGasAmount:               1000,
+			GasUnitPrice:               100,
+			ExpirationTimestampSeconds: 1714158778,
+			ChainId:                    4,
+		}
+
+		auth, err := SimulationTransactionAuthenticator(rawTxn, signer.PubKey())
+		assert.NoError(t, err, name)
+		signedTxn := &SignedTransaction{Transaction: rawTxn, Authenticator: auth}
+
+		// The zeroed signature must round trip through BCS
+		txnBytes, err := bcs.Serialize(signedTxn)
+		assert.NoError(t, err, name)
+		signedTxn2 := &SignedTransaction{Transaction: &RawTransaction{}, Authenticator: &TransactionAuthenticator{}}
+		assert.NoError(t, bcs.Deserialize(signedTxn2, txnBytes), name)
+		txnBytes2, err := bcs.Serialize(signedTxn2)
+		assert.NoError(t, err, name)
+		assert.Equal(t, txnBytes, txnBytes2, name)
+
+		// Too many keys for a single sender
+		_, err = SimulationTransactionAuthenticator(rawTxn, signer.PubKey(), signer.PubKey())
+		assert.Error(t, err, name)
+	}
+}
+
+func TestSimulationTransactionAuthenticatorFeePayer(t *testing.T) {
+	sender, err := NewEd25519Account()
+	assert.NoError(t, err)
+	secondary, err := NewSecp256k1Account()
+	assert.NoError(t, err)
+	feePayer, err := NewEd25519SingleSenderAccount()
+	assert.NoError(t, err)
+	feePayerAddress := feePayer.AccountAddress()
+
+	rawTxn := &RawTransactionWithData{
+		Variant: MultiAgentWithFeePayerRawTransactionWithDataVariant,
+		Inner: &MultiAgentWithFeePayerRawTransactionWithData{
+			RawTxn: &RawTransaction{
+				Sender: sender.AccountAddress(),
+				Payload: TransactionPayload{Payload: &EntryFunction{
+					Module:   ModuleId{Address: AccountOne, Name: "aptos_account"},
+					Function: "transfer",
+					ArgTypes: []TypeTag{},
+					Args:     [][]byte{},
+				}},
+				ChainId: 4,
+			},
+			SecondarySigners: []AccountAddress{secondary.AccountAddress()},
+			FeePayer:         &feePayerAddress,
+		},
+	}
+
+	auth, err := SimulationTransactionAuthenticator(rawTxn, sender.PubKey(), secondary.PubKey(), feePayer.PubKey())
+	assert.NoError(t, err)
+	assert.Equal(t, TransactionAuthenticatorFeePayer, auth.Variant)
+	feePayerAuth := auth.Auth.(*FeePayerTransactionAuthenticator)
+	assert.Equal(t, &feePayerAddress, feePayerAuth.FeePayer)
+	assert.Equal(t, crypto.AccountAuthenticatorEd25519, feePayerAuth.Sender.Variant)
+	assert.Equal(t, crypto.AccountAuthenticatorSingleSender, feePayerAuth.SecondarySigners[0].Variant)
+	assert.Equal(t, crypto.AccountAuthenticatorSingleSender, feePayerAuth.FeePayerAuthenticator.Variant)
+
+	// Missing the fee payer key
+	_, err = SimulationTransactionAuthenticator(rawTxn, sender.PubKey(), secondary.PubKey())
+	assert.Error(t, err)
+}
@@ -3,6 +3,7 @@ package aptos
 import (
 	"fmt"
 	"github.com/aptos-labs/aptos-go-sdk/bcs"
+	"strconv"
 	"strings"
 )
 
@@ -455,3 +456,139 @@ var AptosCoinTypeTag = TypeTag{&StructTag{
 }}
 
 //endregion
+
+//region TypeTag parsing
+
+// ParseTypeTag parses a Move type from its string form, e.g. u64, vector<u8>, or
+// 0x1::coin::CoinStore<0x1::aptos_coin::AptosCoin>
+func ParseTypeTag(typeStr string) (*TypeTag, error) {
+	return parseTypeTag(typeStr, nil)
+}
+
+// parseTypeTag parses a Move type, replacing the generic type parameters T0, T1, ... with typeParams
+func parseTypeTag(typeStr string, typeParams []TypeTag) (*TypeTag, error) {
+	parser := &typeTagParser{input: typeStr, typeParams: typeParams}
+	tag, err := parser.parseType()
+	if err != nil {
+		return nil, fmt.Errorf("invalid type %s: %w", typeStr, err)
+	}
+	parser.skipSpaces()
+	if parser.pos != len(parser.input) {
+		return nil, fmt.Errorf("invalid type %s: unexpected %q at %d", typeStr, parser.input[parser.pos:], parser.pos)
+	}
+	return tag, nil
+}
+
+type typeTagParser struct {
+	input      string
+	pos        int
+	typeParams []TypeTag
+}
+
+func (p *typeTagParser) skipSpaces() {
+	for p.pos < len(p.input) && p.input[p.pos] == ' ' {
+		p.pos++
+	}
+}
+
+// consume skips the given character if it's next
+func (p *typeTagParser) consume(c byte) bool {
+	p.skipSpaces()
+	if p.pos < len(p.input) && p.input[p.pos] == c {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *typeTagParser) parseType() (*TypeTag, error) {
+	p.skipSpaces()
+	start := p.pos
+	for p.pos < len(p.input) && !strings.ContainsRune("<>, ", rune(p.input[p.pos])) {
+		p.pos++
+	}
+	name := p.input[start:p.pos]
+
+	switch name {
+	case "":
+		return nil, fmt.Errorf("missing type at %d", start)
+	case "bool":
+		return &TypeTag{Value: &BoolTag{}}, nil
+	case "u8":
+		return &TypeTag{Value: &U8Tag{}}, nil
+	case "u16":
+		return &TypeTag{Value: &U16Tag{}}, nil
+	case "u32":
+		return &TypeTag{Value: &U32Tag{}}, nil
+	case "u64":
+		return &TypeTag{Value: &U64Tag{}}, nil
+	case "u128":
+		return &TypeTag{Value: &U128Tag{}}, nil
+	case "u256":
+		return &TypeTag{Value: &U256Tag{}}, nil
+	case "address":
+		return &TypeTag{Value: &AddressTag{}}, nil
+	case "signer":
+		return &TypeTag{Value: &SignerTag{}}, nil
+	case "vector":
+		typeArgs, err := p.parseTypeArgs()
+		if err != nil {
+			return nil, err
+		}
+		if len(typeArgs) != 1 {
+			return nil, fmt.Errorf("vector takes 1 type argument, got %d", len(typeArgs))
+		}
+		return &TypeTag{Value: &VectorTag{TypeParam: typeArgs[0]}}, nil
+	}
+
+	// Generic type parameter e.g. T0
+	if p.typeParams != nil && name[0] == 'T' {
+		if index, err := strconv.Atoi(name[1:]); err == nil {
+			if index < 0 || index >= len(p.typeParams) {
+				return nil, fmt.Errorf("missing type argument for %s", name)
+			}
+			typeParam := p.typeParams[index]
+			return &typeParam, nil
+		}
+	}
+
+	parts := strings.Split(name, "::")
+	if len(parts) != 3 || parts[1] == "" || parts[2] == "" {
+		return nil, fmt.Errorf("unknown type %s", name)
+	}
+	tag := &StructTag{Module: parts[1], Name: parts[2], TypeParams: []TypeTag{}}
+	if err := tag.Address.ParseStringRelaxed(parts[0]); err != nil {
+		return nil, fmt.Errorf("invalid address in %s: %w", name, err)
+	}
+	if p.pos < len(p.input) && p.input[p.pos] == '<' {
+		typeArgs, err := p.parseTypeArgs()
+		if err != nil {
+			return nil, err
+		}
+		tag.TypeParams = typeArgs
+	}
+	return &TypeTag{Value: tag}, nil
+}
+
+// parseTypeArgs parses a list of types e.g. <u8, T0>
+func (p *typeTagParser) parseTypeArgs() ([]TypeTag, error) {
+	if !p.consume('<') {
+		return nil, fmt.Errorf("expected < at %d", p.pos)
+	}
+	typeArgs := make([]TypeTag, 0)
+	for {
+		typeArg, err := p.parseType()
+		if err != nil {
+			return nil, err
+		}
+		typeArgs = append(typeArgs, *typeArg)
+		if p.consume('>') {
+			return typeArgs, nil
+		}
+		if !p.consume(',') {
+			return nil, fmt.Errorf("expected , or > at %d", p.pos)
+		}
+	}
+}
+
+//endregion
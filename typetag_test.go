@@ -88,3 +88,36 @@ func TestInvalidTypeTag(t *testing.T) {
 	err := bcs.Deserialize(tag, bytes)
 	assert.Error(t, err)
 }
+
+func TestParseTypeTag(t *testing.T) {
+	for _, typeStr := range []string{
+		"bool", "u8", "u16", "u32", "u64", "u128", "u256", "address", "signer",
+		"vector<u8>",
+		"vector<vector<0x1::string::String>>",
+		"0x1::aptos_coin::AptosCoin",
+		"0x1::coin::CoinStore<0x1::aptos_coin::AptosCoin>",
+		"0x1::option::Option<vector<0x1::object::Object<0x1::string::String>>>",
+		"0x3::other::Pair<u8,0x1::string::String>",
+	} {
+		tag, err := ParseTypeTag(typeStr)
+		assert.NoError(t, err)
+		assert.Equal(t, typeStr, tag.String())
+	}
+
+	// Spaces and long addresses are accepted
+	tag, err := ParseTypeTag("0x0000000000000000000000000000000000000000000000000000000000000003::other::Pair< u8, vector<u64> >")
+	assert.NoError(t, err)
+	assert.Equal(t, "0x3::other::Pair<u8,vector<u64>>", tag.String())
+
+	for _, typeStr := range []string{"", "u7", "vector", "vector<u8", "vector<u8,u8>", "0x1::coin", "0x1::coin::Coin<>", "u8>", "T0"} {
+		_, err = ParseTypeTag(typeStr)
+		assert.Error(t, err, typeStr)
+	}
+
+	// Generic type parameters are substituted
+	tag, err = parseTypeTag("0x1::coin::Coin<T1>", []TypeTag{{Value: &U8Tag{}}, AptosCoinTypeTag})
+	assert.NoError(t, err)
+	assert.Equal(t, "0x1::coin::Coin<0x1::aptos_coin::AptosCoin>", tag.String())
+	_, err = parseTypeTag("T2", []TypeTag{})
+	assert.Error(t, err)
+}
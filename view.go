@@ -0,0 +1,290 @@
+package aptos
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ViewClient is anything that can call view functions, e.g. [Client] or [NodeClient]
+type ViewClient interface {
+	ViewCtx(ctx context.Context, payload *ViewPayload, ledgerVersion ...uint64) ([]any, error)
+}
+
+// ViewInto calls a view function and decodes its return values into T with [DecodeMoveValue].
+//
+// If T is a struct, other than [big.Int], its exported fields receive the return values in order, skipping fields tagged
+// `move:"-"`.  Otherwise, the view function must return a single value, which is decoded into T.  To decode a single
+// returned Move struct, wrap it in a struct with one field.
+//
+//	balance, err := ViewInto[uint64](client, &ViewPayload{
+//		Module:   ModuleId{Address: AccountOne, Name: "coin"},
+//		Function: "balance",
+//		ArgTypes: []TypeTag{AptosCoinTypeTag},
+//		Args:     [][]byte{address[:]},
+//	})
+//
+//	type supply struct {
+//		Current *big.Int
+//		Maximum *big.Int // Option<u128> is nil if not set
+//	}
+//	result, err := ViewInto[supply](client, payload)
+func ViewInto[T any](client ViewClient, payload *ViewPayload, ledgerVersion ...uint64) (result T, err error) {
+	return ViewIntoCtx[T](context.Background(), client, payload, ledgerVersion...)
+}
+
+// ViewIntoCtx is [ViewInto] with a [context.Context] for cancellation and deadlines
+func ViewIntoCtx[T any](ctx context.Context, client ViewClient, payload *ViewPayload, ledgerVersion ...uint64) (result T, err error) {
+	values, err := client.ViewCtx(ctx, payload, ledgerVersion...)
+	if err != nil {
+		return result, err
+	}
+	err = decodeViewValues(values, reflect.ValueOf(&result).Elem())
+	if err != nil {
+		return result, fmt.Errorf("failed to decode %s::%s result into %T: %w", payload.Module.Name, payload.Function, result, err)
+	}
+	return result, nil
+}
+
+func decodeViewValues(values []any, dest reflect.Value) error {
+	if dest.Kind() != reflect.Struct || dest.Type() == bigIntType {
+		if len(values) != 1 {
+			return fmt.Errorf("expected 1 return value, got %d", len(values))
+		}
+		return decodeMoveValue(values[0], dest)
+	}
+
+	i := 0
+	for _, field := range reflect.VisibleFields(dest.Type()) {
+		if !field.IsExported() || field.Anonymous || field.Tag.Get("move") == "-" {
+			continue
+		}
+		if i >= len(values) {
+			return fmt.Errorf("expected at least %d return values, got %d", i+1, len(values))
+		}
+		fieldValue, err := fieldByIndex(dest, field.Index)
+		if err != nil {
+			return fmt.Errorf("return value %d: %w", i, err)
+		}
+		err = decodeMoveValue(values[i], fieldValue)
+		if err != nil {
+			return fmt.Errorf("return value %d: %w", i, err)
+		}
+		i++
+	}
+	return nil
+}
+
+// DecodeMoveValue decodes a Move value from the JSON API, as parsed by encoding/json, into dest, which must be a pointer.
+//
+// Numbers may be decoded into any Go integer type, or a [big.Int], whether they were sent as JSON numbers (u8, u16, u32)
+// or strings (u64, u128, u256).  An address or Object may be decoded into an [AccountAddress], a vector<u8> into a
+// []byte, an Option into a pointer that is nil for none, and other vectors into slices.
+//
+// Move structs are decoded into Go structs by field name.  A Go field is matched to the Move field named by its `move`
+// tag, or else by its name ignoring case and underscores, e.g. CreationNum matches creation_num.  Move fields without a
+// matching Go field are ignored.  An [any] destination receives the value as is.
+//
+//	type coinInfo struct {
+//		Name     string
+//		Decimals uint8
+//		Supply   *big.Int `move:"supply"`
+//	}
+//	info := &coinInfo{}
+//	err := DecodeMoveValue(values[0], info)
+func DecodeMoveValue(value any, dest any) error {
+	destValue := reflect.ValueOf(dest)
+	if destValue.Kind() != reflect.Pointer || destValue.IsNil() {
+		return fmt.Errorf("destination must be a non-nil pointer, got %T", dest)
+	}
+	return decodeMoveValue(value, destValue.Elem())
+}
+
+var (
+	bigIntType         = reflect.TypeOf(big.Int{})
+	accountAddressType = reflect.TypeOf(AccountAddress{})
+	byteSliceType      = reflect.TypeOf([]byte{})
+)
+
+func decodeMoveValue(value any, dest reflect.Value) error {
+	switch dest.Type() {
+	case bigIntType:
+		num, err := moveNumberString(value)
+		if err != nil {
+			return err
+		}
+		if _, ok := dest.Addr().Interface().(*big.Int).SetString(num, 10); !ok {
+			return fmt.Errorf("invalid number %s", num)
+		}
+		return nil
+	case accountAddressType:
+		// An Object is a struct with the address as its inner field
+		if object, ok := value.(map[string]any); ok {
+			value = object["inner"]
+		}
+		str, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("expected an address, got %T", value)
+		}
+		address := dest.Addr().Interface().(*AccountAddress)
+		return address.ParseStringRelaxed(str)
+	case byteSliceType:
+		// A vector<u8> is a hex string
+		if str, ok := value.(string); ok {
+			bytes, err := ParseHex(str)
+			if err != nil {
+				return err
+			}
+			dest.SetBytes(bytes)
+			return nil
+		}
+	}
+
+	switch dest.Kind() {
+	case reflect.Interface:
+		if value == nil {
+			dest.SetZero()
+		} else if reflect.TypeOf(value).AssignableTo(dest.Type()) {
+			dest.Set(reflect.ValueOf(value))
+		} else {
+			return fmt.Errorf("cannot assign %T to %s", value, dest.Type())
+		}
+	case reflect.Pointer:
+		// An Option is a struct with a vector of zero or one values
+		if option, ok := value.(map[string]any); ok && len(option) == 1 {
+			if vec, ok := option["vec"].([]any); ok && len(vec) <= 1 {
+				if len(vec) == 0 {
+					dest.SetZero()
+					return nil
+				}
+				value = vec[0]
+			}
+		}
+		inner := reflect.New(dest.Type().Elem())
+		if err := decodeMoveValue(value, inner.Elem()); err != nil {
+			return err
+		}
+		dest.Set(inner)
+	case reflect.Bool:
+		b, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("expected a bool, got %T", value)
+		}
+		dest.SetBool(b)
+	case reflect.String:
+		str, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("expected a string, got %T", value)
+		}
+		dest.SetString(str)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		num, err := moveNumberString(value)
+		if err != nil {
+			return err
+		}
+		u, err := strconv.ParseUint(num, 10, dest.Type().Bits())
+		if err != nil {
+			return err
+		}
+		dest.SetUint(u)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		num, err := moveNumberString(value)
+		if err != nil {
+			return err
+		}
+		i, err := strconv.ParseInt(num, 10, dest.Type().Bits())
+		if err != nil {
+			return err
+		}
+		dest.SetInt(i)
+	case reflect.Slice:
+		vec, ok := value.([]any)
+		if !ok {
+			return fmt.Errorf("expected a vector, got %T", value)
+		}
+		slice := reflect.MakeSlice(dest.Type(), len(vec), len(vec))
+		for i, item := range vec {
+			if err := decodeMoveValue(item, slice.Index(i)); err != nil {
+				return fmt.Errorf("vector[%d]: %w", i, err)
+			}
+		}
+		dest.Set(slice)
+	case reflect.Struct:
+		fields, ok := value.(map[string]any)
+		if !ok {
+			return fmt.Errorf("expected a struct, got %T", value)
+		}
+		for _, field := range reflect.VisibleFields(dest.Type()) {
+			if !field.IsExported() || field.Anonymous {
+				continue
+			}
+			name, ok := moveFieldName(field, fields)
+			if !ok {
+				continue
+			}
+			fieldValue, err := fieldByIndex(dest, field.Index)
+			if err != nil {
+				return fmt.Errorf("field %s: %w", name, err)
+			}
+			if err := decodeMoveValue(fields[name], fieldValue); err != nil {
+				return fmt.Errorf("field %s: %w", name, err)
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported destination type %s", dest.Type())
+	}
+	return nil
+}
+
+// moveNumberString returns the decimal string of a number, which is a string for u64 and larger
+func moveNumberString(value any) (string, error) {
+	switch num := value.(type) {
+	case string:
+		return num, nil
+	case json.Number:
+		return num.String(), nil
+	case float64:
+		return strconv.FormatFloat(num, 'f', -1, 64), nil
+	default:
+		return "", fmt.Errorf("expected a number, got %T", value)
+	}
+}
+
+// fieldByIndex is [reflect.Value.FieldByIndex], but allocates nil pointers to embedded structs on the way, as
+// encoding/json does, rather than panicking
+func fieldByIndex(dest reflect.Value, index []int) (reflect.Value, error) {
+	for i, x := range index {
+		if i > 0 && dest.Kind() == reflect.Pointer {
+			if dest.IsNil() {
+				if !dest.CanSet() {
+					return reflect.Value{}, fmt.Errorf("cannot set embedded pointer to unexported struct %s", dest.Type().Elem())
+				}
+				dest.Set(reflect.New(dest.Type().Elem()))
+			}
+			dest = dest.Elem()
+		}
+		dest = dest.Field(x)
+	}
+	return dest, nil
+}
+
+// moveFieldName finds the Move field matching a Go struct field
+func moveFieldName(field reflect.StructField, fields map[string]any) (string, bool) {
+	if tag := field.Tag.Get("move"); tag != "" {
+		if tag == "-" {
+			return "", false
+		}
+		_, ok := fields[tag]
+		return tag, ok
+	}
+	for name := range fields {
+		if strings.EqualFold(strings.ReplaceAll(name, "_", ""), field.Name) {
+			return name, true
+		}
+	}
+	return "", false
+}
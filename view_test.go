@@ -0,0 +1,154 @@
+package aptos
+
+import (
+	"encoding/json"
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDecodeMoveValue(t *testing.T) {
+	type metadata struct {
+		Name        string
+		Decimals    uint8
+		IconUri     string `move:"icon_uri"`
+		Ignored     string `move:"-"`
+		CreationNum uint64
+	}
+	type result struct {
+		Metadata metadata
+		Supply   *big.Int
+		Maximum  *big.Int
+		Store    AccountAddress
+		Owner    *AccountAddress
+		Amounts  []uint64
+		Bytes    []byte
+		Frozen   bool
+		Raw      any
+	}
+
+	var value any
+	err := json.Unmarshal([]byte(`{
+		"metadata": {"name": "Aptos Coin", "decimals": 8, "icon_uri": "https://aptos.dev", "ignored": "x", "creation_num": "12", "extra": true},
+		"supply": "340282366920938463463374607431768211455",
+		"maximum": {"vec": []},
+		"store": {"inner": "0xa"},
+		"owner": {"vec": ["0x1"]},
+		"amounts": ["1", "18446744073709551615"],
+		"bytes": "0x0102",
+		"frozen": true,
+		"raw": {"a": 1}
+	}`), &value)
+	assert.NoError(t, err)
+
+	out := &result{}
+	assert.NoError(t, DecodeMoveValue(value, out))
+	assert.Equal(t, metadata{Name: "Aptos Coin", Decimals: 8, IconUri: "https://aptos.dev", CreationNum: 12}, out.Metadata)
+	expectedSupply, _ := new(big.Int).SetString("340282366920938463463374607431768211455", 10)
+	assert.Equal(t, expectedSupply, out.Supply)
+	assert.Nil(t, out.Maximum)
+	assert.Equal(t, AccountAddress{31: 0xa}, out.Store)
+	assert.Equal(t, &AccountOne, out.Owner)
+	assert.Equal(t, []uint64{1, 18446744073709551615}, out.Amounts)
+	assert.Equal(t, []byte{1, 2}, out.Bytes)
+	assert.True(t, out.Frozen)
+	assert.Equal(t, map[string]any{"a": float64(1)}, out.Raw)
+
+	// Mismatched types and overflows are errors
+	var small uint8
+	assert.Error(t, DecodeMoveValue("256", &small))
+	var str string
+	assert.Error(t, DecodeMoveValue(float64(1), &str))
+	assert.Error(t, DecodeMoveValue("1", small))
+
+	// Nil pointers to embedded structs are allocated, unless they can't be set
+	type Inner struct {
+		B uint64
+	}
+	embedded := &struct {
+		A uint64
+		*Inner
+	}{}
+	assert.NoError(t, DecodeMoveValue(map[string]any{"A": "1", "B": "2"}, embedded))
+	assert.Equal(t, uint64(1), embedded.A)
+	assert.Equal(t, &Inner{B: 2}, embedded.Inner)
+	type inner struct {
+		B uint64
+	}
+	unexported := &struct {
+		A uint64
+		*inner
+	}{}
+	assert.Error(t, DecodeMoveValue(map[string]any{"A": "1", "B": "2"}, unexported))
+	assert.NoError(t, DecodeMoveValue(map[string]any{"A": "1"}, unexported))
+	assert.Nil(t, unexported.inner)
+}
+
+func TestViewInto(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/view", r.URL.Path)
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		payload, err := bcs.Serialize(&ViewPayload{Module: ModuleId{Address: AccountOne, Name: "test"}, Function: "single", ArgTypes: []TypeTag{}, Args: [][]byte{}})
+		assert.NoError(t, err)
+		if r.Header.Get("Accept") == "application/x-bcs" {
+			// A BCS sequence of BCS encoded return values
+			response, err := bcs.SerializeSingle(func(ser *bcs.Serializer) {
+				ser.Uleb128(2)
+				ser.WriteBytes([]byte{5, 0, 0, 0, 0, 0, 0, 0})
+				ser.WriteBytes([]byte{1})
+			})
+			assert.NoError(t, err)
+			_, _ = w.Write(response)
+		} else if string(body) == string(payload) {
+			_, _ = w.Write([]byte(`["18446744073709551615"]`))
+		} else {
+			_, _ = w.Write([]byte(`["5", 7, {"vec": ["0x1"]}]`))
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewNodeClient(server.URL+"/v1", 4)
+	assert.NoError(t, err)
+
+	single, err := ViewInto[uint64](client, &ViewPayload{Module: ModuleId{Address: AccountOne, Name: "test"}, Function: "single", ArgTypes: []TypeTag{}, Args: [][]byte{}})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(18446744073709551615), single)
+
+	type multiple struct {
+		Balance uint64
+		Skipped string `move:"-"`
+		Count   int
+		Owner   *AccountAddress
+	}
+	payload := &ViewPayload{Module: ModuleId{Address: AccountOne, Name: "test"}, Function: "multiple", ArgTypes: []TypeTag{}, Args: [][]byte{}}
+	result, err := ViewInto[multiple](client, payload)
+	assert.NoError(t, err)
+	assert.Equal(t, multiple{Balance: 5, Count: 7, Owner: &AccountOne}, result)
+
+	// Fields of a nil embedded struct pointer are filled in
+	type Owned struct {
+		Count int
+		Owner *AccountAddress
+	}
+	type embedded struct {
+		Balance uint64
+		*Owned
+	}
+	embeddedResult, err := ViewInto[embedded](client, payload)
+	assert.NoError(t, err)
+	assert.Equal(t, embedded{Balance: 5, Owned: &Owned{Count: 7, Owner: &AccountOne}}, embeddedResult)
+
+	// A single value can't be decoded from several
+	_, err = ViewInto[uint64](client, payload)
+	assert.Error(t, err)
+
+	values, err := client.ViewBCS(payload)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{{5, 0, 0, 0, 0, 0, 0, 0}, {1}}, values)
+	assert.Equal(t, uint64(5), bcs.NewDeserializer(values[0]).U64())
+}
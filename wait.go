@@ -0,0 +1,156 @@
+package aptos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/aptos-labs/aptos-go-sdk/api"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// ErrTransactionExpired is returned by [NodeClient.WaitForTransaction] in [WaitModeCommitted] when the ledger has passed
+// the transaction's expiration without committing it, so it can never be committed
+var ErrTransactionExpired = errors.New("transaction expired before it was committed")
+
+// TransactionFailedError is returned by [NodeClient.WaitForTransaction] in [WaitModeCommitted] when the transaction was
+// committed but failed, e.g. with a Move abort.  The transaction still used its sequence number and was charged gas.
+//
+//	var failed *TransactionFailedError
+//	if errors.As(err, &failed) {
+//		fmt.Printf("failed at version %d: %s\n", failed.Transaction.Version, failed.VmStatus)
+//	}
+type TransactionFailedError struct {
+	Transaction *api.UserTransaction
+	VmStatus    string
+}
+
+func (e *TransactionFailedError) Error() string {
+	return fmt.Sprintf("transaction %s failed at version %d: %s", e.Transaction.Hash, e.Transaction.Version, e.VmStatus)
+}
+
+// WaitMode is an option to WaitForTransaction, choosing how to wait for the transaction
+type WaitMode uint8
+
+const (
+	// WaitModePoll polls for the transaction until PollTimeout, returning it whether or not it succeeded.  This is the
+	// default.
+	WaitModePoll WaitMode = iota
+	// WaitModeCommitted waits until the transaction is committed or has expired, using the node's long-poll endpoint if
+	// it has one.  A failed transaction is a [TransactionFailedError], and an expired one is [ErrTransactionExpired].
+	WaitModeCommitted
+)
+
+// TransactionExpiration is an option to WaitForTransaction in [WaitModeCommitted], the ExpirationTimestampSeconds of
+// the transaction.  It allows expiration to be detected even if the node never saw the transaction.
+type TransactionExpiration uint64
+
+// getWaitOptions reads the options to WaitForTransaction
+func getWaitOptions(options ...any) (period time.Duration, timeout time.Duration, mode WaitMode, expiration uint64, err error) {
+	period = 100 * time.Millisecond
+	timeout = 10 * time.Second
+	for i, arg := range options {
+		switch value := arg.(type) {
+		case PollPeriod:
+			period = time.Duration(value)
+		case PollTimeout:
+			timeout = time.Duration(value)
+		case WaitMode:
+			mode = value
+		case TransactionExpiration:
+			expiration = uint64(value)
+		default:
+			err = fmt.Errorf("WaitForTransaction arg %d bad type %T", i+1, arg)
+			return
+		}
+	}
+	if expiration != 0 && mode != WaitModeCommitted {
+		err = errors.New("WaitForTransaction TransactionExpiration requires WaitModeCommitted")
+	}
+	return
+}
+
+// waitForCommittedTransaction waits for the transaction to be committed or expire, see [WaitModeCommitted].
+//
+// A node that doesn't know the transaction may not have received it yet, so it is polled until the expiration is
+// known, either from the option or from the node returning it pending, and then until the ledger passes the expiration.
+// If the expiration is never known, the transaction is not found after the timeout.
+func (rc *NodeClient) waitForCommittedTransaction(ctx context.Context, hash string, period time.Duration, timeout time.Duration, expiration uint64) (*api.UserTransaction, error) {
+	ledger := &LedgerInfo{}
+	ledgerCtx := WithLedgerInfo(ctx, ledger)
+	longPoll := true
+	notFoundSince := time.Now()
+	for {
+		var txn *api.Transaction
+		var err error
+		start := time.Now()
+		if longPoll {
+			txn, err = rc.getTransactionCommon(ledgerCtx, rc.baseUrl.JoinPath("transactions/wait_by_hash", hash))
+			if waitByHashUnavailable(err) {
+				slog.Debug("wait_by_hash unavailable, polling by hash", "hash", hash)
+				longPoll = false
+				continue
+			}
+		} else {
+			txn, err = rc.TransactionByHashCtx(ledgerCtx, hash)
+		}
+
+		switch {
+		case err == nil && txn.Type == api.TransactionVariantPendingTransaction:
+			pending, _ := txn.PendingTransaction()
+			expiration = pending.ExpirationTimestampSecs
+			notFoundSince = time.Now()
+		case err == nil && txn.Type == api.TransactionVariantUserTransaction:
+			userTxn, _ := txn.UserTransaction()
+			if !userTxn.Success {
+				return userTxn, &TransactionFailedError{Transaction: userTxn, VmStatus: userTxn.VmStatus}
+			}
+			return userTxn, nil
+		case err == nil:
+			return nil, fmt.Errorf("transaction %s is a %s, not a user transaction", hash, txn.Type)
+		case errors.Is(err, ErrTransactionNotFound):
+			// Either not propagated to the node yet, or dropped from mempool once expired
+			if expiration == 0 && time.Since(notFoundSince) > timeout {
+				return nil, fmt.Errorf("transaction %s not found after %s: %w", hash, timeout, err)
+			}
+		default:
+			return nil, err
+		}
+
+		// A transaction can only be committed in a block with a timestamp before its expiration
+		if expiration != 0 && ledger.LedgerTimestampUsec >= expiration*1_000_000 {
+			return nil, fmt.Errorf("%w: transaction %s expired at %d, ledger is at %s", ErrTransactionExpired, hash,
+				expiration, time.UnixMicro(int64(ledger.LedgerTimestampUsec)).UTC().Format(time.RFC3339))
+		}
+
+		// The long-poll already waited on a pending transaction, unless the node answered right away e.g. at its limit
+		// of long-poll connections, so wait out the rest of the period
+		wait := period
+		if longPoll && err == nil {
+			wait = period - time.Since(start)
+			if wait <= 0 {
+				continue
+			}
+		}
+		err = sleepCtx(ctx, wait)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// waitByHashUnavailable tells whether the node doesn't serve /transactions/wait_by_hash, as opposed to not finding the
+// transaction
+func waitByHashUnavailable(err error) bool {
+	var httpErr *HttpError
+	if !errors.As(err, &httpErr) || httpErr.ApiError != nil {
+		return false
+	}
+	switch httpErr.StatusCode {
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,250 @@
+package aptos
+
+import (
+	"fmt"
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+)
+
+//region WriteSet
+
+// WriteSetVariantV0 is the only version of WriteSet
+const WriteSetVariantV0 uint32 = 0
+
+// WriteSet is the set of state changes made by a transaction, ordered by StateKey
+// Implements bcs.Struct
+type WriteSet struct {
+	Changes []WriteSetChange
+}
+
+// WriteSetChange is a single write to on-chain state
+type WriteSetChange struct {
+	StateKey StateKey
+	WriteOp  WriteOp
+}
+
+//region WriteSet bcs.Struct
+
+func (ws *WriteSet) MarshalBCS(ser *bcs.Serializer) {
+	ser.Uleb128(WriteSetVariantV0)
+	bcs.SerializeSequenceWithFunction(ws.Changes, ser, func(ser *bcs.Serializer, change WriteSetChange) {
+		ser.Struct(&change.StateKey)
+		ser.Struct(&change.WriteOp)
+	})
+}
+
+func (ws *WriteSet) UnmarshalBCS(des *bcs.Deserializer) {
+	variant := des.Uleb128()
+	if des.Error() != nil {
+		return
+	}
+	if variant != WriteSetVariantV0 {
+		des.SetError(fmt.Errorf("unknown WriteSet variant %d", variant))
+		return
+	}
+	ws.Changes = bcs.DeserializeSequenceWithFunction(des, func(des *bcs.Deserializer, change *WriteSetChange) {
+		des.Struct(&change.StateKey)
+		des.Struct(&change.WriteOp)
+	})
+}
+
+//endregion
+//endregion
+
+//region StateKey
+
+type StateKeyVariant uint32
+
+const (
+	StateKeyVariantAccessPath StateKeyVariant = 0 // A resource or module under an account
+	StateKeyVariantTableItem  StateKeyVariant = 1 // An item of a Move table
+	StateKeyVariantRaw        StateKeyVariant = 2 // Raw bytes, used only by the system
+)
+
+// StateKey identifies a location in on-chain state.  Which fields are set depends on the Variant:
+//
+//   - StateKeyVariantAccessPath sets Address and Path, the BCS encoded path of a resource or module
+//   - StateKeyVariantTableItem sets Handle and Key, the BCS encoded key of the table item
+//   - StateKeyVariantRaw sets Raw
+//
+// Implements bcs.Struct
+type StateKey struct {
+	Variant StateKeyVariant
+	Address AccountAddress
+	Path    []byte
+	Handle  AccountAddress
+	Key     []byte
+	Raw     []byte
+}
+
+//region StateKey bcs.Struct
+
+func (key *StateKey) MarshalBCS(ser *bcs.Serializer) {
+	ser.Uleb128(uint32(key.Variant))
+	switch key.Variant {
+	case StateKeyVariantAccessPath:
+		ser.Struct(&key.Address)
+		ser.WriteBytes(key.Path)
+	case StateKeyVariantTableItem:
+		ser.Struct(&key.Handle)
+		ser.WriteBytes(key.Key)
+	case StateKeyVariantRaw:
+		ser.WriteBytes(key.Raw)
+	default:
+		ser.SetError(fmt.Errorf("unknown StateKey variant %d", key.Variant))
+	}
+}
+
+func (key *StateKey) UnmarshalBCS(des *bcs.Deserializer) {
+	key.Variant = StateKeyVariant(des.Uleb128())
+	if des.Error() != nil {
+		return
+	}
+	switch key.Variant {
+	case StateKeyVariantAccessPath:
+		des.Struct(&key.Address)
+		key.Path = des.ReadBytes()
+	case StateKeyVariantTableItem:
+		des.Struct(&key.Handle)
+		key.Key = des.ReadBytes()
+	case StateKeyVariantRaw:
+		key.Raw = des.ReadBytes()
+	default:
+		des.SetError(fmt.Errorf("unknown StateKey variant %d", key.Variant))
+	}
+}
+
+//endregion
+//endregion
+
+//region WriteOp
+
+type WriteOpVariant uint32
+
+const (
+	WriteOpVariantCreation                 WriteOpVariant = 0
+	WriteOpVariantModification             WriteOpVariant = 1
+	WriteOpVariantDeletion                 WriteOpVariant = 2
+	WriteOpVariantCreationWithMetadata     WriteOpVariant = 3
+	WriteOpVariantModificationWithMetadata WriteOpVariant = 4
+	WriteOpVariantDeletionWithMetadata     WriteOpVariant = 5
+)
+
+// WriteOp is a write to a StateKey.  Data is the new BCS encoded value, and is unset for deletions.  Metadata is set only
+// for the WithMetadata variants.
+// Implements bcs.Struct
+type WriteOp struct {
+	Variant  WriteOpVariant
+	Data     []byte
+	Metadata *StateValueMetadata
+}
+
+// IsDeletion returns true if the write removes the value
+func (op *WriteOp) IsDeletion() bool {
+	return op.Variant == WriteOpVariantDeletion || op.Variant == WriteOpVariantDeletionWithMetadata
+}
+
+//region WriteOp bcs.Struct
+
+func (op *WriteOp) MarshalBCS(ser *bcs.Serializer) {
+	ser.Uleb128(uint32(op.Variant))
+	switch op.Variant {
+	case WriteOpVariantCreation, WriteOpVariantModification:
+		ser.WriteBytes(op.Data)
+	case WriteOpVariantDeletion:
+	case WriteOpVariantCreationWithMetadata, WriteOpVariantModificationWithMetadata:
+		ser.WriteBytes(op.Data)
+		op.marshalMetadata(ser)
+	case WriteOpVariantDeletionWithMetadata:
+		op.marshalMetadata(ser)
+	default:
+		ser.SetError(fmt.Errorf("unknown WriteOp variant %d", op.Variant))
+	}
+}
+
+func (op *WriteOp) marshalMetadata(ser *bcs.Serializer) {
+	if op.Metadata == nil {
+		ser.SetError(fmt.Errorf("nil metadata for WriteOp variant %d", op.Variant))
+		return
+	}
+	ser.Struct(op.Metadata)
+}
+
+func (op *WriteOp) UnmarshalBCS(des *bcs.Deserializer) {
+	op.Variant = WriteOpVariant(des.Uleb128())
+	if des.Error() != nil {
+		return
+	}
+	switch op.Variant {
+	case WriteOpVariantCreation, WriteOpVariantModification:
+		op.Data = des.ReadBytes()
+	case WriteOpVariantDeletion:
+	case WriteOpVariantCreationWithMetadata, WriteOpVariantModificationWithMetadata:
+		op.Data = des.ReadBytes()
+		op.Metadata = &StateValueMetadata{}
+		des.Struct(op.Metadata)
+	case WriteOpVariantDeletionWithMetadata:
+		op.Metadata = &StateValueMetadata{}
+		des.Struct(op.Metadata)
+	default:
+		des.SetError(fmt.Errorf("unknown WriteOp variant %d", op.Variant))
+	}
+}
+
+//endregion
+//endregion
+
+//region StateValueMetadata
+
+type StateValueMetadataVariant uint32
+
+const (
+	StateValueMetadataVariantV0 StateValueMetadataVariant = 0 // Single deposit, stored in SlotDeposit
+	StateValueMetadataVariantV1 StateValueMetadataVariant = 1 // Separate slot and bytes deposits
+)
+
+// StateValueMetadata is the storage deposit and creation time of a state value
+// Implements bcs.Struct
+type StateValueMetadata struct {
+	Variant           StateValueMetadataVariant
+	SlotDeposit       uint64
+	BytesDeposit      uint64
+	CreationTimeUsecs uint64
+}
+
+//region StateValueMetadata bcs.Struct
+
+func (md *StateValueMetadata) MarshalBCS(ser *bcs.Serializer) {
+	ser.Uleb128(uint32(md.Variant))
+	switch md.Variant {
+	case StateValueMetadataVariantV0:
+		ser.U64(md.SlotDeposit)
+	case StateValueMetadataVariantV1:
+		ser.U64(md.SlotDeposit)
+		ser.U64(md.BytesDeposit)
+	default:
+		ser.SetError(fmt.Errorf("unknown StateValueMetadata variant %d", md.Variant))
+		return
+	}
+	ser.U64(md.CreationTimeUsecs)
+}
+
+func (md *StateValueMetadata) UnmarshalBCS(des *bcs.Deserializer) {
+	md.Variant = StateValueMetadataVariant(des.Uleb128())
+	if des.Error() != nil {
+		return
+	}
+	switch md.Variant {
+	case StateValueMetadataVariantV0:
+		md.SlotDeposit = des.U64()
+	case StateValueMetadataVariantV1:
+		md.SlotDeposit = des.U64()
+		md.BytesDeposit = des.U64()
+	default:
+		des.SetError(fmt.Errorf("unknown StateValueMetadata variant %d", md.Variant))
+		return
+	}
+	md.CreationTimeUsecs = des.U64()
+}
+
+//endregion
+//endregion